@@ -624,6 +624,8 @@ var MigrationCaveatsIssues = []string{
 func convertIssueInstanceToAnalyzeIssue(issueInstance queryissue.QueryIssue, fileName string, isPlPgSQLIssue bool) utils.AnalyzeSchemaIssue {
 	issueType := UNSUPPORTED_FEATURES_CATEGORY
 	switch true {
+	case queryissue.IsCustomRuleIssue(issueInstance):
+		issueType = CUSTOM_RULES_CATEGORY
 	case isPlPgSQLIssue:
 		issueType = UNSUPPORTED_PLPGSQL_OBJECTS_CATEGORY
 	case slices.ContainsFunc(MigrationCaveatsIssues, func(i string) bool {
@@ -670,7 +672,10 @@ func convertIssueInstanceToAnalyzeIssue(issueInstance queryissue.QueryIssue, fil
 		displayObjectName = fmt.Sprintf("%s, constraint: (%s)", issueInstance.ObjectName, constraintName)
 	}
 
-	summaryMap[issueInstance.ObjectType].invalidCount[issueInstance.ObjectName] = true
+	// custom rules flag the organisation specific anti-patterns, object is still valid for YugabyteDB
+	if issueType != CUSTOM_RULES_CATEGORY {
+		summaryMap[issueInstance.ObjectType].invalidCount[issueInstance.ObjectName] = true
+	}
 
 	return utils.AnalyzeSchemaIssue{
 		IssueType:              issueType,
@@ -680,6 +685,7 @@ func convertIssueInstanceToAnalyzeIssue(issueInstance queryissue.QueryIssue, fil
 		Type:                   issueInstance.Type,
 		Impact:                 issueInstance.Impact,
 		SqlStatement:           issueInstance.SqlStatement,
+		IsDynamicSQL:           queryissue.IsDynamicSQLIssue(issueInstance),
 		DocsLink:               issueInstance.DocsLink,
 		FilePath:               fileName,
 		Suggestion:             issueInstance.Suggestion,
@@ -1133,6 +1139,7 @@ func analyzeSchema() {
 	utils.PrintAndLog("Analyzing schema for target YugabyteDB version %s\n", targetDbVersion)
 	schemaAnalysisStartedEvent := createSchemaAnalysisStartedEvent()
 	controlPlane.SchemaAnalysisStarted(&schemaAnalysisStartedEvent)
+	recordPhaseStarted("ANALYZE SCHEMA", false)
 
 	if !schemaIsExported() {
 		utils.ErrExit("run export schema before running analyze-schema")
@@ -1155,6 +1162,7 @@ func analyzeSchema() {
 
 	schemaAnalysisReport := createSchemaAnalysisIterationCompletedEvent(schemaAnalysisReport)
 	controlPlane.SchemaAnalysisIterationCompleted(&schemaAnalysisReport)
+	recordPhaseCompleted("ANALYZE SCHEMA")
 }
 
 func generateAnalyzeSchemaReport(msr *metadb.MigrationStatusRecord, reportFormat string) (err error) {
@@ -1296,6 +1304,10 @@ var analyzeSchemaCmd = &cobra.Command{
 		if err != nil {
 			utils.ErrExit("%v", err)
 		}
+		err = loadCustomRulesFromFileFlag()
+		if err != nil {
+			utils.ErrExit("%v", err)
+		}
 	},
 
 	Run: func(cmd *cobra.Command, args []string) {
@@ -1311,6 +1323,21 @@ func init() {
 
 	analyzeSchemaCmd.Flags().StringVar(&targetDbVersionStrFlag, "target-db-version", "",
 		fmt.Sprintf("Target YugabyteDB version to analyze schema for (in format A.B.C.D). Defaults to latest stable version (%s)", ybversion.LatestStable.String()))
+
+	analyzeSchemaCmd.Flags().StringVar(&customRulesFileFlag, "custom-rules-file", "",
+		"Path of the YAML file containing user-defined rules to report as issues along with the built-in ones (optional)")
+}
+
+func loadCustomRulesFromFileFlag() error {
+	if customRulesFileFlag == "" {
+		return nil
+	}
+	customRules, err := queryissue.LoadCustomRules(customRulesFileFlag)
+	if err != nil {
+		return fmt.Errorf("failed to load custom rules: %w", err)
+	}
+	parserIssueDetector.SetCustomRules(customRules)
+	return nil
 }
 
 func validateReportOutputFormat(validOutputFormats []string, format string) {
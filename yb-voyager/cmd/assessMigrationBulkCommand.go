@@ -17,17 +17,24 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	_ "embed"
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"text/template"
+	"time"
 
+	"github.com/samber/lo"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
@@ -37,6 +44,9 @@ import (
 )
 
 var bulkAssessmentDir string
+var bulkAssessmentParallelJobs int
+var bulkAssessmentTimeoutInMin int
+var bulkAssessmentMaxRetries int
 var fleetConfigPath string
 var continueOnError utils.BoolStr
 var bulkAssessmentReport BulkAssessmentReport
@@ -52,6 +62,14 @@ var assessMigrationBulkCmd = &cobra.Command{
 		if err != nil {
 			utils.ErrExit("validating fleet config file: %s", err.Error())
 		}
+		if bulkAssessmentParallelJobs < 1 || bulkAssessmentTimeoutInMin < 0 || bulkAssessmentMaxRetries < 0 {
+			utils.ErrExit("--parallel-jobs should be at least 1, --assessment-timeout and --max-retries should not be negative")
+		}
+		// validating here to fail fast instead of failing the assessment of every schema
+		err = loadMigrationEffortCostModelFromFileFlag()
+		if err != nil {
+			utils.ErrExit("%v", err)
+		}
 	},
 
 	Run: func(cmd *cobra.Command, args []string) {
@@ -120,6 +138,15 @@ Sample fleet_config_file:
 	BoolVar(assessMigrationBulkCmd.Flags(), &continueOnError, "continue-on-error", true, "If true, it will print the error message on console and continue to next schema’s assessment")
 	assessMigrationBulkCmd.Flags().StringVar(&bulkAssessmentDir, "bulk-assessment-dir", "", "Top-level directory storing the export-dir of each schema")
 	BoolVar(assessMigrationBulkCmd.Flags(), &startClean, "start-clean", false, "Cleans up all the export-dirs in bulk assessment directory to start everything from scratch")
+	assessMigrationBulkCmd.Flags().IntVar(&bulkAssessmentParallelJobs, "parallel-jobs", 1,
+		"Number of schemas to assess in parallel. With more than 1, the passwords must be provided in the fleet config file "+
+			"and the output of each assessment is written to a file in the logs directory of the bulk-assessment-dir")
+	assessMigrationBulkCmd.Flags().IntVar(&bulkAssessmentTimeoutInMin, "assessment-timeout", 0,
+		"Time (in minutes) after which the assessment of a schema is aborted. 0 means no timeout")
+	assessMigrationBulkCmd.Flags().IntVar(&bulkAssessmentMaxRetries, "max-retries", 0,
+		"Number of times to retry the assessment of a schema if it fails or times out")
+	assessMigrationBulkCmd.Flags().StringVar(&effortCostModelFileFlag, "effort-cost-model-file", "",
+		"Path of the YAML file with the cost model to use for the migration effort estimate of each schema (optional)")
 
 	// marking mandatory flags
 	assessMigrationBulkCmd.MarkFlagRequired("fleet-config-file")
@@ -161,34 +188,77 @@ func assessMigrationBulk() error {
 		return fmt.Errorf("failed to get migration UUID: %w", err)
 	}
 
-	for _, dbConfig := range bulkAssessmentDBConfigs {
-		utils.PrintAndLog("\nAssessing '%s' schema", dbConfig.GetSchemaIdentifier())
+	err = initBulkAssessmentStatus(bulkAssessmentDBConfigs)
+	if err != nil {
+		return fmt.Errorf("failed to initialise bulk assessment status: %w", err)
+	}
 
-		if isMigrationAssessmentDoneForConfig(dbConfig) {
+	// generating the report upfront and after every assessment so that it reflects the progress even if the command is interrupted
+	err = generateBulkAssessmentReport(bulkAssessmentDBConfigs)
+	if err != nil {
+		return fmt.Errorf("failed to generate bulk assessment report: %w", err)
+	}
+
+	bulkAssessmentStatus := readBulkAssessmentStatus()
+	pendingDBConfigs := lo.Filter(bulkAssessmentDBConfigs, func(dbConfig AssessMigrationDBConfig, _ int) bool {
+		if bulkAssessmentStatus.GetRowStatus(dbConfig) == COMPLETE {
 			utils.PrintAndLog("assessment report for schema %s already exists, skipping...", dbConfig.GetSchemaIdentifier())
-			continue
+			return false
 		}
-
-		err = executeAssessment(dbConfig)
-		if err != nil {
-			log.Errorf("failed to assess migration for schema %s: %v", dbConfig.GetSchemaIdentifier(), err)
-			fmt.Printf("failed to assess migration for schema %s: %v\n", dbConfig.GetSchemaIdentifier(), err)
-			log.Infof("For detailed information on the '%s' schema assessment, please refer to the corresponding log file at: %s\n",
-				dbConfig.GetSchemaIdentifier(), dbConfig.GetAssessmentLogFilePath())
-			if !continueOnError {
-				break
+		return true
+	})
+	if bulkAssessmentParallelJobs > 1 {
+		// the assessments running in parallel can't prompt for the passwords
+		for _, dbConfig := range pendingDBConfigs {
+			if dbConfig.Password == "" {
+				return fmt.Errorf("password for schema %s is not provided in the fleet config file, required with --parallel-jobs > 1",
+					dbConfig.GetSchemaIdentifier())
 			}
-		} else {
-			log.Infof("For detailed information on the '%s' schema assessment, please refer to the corresponding log file at: %s\n",
-				dbConfig.GetSchemaIdentifier(), dbConfig.GetAssessmentLogFilePath())
 		}
+	}
+
+	utils.PrintAndLog("assessing %d of the %d schema(s) with %d parallel job(s)", len(pendingDBConfigs), len(bulkAssessmentDBConfigs), bulkAssessmentParallelJobs)
+	var stopAssessments atomic.Bool
+	var reportMutex sync.Mutex
+	dbConfigsCh := make(chan AssessMigrationDBConfig)
+	var wg sync.WaitGroup
+	for i := 0; i < bulkAssessmentParallelJobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for dbConfig := range dbConfigsCh {
+				err := assessWithRetries(dbConfig)
+				if err != nil && !continueOnError {
+					stopAssessments.Store(true)
+				}
+
+				reportMutex.Lock()
+				err = generateBulkAssessmentReport(bulkAssessmentDBConfigs)
+				reportMutex.Unlock()
+				if err != nil {
+					log.Errorf("failed to generate bulk assessment report: %v", err)
+				}
+			}
+		}()
+	}
 
+	for _, dbConfig := range pendingDBConfigs {
 		if ProcessShutdownRequested {
 			log.Info("Exiting from assess-migration-bulk. Further assessments will not be executed due to a shutdown request.")
-			return nil
+			break
+		}
+		if stopAssessments.Load() {
+			log.Info("Further assessments will not be executed since an assessment failed and --continue-on-error is false.")
+			break
 		}
+		dbConfigsCh <- dbConfig
 	}
+	close(dbConfigsCh)
+	wg.Wait()
 
+	if ProcessShutdownRequested {
+		return nil
+	}
 	err = generateBulkAssessmentReport(bulkAssessmentDBConfigs)
 	if err != nil {
 		return fmt.Errorf("failed to generate bulk assessment report: %w", err)
@@ -196,6 +266,47 @@ func assessMigrationBulk() error {
 	return nil
 }
 
+// assessWithRetries runs the assessment of the fleet config row, retrying on failure, and persists its status
+func assessWithRetries(dbConfig AssessMigrationDBConfig) error {
+	schemaIdentifier := dbConfig.GetSchemaIdentifier()
+	var err error
+	for attempt := 0; attempt <= bulkAssessmentMaxRetries; attempt++ {
+		if attempt > 0 {
+			utils.PrintAndLog("retrying assessment of schema %s (retry %d of %d)", schemaIdentifier, attempt, bulkAssessmentMaxRetries)
+		} else {
+			utils.PrintAndLog("\nAssessing '%s' schema", schemaIdentifier)
+		}
+		statusErr := markBulkAssessmentStarted(schemaIdentifier)
+		if statusErr != nil {
+			log.Errorf("%v", statusErr)
+		}
+
+		err = executeAssessment(dbConfig)
+		log.Infof("For detailed information on the '%s' schema assessment, please refer to the corresponding log file at: %s\n",
+			schemaIdentifier, dbConfig.GetAssessmentLogFilePath())
+		if ProcessShutdownRequested {
+			// leaving the status as IN-PROGRESS so that it is resumed in the next run
+			return err
+		}
+		if err == nil {
+			statusErr = markBulkAssessmentFinished(schemaIdentifier, COMPLETE, nil)
+			if statusErr != nil {
+				log.Errorf("%v", statusErr)
+			}
+			return nil
+		}
+
+		log.Errorf("failed to assess migration for schema %s: %v", schemaIdentifier, err)
+		fmt.Printf("failed to assess migration for schema %s: %v\n", schemaIdentifier, err)
+		status := lo.Ternary(errors.Is(err, context.DeadlineExceeded), BULK_ASSESSMENT_TIMED_OUT, ERROR)
+		statusErr = markBulkAssessmentFinished(schemaIdentifier, status, err)
+		if statusErr != nil {
+			log.Errorf("%v", statusErr)
+		}
+	}
+	return err
+}
+
 func executeAssessment(dbConfig AssessMigrationDBConfig) error {
 	log.Infof("executing assessment for schema %q", dbConfig.GetSchemaIdentifier())
 	exportDirPath := dbConfig.GetAssessmentExportDirPath()
@@ -204,15 +315,48 @@ func executeAssessment(dbConfig AssessMigrationDBConfig) error {
 		return fmt.Errorf("creating export-directory %q for schema %q: %w", exportDirPath, dbConfig.GetSchemaIdentifier(), err)
 	}
 
-	execCmd := exec.Command(os.Args[0], cmdArgs...)
-	// password either has to be provided via fleet_config_file or can be provided at run-time by the user.
+	ctx := context.Background()
+	if bulkAssessmentTimeoutInMin > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(bulkAssessmentTimeoutInMin)*time.Minute)
+		defer cancel()
+	}
+	execCmd := exec.CommandContext(ctx, os.Args[0], cmdArgs...)
+	interactive := bulkAssessmentParallelJobs == 1 && !utils.DoNotPrompt
+	if !interactive {
+		// assess-migration runs the gather scripts(psql, sqlplus, ora2pg, ...) as its own children. Running it in its
+		// own process group lets the timeout kill the whole tree instead of leaving the gather scripts running.
+		// Interactive assessments stay in the foreground process group to read the prompts from the console,
+		// and on Ctrl-C the children are shut down by CleanupChildProcesses() in either case.
+		execCmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+		execCmd.Cancel = func() error {
+			return syscall.Kill(-execCmd.Process.Pid, syscall.SIGKILL)
+		}
+	}
+	// password either has to be provided via fleet_config_file, the --secret-source or can be provided at run-time by the user.
 	// user setting the env var route is not supported for assess-migration-bulk command
 	execCmd.Env = append(os.Environ(), "SOURCE_DB_PASSWORD="+dbConfig.Password)
-	execCmd.Stdout = os.Stdout
-	execCmd.Stderr = os.Stderr
-	execCmd.Stdin = os.Stdin
+	if bulkAssessmentParallelJobs > 1 {
+		// output of the parallel assessments would be interleaved on the console
+		outputFilePath := dbConfig.GetAssessmentOutputFilePath()
+		outputFile, err := os.OpenFile(outputFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("opening output file %q for schema %q: %w", outputFilePath, dbConfig.GetSchemaIdentifier(), err)
+		}
+		defer outputFile.Close()
+		execCmd.Stdout = outputFile
+		execCmd.Stderr = outputFile
+	} else {
+		execCmd.Stdout = os.Stdout
+		execCmd.Stderr = os.Stderr
+		execCmd.Stdin = os.Stdin
+	}
 	log.Infof("executing the cmd: %s", execCmd.String())
 	err := execCmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("assess migration of schema-%s timed out after %d minute(s): %w", dbConfig.GetSchemaIdentifier(),
+			bulkAssessmentTimeoutInMin, ctx.Err())
+	}
 	if err != nil {
 		return fmt.Errorf("error while assess migration of schema-%s: %v", dbConfig.GetSchemaIdentifier(), err)
 	}
@@ -254,10 +398,18 @@ func buildCommandArguments(dbConfig AssessMigrationDBConfig, exportDirPath strin
 	// since bulk command has separate check to decide beforehand whether the report exists or assessment needs to be performed.
 	args = append(args, "--start-clean", "true")
 
-	if utils.DoNotPrompt {
+	if utils.DoNotPrompt || bulkAssessmentParallelJobs > 1 {
 		args = append(args, "--yes")
 	}
 
+	if effortCostModelFileFlag != "" {
+		args = append(args, "--effort-cost-model-file", effortCostModelFileFlag)
+	}
+
+	if secretSourceSpec != "" {
+		args = append(args, "--secret-source", secretSourceSpec)
+	}
+
 	return args
 }
 
@@ -350,26 +502,31 @@ const REPORT_PATH_NOTE = "To automatically apply the recommendations, continue t
 
 func generateBulkAssessmentReport(dbConfigs []AssessMigrationDBConfig) error {
 	log.Infof("generating bulk assessment report")
+	bulkAssessmentReport = BulkAssessmentReport{}
+	bulkAssessmentStatus := readBulkAssessmentStatus()
 	for _, dbConfig := range dbConfigs {
 		// extension will set later on during html/json report generation
 		assessmentReportBasePath := dbConfig.GetAssessmentReportBasePath()
 		var assessmentDetail = AssessmentDetail{
 			Schema:             dbConfig.Schema,
 			DatabaseIdentifier: dbConfig.GetDatabaseIdentifier(),
-			Status:             COMPLETE,
+			Status:             bulkAssessmentStatus.GetRowStatus(dbConfig),
 		}
-		if !isMigrationAssessmentDoneForConfig(dbConfig) {
-			assessmentDetail.Status = ERROR
-		} else {
+		if assessmentDetail.Status == COMPLETE {
 			assessmentReportRelBasePath, err := filepath.Rel(bulkAssessmentDir, assessmentReportBasePath)
 			if err != nil {
 				return fmt.Errorf("failed to get relative path for %s schema assessment report: %w", dbConfig.GetSchemaIdentifier(), err)
 			}
 			assessmentDetail.ReportPath = assessmentReportRelBasePath
+			addMigrationEffortToAssessmentDetail(&assessmentDetail, dbConfig)
 		}
 		bulkAssessmentReport.Details = append(bulkAssessmentReport.Details, assessmentDetail)
 	}
 
+	bulkAssessmentReport.TotalMigrationEffortInHours = roundEffortHours(lo.SumBy(bulkAssessmentReport.Details, func(detail AssessmentDetail) float64 {
+		return detail.MigrationEffortInHours
+	}))
+
 	// add notes to the report
 	bulkAssessmentReport.Notes = append(bulkAssessmentReport.Notes, REPORT_PATH_NOTE)
 
@@ -385,6 +542,19 @@ func generateBulkAssessmentReport(dbConfigs []AssessMigrationDBConfig) error {
 	return nil
 }
 
+// reads the migration complexity and effort from the assessment report of the schema, if available
+func addMigrationEffortToAssessmentDetail(assessmentDetail *AssessmentDetail, dbConfig AssessMigrationDBConfig) {
+	report, err := ParseJSONToAssessmentReport(dbConfig.GetJsonAssessmentReportPath())
+	if err != nil {
+		log.Warnf("failed to read the assessment report of %s schema for migration effort: %v", dbConfig.GetSchemaIdentifier(), err)
+		return
+	}
+	assessmentDetail.MigrationComplexity = report.MigrationComplexity
+	if report.MigrationEffort != nil {
+		assessmentDetail.MigrationEffortInHours = report.MigrationEffort.TotalHours
+	}
+}
+
 func generateBulkAssessmentJsonReport() error {
 	for i := range bulkAssessmentReport.Details {
 		if bulkAssessmentReport.Details[i].ReportPath != "" {
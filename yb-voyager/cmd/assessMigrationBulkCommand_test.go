@@ -18,6 +18,7 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"os"
 	"strings"
 	"testing"
@@ -240,3 +241,45 @@ oracle,user3,schema3,db3`,
 		})
 	}
 }
+
+func TestBulkAssessmentStatus(t *testing.T) {
+	bulkAssessmentDir = t.TempDir()
+	defer func() { bulkAssessmentDir = "" }()
+	dbConfigs := []AssessMigrationDBConfig{
+		{DbType: "oracle", DbName: "db1", Schema: "s1"},
+		{DbType: "oracle", DbName: "db1", Schema: "s2"},
+		{DbType: "oracle", DbName: "db2", Schema: "s1"},
+	}
+
+	assert.NoError(t, initBulkAssessmentStatus(dbConfigs))
+	for _, dbConfig := range dbConfigs {
+		assert.Equal(t, BULK_ASSESSMENT_PENDING, readBulkAssessmentStatus().GetRowStatus(dbConfig))
+	}
+
+	assert.NoError(t, markBulkAssessmentStarted(dbConfigs[0].GetSchemaIdentifier()))
+	assert.NoError(t, markBulkAssessmentStarted(dbConfigs[1].GetSchemaIdentifier()))
+	assert.NoError(t, markBulkAssessmentFinished(dbConfigs[1].GetSchemaIdentifier(), BULK_ASSESSMENT_TIMED_OUT, context.DeadlineExceeded))
+	assert.Equal(t, INPROGRESS, readBulkAssessmentStatus().GetRowStatus(dbConfigs[0]))
+	assert.Equal(t, BULK_ASSESSMENT_TIMED_OUT, readBulkAssessmentStatus().GetRowStatus(dbConfigs[1]))
+
+	// re-run after an interruption: the in-progress assessment is pending again, the failed one is retained
+	assert.NoError(t, initBulkAssessmentStatus(dbConfigs))
+	status, err := bulkAssessmentStatusFile.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, BULK_ASSESSMENT_PENDING, status.Rows["db1-s1"].Status)
+	assert.Equal(t, 1, status.Rows["db1-s1"].Attempts)
+	assert.Equal(t, BULK_ASSESSMENT_TIMED_OUT, status.Rows["db1-s2"].Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), status.Rows["db1-s2"].LastError)
+	assert.Equal(t, BULK_ASSESSMENT_PENDING, status.Rows["db2-s1"].Status)
+}
+
+func TestBuildCommandArgumentsInheritedFlags(t *testing.T) {
+	dbConfig := AssessMigrationDBConfig{DbType: POSTGRESQL, Schema: "public", User: "voyager", DbName: "orders"}
+	args := buildCommandArguments(dbConfig, "/tmp/export-dir")
+	assert.NotContains(t, args, "--secret-source")
+
+	secretSourceSpec = "file:/tmp/passwords"
+	defer func() { secretSourceSpec = "" }()
+	args = buildCommandArguments(dbConfig, "/tmp/export-dir")
+	assert.Contains(t, strings.Join(args, " "), "--secret-source file:/tmp/passwords")
+}
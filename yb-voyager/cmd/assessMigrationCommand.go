@@ -55,9 +55,13 @@ var (
 	assessmentReport                 AssessmentReport
 	assessmentDB                     *migassessment.AssessmentDB
 	intervalForCapturingIOPS         int64
-	assessMigrationSupportedDBTypes  = []string{POSTGRESQL, ORACLE}
+	durationForCapturingIOPS         int64
+	assessMigrationSupportedDBTypes  = []string{POSTGRESQL, ORACLE, MYSQL}
 	referenceOrTablePartitionPresent = false
 	pgssEnabledForAssessment         = false
+	sizingExperimentDataFlag         string
+	profileData                      utils.BoolStr
+	dataProfileSamplePercent         float64
 )
 
 var sourceConnectionFlags = []string{
@@ -90,18 +94,37 @@ var assessMigrationCmd = &cobra.Command{
 		if err != nil {
 			utils.ErrExit("%v", err)
 		}
+		err = loadCustomRulesFromFileFlag()
+		if err != nil {
+			utils.ErrExit("%v", err)
+		}
+		err = loadMigrationEffortCostModelFromFileFlag()
+		if err != nil {
+			utils.ErrExit("%v", err)
+		}
+		validateSizingExperimentDataFlag()
+		validateSourceDBLogFilesFlag()
+		validateDataProfileFlags(cmd)
+		validateIopsCaptureFlags()
+		err = migassessment.SizingParams.Validate()
+		if err != nil {
+			utils.ErrExit("invalid sizing parameters: %v", err)
+		}
 		if cmd.Flags().Changed("assessment-metadata-dir") {
 			validateAssessmentMetadataDirFlag()
+			// the source connection details in the config file/environment are not used
 			for _, f := range sourceConnectionFlags {
-				if cmd.Flags().Changed(f) {
+				if isFlagPassedOnCommandLine(cmd, f) {
 					utils.ErrExit("Cannot pass `--source-*` connection related flags when `--assessment-metadata-dir` is provided.\nPlease re-run the command without these flags")
 				}
 			}
 		} else {
 			cmd.MarkFlagRequired("source-db-user")
 			cmd.MarkFlagRequired("source-db-name")
-			//Update this later as per db-types TODO
-			cmd.MarkFlagRequired("source-db-schema")
+			// no concept of schema in MySQL, the database is assessed
+			if source.DBType != MYSQL {
+				cmd.MarkFlagRequired("source-db-schema")
+			}
 		}
 	},
 
@@ -303,12 +326,57 @@ func init() {
 			"it will be assumed to be present at default path inside the export directory.")
 
 	assessMigrationCmd.Flags().Int64Var(&intervalForCapturingIOPS, "iops-capture-interval", 120,
-		"Interval (in seconds) at which voyager will gather IOPS metadata from source database for the given schema(s). (only valid for PostgreSQL)")
+		"Interval (in seconds) at which voyager will gather IOPS metadata from source database for the given schema(s). "+
+			"The reads/writes per second are computed over the actual time between the samples. (only valid for PostgreSQL and MySQL)")
 
-	BoolVar(assessMigrationCmd.Flags(), &source.RunGuardrailsChecks, "run-guardrails-checks", true, "run guardrails checks before assess migration. (only valid for PostgreSQL)")
+	assessMigrationCmd.Flags().Int64Var(&durationForCapturingIOPS, "iops-capture-duration", 0,
+		"Duration (in seconds) for which voyager will sample the IOPS metadata every --iops-capture-interval, to compute the average, p95 and peak "+
+			"reads/writes per second and the daily workload shape of the tables. The sizing uses the peak. "+
+			"Default 0 i.e. only two samples --iops-capture-interval apart. (only valid for PostgreSQL and MySQL)")
+
+	BoolVar(assessMigrationCmd.Flags(), &source.RunGuardrailsChecks, "run-guardrails-checks", true, "run guardrails checks before assess migration. (only valid for PostgreSQL and MySQL)")
 
 	assessMigrationCmd.Flags().StringVar(&targetDbVersionStrFlag, "target-db-version", "",
 		fmt.Sprintf("Target YugabyteDB version to assess migration for (in format A.B.C.D). Defaults to latest stable version (%s)", ybversion.LatestStable.String()))
+
+	assessMigrationCmd.Flags().StringVar(&customRulesFileFlag, "custom-rules-file", "",
+		"Path of the YAML file containing user-defined rules to report as issues along with the built-in ones (optional)")
+
+	assessMigrationCmd.Flags().StringSliceVar(&source.DBLogFiles, "source-db-log-files", nil,
+		"Comma separated list of PostgreSQL server log files (csvlog or stderr format, glob patterns allowed) with the statements logged "+
+			"using log_statement/log_min_duration_statement, to detect the unsupported query constructs when pg_stat_statements is not available. Ignored if the queries are collected from pg_stat_statements (optional)")
+
+	BoolVar(assessMigrationCmd.Flags(), &profileData, "profile-data", false,
+		"Profile the data of the tables(null ratio, distinct values, value/row widths and key skew) by sampling them, to report the oversized rows, "+
+			"low cardinality leading index columns and skewed shard keys. (only valid for PostgreSQL)")
+
+	assessMigrationCmd.Flags().Float64Var(&dataProfileSamplePercent, "profile-data-sample-percent", 1,
+		"Percentage of the blocks of the tables to sample for profiling the data, used with --profile-data. Tables smaller than 10MB are read fully")
+
+	assessMigrationCmd.Flags().StringVar(&effortCostModelFileFlag, "effort-cost-model-file", "",
+		"Path of the YAML file with the hours per issue impact level/issue type and the factors per object type "+
+			"to use for the migration effort estimate instead of the built-in ones (optional)")
+
+	assessMigrationCmd.Flags().StringVar(&sizingExperimentDataFlag, "sizing-experiment-data", "",
+		"Path of the sqlite file containing the experiment data(colocated/sharded limits, throughput, load times and optionally the instance types) "+
+			"to use for the sizing recommendation instead of the built-in data (optional)")
+
+	assessMigrationCmd.Flags().IntVar(&migassessment.SizingParams.ReplicationFactor, "target-replication-factor", migassessment.DEFAULT_REPLICATION_FACTOR,
+		"Replication factor of the target YugabyteDB cluster to consider for the sizing recommendation")
+
+	assessMigrationCmd.Flags().IntVar(&migassessment.SizingParams.NumZones, "target-num-zones", 0,
+		"Number of regions/zones across which the target YugabyteDB cluster nodes are spread. "+
+			"Number of nodes recommended will be a multiple of it (optional)")
+
+	assessMigrationCmd.Flags().IntVar(&migassessment.SizingParams.ProjectionYears, "sizing-projection-years", 0,
+		fmt.Sprintf("Number of years (max %d) to project the sizing recommendation for, considering the yearly growth (optional)",
+			migassessment.MAX_SIZING_PROJECTION_YEARS))
+
+	assessMigrationCmd.Flags().Float64Var(&migassessment.SizingParams.YearlyDataGrowthPercent, "yearly-data-growth-percent", 0,
+		"Expected yearly growth (in percentage) of the data size for the sizing projection")
+
+	assessMigrationCmd.Flags().Float64Var(&migassessment.SizingParams.YearlyThroughputGrowthPercent, "yearly-throughput-growth-percent", 0,
+		"Expected yearly growth (in percentage) of the reads/writes per second for the sizing projection")
 }
 
 func assessMigration() (err error) {
@@ -398,6 +466,7 @@ func assessMigration() (err error) {
 
 	startEvent := createMigrationAssessmentStartedEvent()
 	controlPlane.MigrationAssessmentStarted(startEvent)
+	recordPhaseStarted("ASSESS MIGRATION", bool(startClean))
 
 	initAssessmentDB() // Note: migassessment.AssessmentDir needs to be set beforehand
 
@@ -413,6 +482,18 @@ func assessMigration() (err error) {
 		return fmt.Errorf("failed to populate metadata CSV into SQLite DB: %w", err)
 	}
 
+	if len(source.DBLogFiles) > 0 {
+		numQueries, used, err := migassessment.PopulateQueriesFromPostgresLogs(assessmentDB, source.DBLogFiles)
+		if err != nil {
+			return fmt.Errorf("failed to collect queries from the source db log files: %w", err)
+		}
+		if used {
+			utils.PrintAndLog("collected %d distinct queries from %d source db log file(s)", numQueries, len(source.DBLogFiles))
+		} else {
+			utils.PrintAndLog("ignoring the source db log file(s) as the queries are collected from pg_stat_statements")
+		}
+	}
+
 	err = runAssessment()
 	if err != nil {
 		utils.PrintAndLog("failed to run assessment: %v", err)
@@ -428,6 +509,7 @@ func assessMigration() (err error) {
 	utils.PrintAndLog("Migration assessment completed successfully.")
 	completedEvent := createMigrationAssessmentCompletedEvent()
 	controlPlane.MigrationAssessmentCompleted(completedEvent)
+	recordPhaseCompleted("ASSESS MIGRATION")
 	err = SetMigrationAssessmentDoneInMSR()
 	if err != nil {
 		return fmt.Errorf("failed to set migration assessment completed in MSR: %w", err)
@@ -590,6 +672,20 @@ func flattenAssessmentReportToAssessmentIssues(ar AssessmentReport) []Assessment
 		})
 	}
 
+	for _, finding := range ar.CustomRuleFindings {
+		for _, object := range finding.Objects {
+			issues = append(issues, AssessmentIssueYugabyteD{
+				Type:               constants.CUSTOM_RULE,
+				TypeDescription:    GetCategoryDescription(constants.CUSTOM_RULE),
+				Subtype:            finding.RuleName,
+				SubtypeDescription: finding.Description,
+				ObjectName:         object.ObjectName,
+				SqlStatement:       object.SqlStatement,
+				DocsLink:           finding.DocsLink,
+			})
+		}
+	}
+
 	for _, plpgsqlObjects := range ar.UnsupportedPlPgSqlObjects {
 		for _, object := range plpgsqlObjects.Objects {
 			issues = append(issues, AssessmentIssueYugabyteD{
@@ -675,6 +771,11 @@ func gatherAssessmentMetadata() (err error) {
 		if err != nil {
 			return fmt.Errorf("error gathering metadata and stats from source Oracle database: %w", err)
 		}
+	case MYSQL:
+		err := gatherAssessmentMetadataFromMySQL()
+		if err != nil {
+			return fmt.Errorf("error gathering metadata and stats from source MySQL database: %w", err)
+		}
 	default:
 		return fmt.Errorf("source DB Type %s is not yet supported for metadata and stats gathering", source.DBType)
 	}
@@ -715,8 +816,28 @@ func gatherAssessmentMetadataFromPG() (err error) {
 		return err
 	}
 
-	return runGatherAssessmentMetadataScript(scriptPath, []string{fmt.Sprintf("PGPASSWORD=%s", source.Password)},
-		source.DB().GetConnectionUriWithoutPassword(), source.Schema, assessmentMetadataDir, fmt.Sprintf("%t", pgssEnabledForAssessment), fmt.Sprintf("%d", intervalForCapturingIOPS))
+	envVars := []string{fmt.Sprintf("PGPASSWORD=%s", source.Password)}
+	if profileData {
+		envVars = append(envVars, fmt.Sprintf("DATA_PROFILE_SAMPLE_PERCENT=%g", dataProfileSamplePercent))
+	}
+	return runGatherAssessmentMetadataScript(scriptPath, envVars,
+		source.DB().GetConnectionUriWithoutPassword(), source.Schema, assessmentMetadataDir, fmt.Sprintf("%t", pgssEnabledForAssessment), fmt.Sprintf("%d", intervalForCapturingIOPS),
+		fmt.Sprintf("%d", durationForCapturingIOPS))
+}
+
+func gatherAssessmentMetadataFromMySQL() (err error) {
+	if assessmentMetadataDirFlag != "" {
+		return nil
+	}
+
+	scriptPath, err := findGatherMetadataScriptPath(MYSQL)
+	if err != nil {
+		return err
+	}
+
+	return runGatherAssessmentMetadataScript(scriptPath, []string{fmt.Sprintf("MYSQL_PWD=%s", source.Password)},
+		source.Host, fmt.Sprintf("%d", source.Port), source.User, source.DBName, assessmentMetadataDir, fmt.Sprintf("%d", intervalForCapturingIOPS),
+		fmt.Sprintf("%d", durationForCapturingIOPS))
 }
 
 func findGatherMetadataScriptPath(dbType string) (string, error) {
@@ -726,6 +847,8 @@ func findGatherMetadataScriptPath(dbType string) (string, error) {
 		defaultScriptPath = "/etc/yb-voyager/gather-assessment-metadata/postgresql/yb-voyager-pg-gather-assessment-metadata.sh"
 	case ORACLE:
 		defaultScriptPath = "/etc/yb-voyager/gather-assessment-metadata/oracle/yb-voyager-oracle-gather-assessment-metadata.sh"
+	case MYSQL:
+		defaultScriptPath = "/etc/yb-voyager/gather-assessment-metadata/mysql/yb-voyager-mysql-gather-assessment-metadata.sh"
 	default:
 		panic(fmt.Sprintf("invalid source db type %q", dbType))
 	}
@@ -802,10 +925,10 @@ func runGatherAssessmentMetadataScript(scriptPath string, envVars []string, scri
 /*
 It is due to the differences in how tools like ora2pg, and pg_dump exports the schema
 pg_dump - export schema in single .sql file which is later on segregated by voyager in respective .sql file
-ora2pg - export schema in given .sql file, and we have to call it for each object type to export schema
+ora2pg - export schema in given .sql file, and we have to call it for each object type to export schema(Oracle and MySQL)
 */
 func parseExportedSchemaFileForAssessmentIfRequired() {
-	if source.DBType == ORACLE {
+	if source.DBType == ORACLE || source.DBType == MYSQL {
 		return // already parsed into schema files while exporting
 	}
 
@@ -898,6 +1021,7 @@ func generateAssessmentReport() (err error) {
 
 	// calculating migration complexity after collecting all assessment issues
 	assessmentReport.MigrationComplexity = calculateMigrationComplexity(source.DBType, schemaDir, assessmentReport)
+	assessmentReport.MigrationEffort = estimateMigrationEffort(source.DBType, assessmentReport.Issues, migrationEffortCostModel)
 
 	assessmentReport.Sizing = migassessment.SizingReport
 	assessmentReport.TableIndexStats, err = assessmentDB.FetchAllStats()
@@ -905,6 +1029,31 @@ func generateAssessmentReport() (err error) {
 		return fmt.Errorf("fetching all stats info from AssessmentDB: %w", err)
 	}
 
+	assessmentReport.ShardingKeyRecommendations, err = migassessment.ShardingKeyAssessment(assessmentDB)
+	if err != nil {
+		return fmt.Errorf("failed to perform sharding key assessment: %w", err)
+	}
+
+	assessmentReport.RedundantIndexes, err = migassessment.RedundantIndexAssessment(assessmentDB)
+	if err != nil {
+		return fmt.Errorf("failed to perform redundant index assessment: %w", err)
+	}
+
+	assessmentReport.QueryPerformanceRisks, err = migassessment.QueryPerformanceRiskAssessment(assessmentDB, assessmentReport.ShardingKeyRecommendations)
+	if err != nil {
+		return fmt.Errorf("failed to perform query performance risk assessment: %w", err)
+	}
+
+	assessmentReport.Workload, err = migassessment.WorkloadAssessment(assessmentDB)
+	if err != nil {
+		return fmt.Errorf("failed to perform workload assessment: %w", err)
+	}
+
+	assessmentReport.DataProfile, err = migassessment.DataProfileAssessment(assessmentDB)
+	if err != nil {
+		return fmt.Errorf("failed to perform data profile assessment: %w", err)
+	}
+
 	addNotesToAssessmentReport()
 	postProcessingOfAssessmentReport()
 
@@ -937,7 +1086,8 @@ func getAssessmentReportContentFromAnalyzeSchema() error {
 	switch source.DBType {
 	case ORACLE:
 		unsupportedFeatures, err = fetchUnsupportedOracleFeaturesFromSchemaReport(schemaAnalysisReport)
-	case POSTGRESQL:
+	case POSTGRESQL, MYSQL:
+		// MySQL schema is converted to PostgreSQL by ora2pg, so the same detection applies
 		unsupportedFeatures, err = fetchUnsupportedPGFeaturesFromSchemaReport(schemaAnalysisReport)
 	default:
 		panic(fmt.Sprintf("unsupported source db type %q", source.DBType))
@@ -952,9 +1102,31 @@ func getAssessmentReportContentFromAnalyzeSchema() error {
 	if utils.GetEnvAsBool("REPORT_UNSUPPORTED_PLPGSQL_OBJECTS", true) {
 		assessmentReport.UnsupportedPlPgSqlObjects = fetchUnsupportedPlPgSQLObjects(schemaAnalysisReport)
 	}
+
+	addCustomRuleFindingsFromSchemaReport(schemaAnalysisReport)
 	return nil
 }
 
+func addCustomRuleFindingsFromSchemaReport(schemaAnalysisReport utils.SchemaReport) {
+	for _, issue := range schemaAnalysisReport.Issues {
+		if issue.IssueType != CUSTOM_RULES_CATEGORY {
+			continue
+		}
+		assessmentReport.AddCustomRuleFinding(AssessmentIssue{
+			Category:            CUSTOM_RULES_CATEGORY,
+			CategoryDescription: GetCategoryDescription(CUSTOM_RULES_CATEGORY),
+			Type:                issue.Type,
+			Name:                issue.Reason,
+			Description:         parserIssueDetector.GetCustomRuleDescription(issue.Type),
+			Impact:              issue.Impact,
+			ObjectType:          issue.ObjectType,
+			ObjectName:          issue.ObjectName,
+			SqlStatement:        issue.SqlStatement,
+			DocsLink:            issue.DocsLink,
+		}, issue.Suggestion)
+	}
+}
+
 // when we group multiple Issue instances into a single bucket of UnsupportedFeature.
 // Ideally, all the issues in the same bucket should have the same minimum version fixed in.
 // We want to validate that and fail if not.
@@ -1192,7 +1364,7 @@ func fetchUnsupportedObjectTypes() ([]UnsupportedFeature, error) {
 }
 
 func fetchUnsupportedPlPgSQLObjects(schemaAnalysisReport utils.SchemaReport) []UnsupportedFeature {
-	if source.DBType != POSTGRESQL {
+	if source.DBType != POSTGRESQL && source.DBType != MYSQL {
 		return nil
 	}
 
@@ -1222,6 +1394,7 @@ func fetchUnsupportedPlPgSQLObjects(schemaAnalysisReport utils.SchemaReport) []U
 				ObjectType:   issue.ObjectType,
 				ObjectName:   issue.ObjectName,
 				SqlStatement: issue.SqlStatement,
+				IsDynamicSQL: issue.IsDynamicSQL,
 			})
 			docsLink = issue.DocsLink
 
@@ -1304,6 +1477,20 @@ func fetchUnsupportedQueryConstructs() ([]utils.UnsupportedQueryConstruct, error
 		}
 
 		for _, issue := range issues {
+			if queryissue.IsCustomRuleIssue(issue) {
+				assessmentReport.AddCustomRuleFinding(AssessmentIssue{
+					Category:            CUSTOM_RULES_CATEGORY,
+					CategoryDescription: GetCategoryDescription(CUSTOM_RULES_CATEGORY),
+					Type:                issue.Type,
+					Name:                issue.Name,
+					Description:         issue.Description,
+					Impact:              issue.Impact,
+					ObjectType:          issue.ObjectType,
+					SqlStatement:        issue.SqlStatement,
+					DocsLink:            issue.DocsLink,
+				}, issue.Suggestion)
+				continue
+			}
 			uqc := utils.UnsupportedQueryConstruct{
 				Query:                  issue.SqlStatement,
 				ConstructTypeName:      issue.Name,
@@ -1369,6 +1556,8 @@ func fetchColumnsWithUnsupportedDataTypes() ([]utils.TableColumnsDataTypes, []ut
 		liveWithFForFBUnsupportedDatatypes = srcdb.GetPGLiveMigrationWithFFOrFBUnsupportedDatatypes()
 	case ORACLE:
 		sourceUnsupportedDatatypes = srcdb.OracleUnsupportedDataTypes
+	case MYSQL:
+		sourceUnsupportedDatatypes = srcdb.MysqlUnsupportedDataTypes
 	default:
 		panic(fmt.Sprintf("invalid source db type %q", source.DBType))
 	}
@@ -1655,6 +1844,8 @@ func generateAssessmentReportHtml(reportDir string) error {
 		"groupByObjectName":                groupByObjectName,
 		"totalUniqueObjectNamesOfAllTypes": totalUniqueObjectNamesOfAllTypes,
 		"getSupportedVersionString":        getSupportedVersionString,
+		"humanReadableByteCount":           utils.HumanReadableByteCount,
+		"hourlyOpsPerSecond":               hourlyOpsPerSecond,
 	}
 	tmpl := template.Must(template.New("report").Funcs(funcMap).Parse(string(bytesTemplate)))
 
@@ -1736,6 +1927,96 @@ func validateAssessmentMetadataDirFlag() {
 	}
 }
 
+func validateSizingExperimentDataFlag() {
+	if sizingExperimentDataFlag == "" {
+		return
+	}
+	if !utils.FileOrFolderExists(sizingExperimentDataFlag) {
+		utils.ErrExit("sizing experiment data file: %q provided with `--sizing-experiment-data` flag does not exist", sizingExperimentDataFlag)
+	}
+	var err error
+	migassessment.ExperimentDataFilePath, err = filepath.Abs(sizingExperimentDataFlag)
+	if err != nil {
+		utils.ErrExit("failed to get absolute path of sizing experiment data file %q: %v", sizingExperimentDataFlag, err)
+	}
+	err = migassessment.ValidateExperimentDataFile(migassessment.ExperimentDataFilePath)
+	if err != nil {
+		utils.ErrExit("invalid sizing experiment data file %q provided with `--sizing-experiment-data` flag: %v", sizingExperimentDataFlag, err)
+	}
+	log.Infof("using provided sizing experiment data: %s", migassessment.ExperimentDataFilePath)
+}
+
+// reads+writes per second of the object in every hour of the day, "-" for the hours not sampled
+func hourlyOpsPerSecond(workload migassessment.ObjectWorkload) []string {
+	result := make([]string, 24)
+	for i := range result {
+		result[i] = "-"
+	}
+	for _, h := range workload.Hourly {
+		result[h.Hour] = fmt.Sprintf("%.1f", h.ReadsPerSecond+h.WritesPerSecond)
+	}
+	return result
+}
+
+func validateIopsCaptureFlags() {
+	if durationForCapturingIOPS == 0 {
+		return
+	}
+	if source.DBType != POSTGRESQL && source.DBType != MYSQL {
+		utils.ErrExit("`--iops-capture-duration` flag is only supported for %s and %s source databases", POSTGRESQL, MYSQL)
+	}
+	if intervalForCapturingIOPS <= 0 {
+		utils.ErrExit("invalid value %d for `--iops-capture-interval` flag: must be greater than 0 for sampling the IOPS", intervalForCapturingIOPS)
+	}
+	if durationForCapturingIOPS < intervalForCapturingIOPS {
+		utils.ErrExit("invalid value %d for `--iops-capture-duration` flag: must be at least the `--iops-capture-interval`(%d)",
+			durationForCapturingIOPS, intervalForCapturingIOPS)
+	}
+}
+
+func validateDataProfileFlags(cmd *cobra.Command) {
+	if !profileData {
+		if cmd.Flags().Changed("profile-data-sample-percent") {
+			utils.ErrExit("`--profile-data-sample-percent` flag can only be used along with `--profile-data`")
+		}
+		return
+	}
+	if source.DBType != POSTGRESQL {
+		utils.ErrExit("`--profile-data` flag is only supported for %s source database", POSTGRESQL)
+	}
+	if dataProfileSamplePercent <= 0 || dataProfileSamplePercent > 100 {
+		utils.ErrExit("invalid value %g for `--profile-data-sample-percent` flag: must be greater than 0 and at most 100", dataProfileSamplePercent)
+	}
+}
+
+func validateSourceDBLogFilesFlag() {
+	if len(source.DBLogFiles) == 0 {
+		return
+	}
+	if source.DBType != POSTGRESQL {
+		utils.ErrExit("`--source-db-log-files` flag is only supported for %s source database", POSTGRESQL)
+	}
+	var logFiles []string
+	for _, pattern := range source.DBLogFiles {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			utils.ErrExit("invalid pattern %q provided with `--source-db-log-files` flag: %v", pattern, err)
+		}
+		if len(matches) == 0 {
+			utils.ErrExit("source db log file: %q provided with `--source-db-log-files` flag does not exist", pattern)
+		}
+		for _, match := range matches {
+			absPath, err := filepath.Abs(match)
+			if err != nil {
+				utils.ErrExit("failed to get absolute path of source db log file %q: %v", match, err)
+			}
+			logFiles = append(logFiles, absPath)
+		}
+	}
+	source.DBLogFiles = lo.Uniq(logFiles)
+	log.Infof("using source db log files for the queries: %v", source.DBLogFiles)
+}
+
 func validateAndSetTargetDbVersionFlag() error {
 	if targetDbVersionStrFlag == "" {
 		targetDbVersion = ybversion.LatestStable
@@ -0,0 +1,129 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/migassessment"
+)
+
+// loads the given metadata CSVs(as written by the gather script) into a new assessment DB
+func populateTestAssessmentDB(t *testing.T, metadataFiles map[string]string) {
+	t.Helper()
+	assessmentMetadataDir = t.TempDir()
+	dbFilePath := filepath.Join(t.TempDir(), "assessment.db")
+	migassessment.GetSourceMetadataDBFilePath = func() string {
+		return dbFilePath
+	}
+	migassessment.SourceDBType = POSTGRESQL
+	t.Cleanup(func() {
+		assessmentMetadataDir = ""
+		assessmentDB = nil
+		migassessment.SourceDBType = ""
+	})
+
+	for fileName, content := range metadataFiles {
+		require.NoError(t, os.WriteFile(filepath.Join(assessmentMetadataDir, fileName), []byte(content), 0644))
+	}
+	require.NoError(t, migassessment.InitAssessmentDB())
+	var err error
+	assessmentDB, err = migassessment.NewAssessmentDB(POSTGRESQL)
+	require.NoError(t, err)
+	require.NoError(t, populateMetadataCSVIntoAssessmentDB())
+}
+
+func TestDataProfileFromMetadataCSV(t *testing.T) {
+	populateTestAssessmentDB(t, map[string]string{
+		// the NULLs of the gather script are empty fields in the CSV: top_value_freq of the non-index columns,
+		// the pg_stats columns of the never analyzed tables
+		"column-data-profile.csv": `schema_name,table_name,column_name,null_frac,distinct_count,avg_width,max_width,top_value_freq
+public,orders,id,0,1000,4.00,4,0
+public,orders,note,0.5,20,12.50,40,
+public,never_analyzed,id,,,4.00,4,
+`,
+		"table-row-widths.csv": `schema_name,table_name,sampled_rows,avg_row_width,max_row_width
+public,orders,1000,30.00,60
+public,never_analyzed,10,10.00,10
+`,
+		"index-leading-columns.csv": `schema_name,table_name,index_name,is_primary_key,column_name,is_identity,owned_sequence,column_default
+public,orders,orders_pkey,1,id,0,public.orders_id_seq,nextval('orders_id_seq'::regclass)
+public,never_analyzed,never_analyzed_pkey,1,id,0,,
+`,
+	})
+
+	profile, err := migassessment.DataProfileAssessment(assessmentDB)
+	require.NoError(t, err)
+	require.NotNil(t, profile)
+	require.Len(t, profile.Columns, 3)
+
+	neverAnalyzed := profile.Columns[0]
+	assert.Equal(t, "never_analyzed", neverAnalyzed.TableName)
+	assert.Nil(t, neverAnalyzed.NullRatio)
+	assert.Nil(t, neverAnalyzed.DistinctCount)
+	assert.Nil(t, neverAnalyzed.KeySkew)
+	assert.Equal(t, 4.0, *neverAnalyzed.AvgWidth)
+
+	assert.Equal(t, "id", profile.Columns[1].ColumnName)
+	assert.Equal(t, int64(1000), *profile.Columns[1].DistinctCount)
+	assert.Equal(t, 0.0, *profile.Columns[1].KeySkew)
+
+	note := profile.Columns[2]
+	assert.Equal(t, "note", note.ColumnName)
+	assert.Equal(t, 0.5, *note.NullRatio)
+	assert.Nil(t, note.KeySkew)
+	assert.Equal(t, int64(40), *note.MaxWidth)
+}
+
+func TestWorkloadFromMetadataCSVWithPartitionedTable(t *testing.T) {
+	// reads/writes are not collected for the partitioned tables/indexes, empty fields in the CSV
+	iopsCSV := func(measurementType string, ordersReads int, measurementTime int) string {
+		return fmt.Sprintf(`schema_name,object_name,object_type,seq_reads,row_writes,measurement_type,measurement_time
+public,orders,table,%d,100,%s,%d
+public,events,partitioned table,,,%s,%d
+public,events_idx,partitioned index,,,%s,%d
+`, ordersReads, measurementType, measurementTime, measurementType, measurementTime, measurementType, measurementTime)
+	}
+	populateTestAssessmentDB(t, map[string]string{
+		"table-index-iops-initial.csv": iopsCSV("initial", 1000, 1000),
+		"table-index-iops-final.csv":   iopsCSV("final", 7000, 1060),
+		"table-row-counts.csv": `schema_name,table_name,row_count
+public,orders,1000
+public,events,0
+`,
+		"object-type-mapping.csv": `schema_name,object_name,object_type
+public,orders,table
+public,events,partitioned table
+public,events_idx,partitioned index
+`,
+	})
+
+	stats, err := assessmentDB.FetchAllStats()
+	require.NoError(t, err)
+	require.Len(t, *stats, 1)
+	orders := (*stats)[0]
+	assert.Equal(t, "orders", orders.ObjectName)
+	assert.Equal(t, int64(100), *orders.ReadsPerSecond)
+	assert.Equal(t, int64(0), *orders.WritesPerSecond)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils/jsonfile"
+)
+
+/*
+The status of the assessment of each fleet config row is persisted in the bulk-assessment-dir so that
+a re-run after a crash or Ctrl-C resumes with only the unfinished assessments.
+
+	PENDING -> IN-PROGRESS -> COMPLETE
+	                       -> ERROR/TIMED-OUT (after all the retries)
+
+Assessments left IN-PROGRESS by an interrupted run are considered PENDING on the next run.
+*/
+
+const (
+	BULK_ASSESSMENT_STATUS_FILE_NAME = "bulk_assessment_status.json"
+	BULK_ASSESSMENT_PENDING          = "PENDING"
+	BULK_ASSESSMENT_TIMED_OUT        = "TIMED-OUT"
+)
+
+type BulkAssessmentStatus struct {
+	Rows map[string]*BulkAssessmentRowStatus `json:"Rows"` // key: schema identifier of the fleet config row
+}
+
+type BulkAssessmentRowStatus struct {
+	SchemaIdentifier string `json:"SchemaIdentifier"`
+	Status           string `json:"Status"`
+	Attempts         int    `json:"Attempts"`
+	LastError        string `json:"LastError,omitempty"`
+	StartedAt        string `json:"StartedAt,omitempty"`
+	FinishedAt       string `json:"FinishedAt,omitempty"`
+}
+
+var bulkAssessmentStatusFile *jsonfile.JsonFile[BulkAssessmentStatus]
+
+func getBulkAssessmentStatusFilePath() string {
+	return filepath.Join(bulkAssessmentDir, BULK_ASSESSMENT_STATUS_FILE_NAME)
+}
+
+// initBulkAssessmentStatus adds the rows missing in the status file and resets the interrupted ones
+func initBulkAssessmentStatus(dbConfigs []AssessMigrationDBConfig) error {
+	bulkAssessmentStatusFile = jsonfile.NewJsonFile[BulkAssessmentStatus](getBulkAssessmentStatusFilePath())
+	if bool(startClean) && utils.FileOrFolderExists(bulkAssessmentStatusFile.FilePath) {
+		err := bulkAssessmentStatusFile.Delete()
+		if err != nil {
+			return fmt.Errorf("deleting bulk assessment status file: %w", err)
+		}
+	}
+
+	return bulkAssessmentStatusFile.Update(func(status *BulkAssessmentStatus) {
+		if status.Rows == nil {
+			status.Rows = make(map[string]*BulkAssessmentRowStatus)
+		}
+		for _, dbConfig := range dbConfigs {
+			identifier := dbConfig.GetSchemaIdentifier()
+			row, ok := status.Rows[identifier]
+			if !ok {
+				row = &BulkAssessmentRowStatus{SchemaIdentifier: identifier, Status: BULK_ASSESSMENT_PENDING}
+				status.Rows[identifier] = row
+			}
+			switch {
+			case isMigrationAssessmentDoneForConfig(dbConfig):
+				row.Status = COMPLETE
+			case row.Status == INPROGRESS || row.Status == COMPLETE:
+				// interrupted in the previous run or the export-dir was cleaned up after the assessment
+				log.Infof("resetting status of the assessment of %s from %s to %s", identifier, row.Status, BULK_ASSESSMENT_PENDING)
+				row.Status = BULK_ASSESSMENT_PENDING
+			}
+		}
+	})
+}
+
+func updateBulkAssessmentRowStatus(schemaIdentifier string, fn func(row *BulkAssessmentRowStatus)) error {
+	err := bulkAssessmentStatusFile.Update(func(status *BulkAssessmentStatus) {
+		if status.Rows == nil {
+			status.Rows = make(map[string]*BulkAssessmentRowStatus)
+		}
+		row, ok := status.Rows[schemaIdentifier]
+		if !ok {
+			row = &BulkAssessmentRowStatus{SchemaIdentifier: schemaIdentifier}
+			status.Rows[schemaIdentifier] = row
+		}
+		fn(row)
+	})
+	if err != nil {
+		return fmt.Errorf("updating bulk assessment status of %s: %w", schemaIdentifier, err)
+	}
+	return nil
+}
+
+func markBulkAssessmentStarted(schemaIdentifier string) error {
+	return updateBulkAssessmentRowStatus(schemaIdentifier, func(row *BulkAssessmentRowStatus) {
+		row.Status = INPROGRESS
+		row.Attempts++
+		row.StartedAt = time.Now().Format(time.RFC3339)
+		row.FinishedAt = ""
+	})
+}
+
+func markBulkAssessmentFinished(schemaIdentifier string, status string, assessmentErr error) error {
+	return updateBulkAssessmentRowStatus(schemaIdentifier, func(row *BulkAssessmentRowStatus) {
+		row.Status = status
+		row.LastError = ""
+		if assessmentErr != nil {
+			row.LastError = assessmentErr.Error()
+		}
+		row.FinishedAt = time.Now().Format(time.RFC3339)
+	})
+}
+
+// reads the current status of all the fleet config rows, empty if not known
+func readBulkAssessmentStatus() *BulkAssessmentStatus {
+	if bulkAssessmentStatusFile == nil || !utils.FileOrFolderExists(bulkAssessmentStatusFile.FilePath) {
+		return &BulkAssessmentStatus{}
+	}
+	status, err := bulkAssessmentStatusFile.Read()
+	if err != nil {
+		log.Warnf("reading bulk assessment status file: %v", err)
+		return &BulkAssessmentStatus{}
+	}
+	return status
+}
+
+// returns the status of the assessment of the fleet config row, PENDING if not known
+func (status *BulkAssessmentStatus) GetRowStatus(dbConfig AssessMigrationDBConfig) string {
+	row, ok := status.Rows[dbConfig.GetSchemaIdentifier()]
+	if !ok {
+		return BULK_ASSESSMENT_PENDING
+	}
+	return row.Status
+}
@@ -0,0 +1,594 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/datafile"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/diagnostics"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/secret"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/tgtdb"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
+)
+
+const (
+	DIAGNOSTICS_ARCHIVE_PREFIX    = "yb-voyager-diagnostics"
+	DIAGNOSTICS_TIMESTAMP_FORMAT  = "20060102-150405"
+	DEFAULT_MAX_LOG_FILE_SIZE_MB  = 100
+	DIAGNOSTICS_FILE_PERMISSIONS  = 0600
+	DIAGNOSTICS_TRUNCATED_LOG_FMT = "[yb-voyager collect diagnostics: only the last %d MB of the %d bytes log file are included]"
+)
+
+var (
+	diagnosticsOutputFile string
+	redactTableNames      utils.BoolStr
+	maxLogFileSizeInMB    int64
+)
+
+// env vars with the passwords and tokens, whose values are redacted wherever they occur
+var diagnosticsSecretEnvVars = []string{
+	"SOURCE_DB_PASSWORD", "TARGET_DB_PASSWORD", "SOURCE_REPLICA_DB_PASSWORD",
+	SERVE_AUTH_TOKEN_ENV_VAR, "WEBHOOK_SECRET", "VAULT_TOKEN",
+}
+
+// env vars relevant to the migration, only whether they are set is reported
+var diagnosticsEnvVars = []string{
+	"SOURCE_DB_PASSWORD", "TARGET_DB_PASSWORD", "SOURCE_REPLICA_DB_PASSWORD",
+	"CONTROL_PLANE_TYPE", "YUGABYTED_DB_CONN_STRING", "WEBHOOK_URL", "WEBHOOK_SECRET",
+	"VAULT_ADDR", "VAULT_TOKEN", "JAVA_HOME", "ORACLE_HOME", "TNS_ADMIN", "YB_MASTER_PORT",
+}
+
+var collectCmd = &cobra.Command{
+	Use:   "collect",
+	Short: PARENT_COMMAND_USAGE,
+	Long:  ``,
+}
+
+var collectDiagnosticsCmd = &cobra.Command{
+	Use:   "diagnostics",
+	Short: "Collects the logs and the migration state into an archive to be shared for troubleshooting",
+	Long: `Collects the voyager and debezium logs, the migration status record and the metaDB content, the voyager and database versions,
+the data file descriptor, a summary of the queue segments and the environment info into a single tar.gz archive.
+Passwords, tokens, the database hosts and users, and IP addresses are redacted. Table names are redacted if --redact-table-names is set.
+Review the archive before sharing it.`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		// the persistent pre-run is skipped so that the export dir is left as is, even if it is of an incompatible version.
+		// Logging is not initialized so as to not add to the logs being collected, the warnings are printed on the console.
+		log.SetLevel(log.WarnLevel)
+		validateExportDirFlag()
+		if metaDBIsCreated(exportDir) {
+			var err error
+			metaDB, err = metadb.NewReadOnlyMetaDB(exportDir)
+			if err != nil {
+				utils.ErrExit("failed to open meta db: %s", err)
+			}
+		}
+		collectDiagnostics()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(collectCmd)
+	collectCmd.AddCommand(collectDiagnosticsCmd)
+	registerExportDirFlag(collectDiagnosticsCmd)
+
+	collectDiagnosticsCmd.Flags().StringVar(&diagnosticsOutputFile, "output-file", "",
+		fmt.Sprintf("path of the diagnostics archive (default: <export-dir>/reports/%s-<timestamp>.tar.gz)", DIAGNOSTICS_ARCHIVE_PREFIX))
+	BoolVar(collectDiagnosticsCmd.Flags(), &redactTableNames, "redact-table-names", false,
+		"replace the names of the migrated tables by placeholders like table_1 in the collected files")
+	collectDiagnosticsCmd.Flags().Int64Var(&maxLogFileSizeInMB, "max-log-file-size", DEFAULT_MAX_LOG_FILE_SIZE_MB,
+		"maximum size (in MB) of each log file in the archive. Only the end of the larger log files is included")
+}
+
+type diagnosticsDatabase struct {
+	Role      string `json:"role"`
+	DBType    string `json:"db_type"`
+	DBVersion string `json:"db_version"`
+}
+
+type diagnosticsEnvironment struct {
+	OS                     string   `json:"os"`
+	Arch                   string   `json:"arch"`
+	KernelVersion          string   `json:"kernel_version"`
+	NumCPU                 int      `json:"num_cpu"`
+	MemTotal               string   `json:"mem_total"`
+	GoVersion              string   `json:"go_version"`
+	JavaVersion            string   `json:"java_version"`
+	ExportDirFreeDiskSpace uint64   `json:"export_dir_free_disk_space"`
+	EnvVarsSet             []string `json:"env_vars_set"` // only the names, not the values
+	SecretSourceType       string   `json:"secret_source_type,omitempty"`
+	ControlPlaneType       string   `json:"control_plane_type,omitempty"`
+}
+
+type diagnosticsSummary struct {
+	CollectedAt        time.Time               `json:"collected_at"`
+	VoyagerVersion     string                  `json:"voyager_version"`
+	GitCommitHash      string                  `json:"git_commit_hash"`
+	MigrationUUID      string                  `json:"migration_uuid,omitempty"`
+	MigrationType      string                  `json:"migration_type,omitempty"`
+	Databases          []*diagnosticsDatabase  `json:"databases"`
+	RunningCommands    []string                `json:"running_voyager_commands"`
+	Environment        *diagnosticsEnvironment `json:"environment"`
+	TableNamesRedacted bool                    `json:"table_names_redacted"`
+	MaxLogFileSizeInMB int64                   `json:"max_log_file_size_mb"`
+	CollectedFiles     []string                `json:"collected_files"`
+	FailedToCollect    []string                `json:"failed_to_collect"`
+}
+
+type diagnosticsQueueSegmentsSummary struct {
+	ImporterRole string `json:"importer_role"`
+	ExporterRole string `json:"exporter_role"`
+	metadb.QueueSegmentsStats
+}
+
+// diagnosticsCollector builds the archive entries in memory, sanitizing each of them before adding
+type diagnosticsCollector struct {
+	exportDir      string
+	msr            *metadb.MigrationStatusRecord
+	metaDB         *metadb.MetaDB
+	sanitizer      *diagnostics.Sanitizer
+	maxLogFileSize int64
+	summary        *diagnosticsSummary
+	files          []*diagnosticsFile
+}
+
+type diagnosticsFile struct {
+	name    string
+	content []byte
+}
+
+func collectDiagnostics() {
+	var msr *metadb.MigrationStatusRecord
+	if metaDB != nil {
+		var err error
+		msr, err = metaDB.GetMigrationStatusRecord()
+		if err != nil {
+			utils.ErrExit("get migration status record: %v", err)
+		}
+	}
+
+	collectedAt := time.Now()
+	if diagnosticsOutputFile == "" {
+		diagnosticsOutputFile = filepath.Join(exportDir, "reports",
+			fmt.Sprintf("%s-%s.tar.gz", DIAGNOSTICS_ARCHIVE_PREFIX, collectedAt.Format(DIAGNOSTICS_TIMESTAMP_FORMAT)))
+	}
+	if maxLogFileSizeInMB <= 0 {
+		utils.ErrExit("invalid value for --max-log-file-size: %d. It should be greater than 0", maxLogFileSizeInMB)
+	}
+
+	c := newDiagnosticsCollector(exportDir, msr, metaDB, maxLogFileSizeInMB*1024*1024, collectedAt)
+	addDiagnosticsSecretsAndIdentifiers(c.sanitizer, msr)
+	if redactTableNames {
+		addDiagnosticsTableNames(c.sanitizer, exportDir, msr, metaDB)
+	}
+	c.summary.Environment = getDiagnosticsEnvironment(exportDir)
+	c.summary.TableNamesRedacted = bool(redactTableNames)
+	for cmdName := range getRunningVoyagerCommands(exportDir) {
+		c.summary.RunningCommands = append(c.summary.RunningCommands, cmdName)
+	}
+	slices.Sort(c.summary.RunningCommands)
+	c.collect()
+
+	err := c.writeArchive(diagnosticsOutputFile)
+	if err != nil {
+		utils.ErrExit("write diagnostics archive %q: %v", diagnosticsOutputFile, err)
+	}
+	utils.PrintAndLog("Diagnostics collected at %s", color.GreenString(diagnosticsOutputFile))
+	if len(c.summary.FailedToCollect) > 0 {
+		utils.PrintAndLog("Failed to collect some of the diagnostics (refer summary.json in the archive): %s",
+			strings.Join(c.summary.FailedToCollect, ", "))
+	}
+	fmt.Println("Passwords, database hosts and users and IP addresses are redacted. Review the archive before sharing it.")
+}
+
+func newDiagnosticsCollector(exportDir string, msr *metadb.MigrationStatusRecord, mdb *metadb.MetaDB,
+	maxLogFileSize int64, collectedAt time.Time) *diagnosticsCollector {
+	c := &diagnosticsCollector{
+		exportDir:      exportDir,
+		msr:            msr,
+		metaDB:         mdb,
+		sanitizer:      diagnostics.NewSanitizer(),
+		maxLogFileSize: maxLogFileSize,
+		summary: &diagnosticsSummary{
+			CollectedAt:        collectedAt,
+			VoyagerVersion:     utils.YB_VOYAGER_VERSION,
+			GitCommitHash:      utils.GitCommitHash(),
+			Databases:          []*diagnosticsDatabase{},
+			RunningCommands:    []string{},
+			MaxLogFileSizeInMB: maxLogFileSize / (1024 * 1024),
+			CollectedFiles:     []string{},
+			FailedToCollect:    []string{},
+		},
+	}
+	if msr != nil {
+		c.summary.MigrationUUID = msr.MigrationUUID
+		c.summary.MigrationType = getMigrationTypeDescription(msr)
+		if msr.SourceDBConf != nil {
+			c.summary.Databases = append(c.summary.Databases, &diagnosticsDatabase{
+				Role: "source", DBType: msr.SourceDBConf.DBType, DBVersion: msr.SourceDBConf.DBVersion})
+		}
+		for _, conf := range []struct {
+			role string
+			conf *tgtdb.TargetConf
+		}{{"target", msr.TargetDBConf}, {"source-replica", msr.SourceReplicaDBConf}, {"source-as-target", msr.SourceDBAsTargetConf}} {
+			if conf.conf != nil {
+				c.summary.Databases = append(c.summary.Databases, &diagnosticsDatabase{
+					Role: conf.role, DBType: conf.conf.TargetDBType, DBVersion: conf.conf.DBVersion})
+			}
+		}
+	}
+	return c
+}
+
+// addDiagnosticsSecretsAndIdentifiers adds the known passwords/tokens and the database hosts/users to the sanitizer
+func addDiagnosticsSecretsAndIdentifiers(s *diagnostics.Sanitizer, msr *metadb.MigrationStatusRecord) {
+	for _, envVar := range diagnosticsSecretEnvVars {
+		s.AddSecret(os.Getenv(envVar))
+	}
+	if secretSourceSpec != "" {
+		for _, name := range []string{secret.SOURCE_DB_PASSWORD, secret.TARGET_DB_PASSWORD, secret.SOURCE_REPLICA_DB_PASSWORD,
+			secret.YUGABYTED_DB_PASSWORD, secret.SERVE_AUTH_TOKEN} {
+			value, err := getPasswordFromSecretSource(name)
+			if err != nil {
+				log.Warnf("get %q from the secret source to redact it: %v", name, err)
+				continue
+			}
+			s.AddSecret(value)
+		}
+	}
+	if connString := os.Getenv("YUGABYTED_DB_CONN_STRING"); connString != "" {
+		u, err := url.Parse(connString)
+		if err != nil {
+			log.Warnf("parse YUGABYTED_DB_CONN_STRING to redact the host and user: %v", err)
+		} else {
+			s.AddIdentifier(u.Hostname(), "yugabyted-db-host")
+			s.AddIdentifier(u.User.Username(), "yugabyted-db-user")
+			password, _ := u.User.Password()
+			s.AddSecret(password)
+		}
+	}
+	if msr == nil {
+		return
+	}
+
+	if msr.SourceDBConf != nil {
+		s.AddIdentifier(msr.SourceDBConf.Host, "source-db-host")
+		s.AddIdentifier(msr.SourceDBConf.User, "source-db-user")
+		s.AddSecret(msr.SourceDBConf.Password)
+		s.AddSecret(msr.SourceDBConf.SSLKeyStorePassword)
+		s.AddSecret(msr.SourceDBConf.SSLTrustStorePassword)
+	}
+	for _, conf := range []struct {
+		placeholderPrefix string
+		conf              *tgtdb.TargetConf
+	}{{"target-db", msr.TargetDBConf}, {"source-replica-db", msr.SourceReplicaDBConf}, {"source-db", msr.SourceDBAsTargetConf}} {
+		if conf.conf != nil {
+			s.AddIdentifier(conf.conf.Host, conf.placeholderPrefix+"-host")
+			s.AddIdentifier(conf.conf.User, conf.placeholderPrefix+"-user")
+			s.AddSecret(conf.conf.Password)
+		}
+	}
+}
+
+// addDiagnosticsTableNames adds the names of the tables known from the migration state to the sanitizer
+func addDiagnosticsTableNames(s *diagnostics.Sanitizer, exportDir string, msr *metadb.MigrationStatusRecord, mdb *metadb.MetaDB) {
+	var tableNames []string
+	if msr != nil {
+		tableNames = append(tableNames, msr.TableListExportedFromSource...)
+		for from, to := range msr.SourceRenameTablesMap {
+			tableNames = append(tableNames, from, to)
+		}
+		for from, to := range msr.TargetRenameTablesMap {
+			tableNames = append(tableNames, from, to)
+		}
+	}
+
+	descriptorPath := exportDir + datafile.DESCRIPTOR_PATH
+	if utils.FileOrFolderExists(descriptorPath) {
+		// not using datafile.OpenDescriptor() as it exits on errors
+		var descriptor datafile.Descriptor
+		bytes, err := os.ReadFile(descriptorPath)
+		if err == nil {
+			err = json.Unmarshal(bytes, &descriptor)
+		}
+		if err != nil {
+			log.Warnf("read data file descriptor %q for the table names: %v", descriptorPath, err)
+		} else {
+			for _, entry := range descriptor.DataFileList {
+				tableNames = append(tableNames, entry.TableName)
+			}
+			for tableName := range descriptor.TableNameToExportedColumns {
+				tableNames = append(tableNames, tableName)
+			}
+		}
+	}
+
+	if mdb != nil {
+		stats, err := mdb.GetExportedEventsStatsPerTable()
+		if err != nil {
+			log.Warnf("get exported events stats per table for the table names: %v", err)
+		}
+		for _, stat := range stats {
+			tableNames = append(tableNames, stat.TableName)
+		}
+	}
+
+	// sorted for the same placeholders across the runs
+	slices.Sort(tableNames)
+	for _, tableName := range tableNames {
+		s.AddTableName(tableName)
+	}
+}
+
+func getDiagnosticsEnvironment(exportDir string) *diagnosticsEnvironment {
+	env := &diagnosticsEnvironment{
+		OS:               runtime.GOOS,
+		Arch:             runtime.GOARCH,
+		NumCPU:           runtime.NumCPU(),
+		GoVersion:        runtime.Version(),
+		EnvVarsSet:       []string{},
+		ControlPlaneType: getControlPlaneType(),
+	}
+	if out, err := exec.Command("uname", "-r").Output(); err == nil {
+		env.KernelVersion = strings.TrimSpace(string(out))
+	}
+	if meminfo, err := os.ReadFile("/proc/meminfo"); err == nil {
+		for _, line := range strings.Split(string(meminfo), "\n") {
+			if value, found := strings.CutPrefix(line, "MemTotal:"); found {
+				env.MemTotal = strings.TrimSpace(value)
+				break
+			}
+		}
+	}
+	// java -version prints to stderr
+	if out, err := exec.Command("java", "-version").CombinedOutput(); err == nil {
+		env.JavaVersion, _, _ = strings.Cut(string(out), "\n")
+	}
+	if freeSpace, err := getFreeDiskSpace(exportDir); err == nil {
+		env.ExportDirFreeDiskSpace = freeSpace
+	}
+	for _, envVar := range os.Environ() {
+		name, _, _ := strings.Cut(envVar, "=")
+		if slices.Contains(diagnosticsEnvVars, name) || strings.HasPrefix(name, "YB_VOYAGER_") {
+			env.EnvVarsSet = append(env.EnvVarsSet, name)
+		}
+	}
+	slices.Sort(env.EnvVarsSet)
+	if secretSourceSpec != "" {
+		env.SecretSourceType, _, _ = strings.Cut(secretSourceSpec, ":")
+	}
+	return env
+}
+
+func (c *diagnosticsCollector) collect() {
+	if c.msr != nil {
+		c.addJSON("migration_status_record.json", c.msr)
+	}
+	if c.metaDB != nil {
+		c.collectMetaDB()
+		c.collectQueueSegmentsSummary()
+	}
+
+	descriptorPath := c.exportDir + datafile.DESCRIPTOR_PATH
+	if utils.FileOrFolderExists(descriptorPath) {
+		c.addFile(strings.TrimPrefix(datafile.DESCRIPTOR_PATH, "/"), descriptorPath)
+	}
+	// debezium config
+	c.addDir(filepath.Join("metainfo", "conf"), c.addFile)
+	// voyager, debezium and serve job logs
+	c.addDir("logs", c.addLogFile)
+}
+
+func (c *diagnosticsCollector) collectMetaDB() {
+	tableNames, err := c.metaDB.GetTableNames()
+	if err != nil {
+		c.failed("metadb", err)
+		return
+	}
+	for _, tableName := range tableNames {
+		rows, err := c.metaDB.GetTableRows(tableName)
+		if err != nil {
+			c.failed("metadb/"+tableName, err)
+			continue
+		}
+		c.addJSON(fmt.Sprintf("metadb/%s.json", tableName), rows)
+	}
+}
+
+func (c *diagnosticsCollector) collectQueueSegmentsSummary() {
+	result := []*diagnosticsQueueSegmentsSummary{}
+	if c.msr != nil {
+		for _, roles := range getMonitorImporterRoles(c.msr) {
+			stats, err := c.metaDB.GetQueueSegmentsStats(roles[0], roles[1])
+			if err != nil {
+				c.failed("queue segments summary", fmt.Errorf("get queue segments stats for %s: %w", roles[0], err))
+				return
+			}
+			result = append(result, &diagnosticsQueueSegmentsSummary{
+				ImporterRole: roles[0], ExporterRole: roles[1], QueueSegmentsStats: *stats})
+		}
+	}
+	c.addJSON("queue_segments_summary.json", result)
+}
+
+// addDir adds all the files under the dir (relative to the export dir) using the addFn
+func (c *diagnosticsCollector) addDir(dir string, addFn func(name string, path string)) {
+	root := filepath.Join(c.exportDir, dir)
+	if !utils.FileOrFolderExists(root) {
+		return
+	}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			c.failed(path, err)
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		name, err := filepath.Rel(c.exportDir, path)
+		if err != nil {
+			return err
+		}
+		addFn(filepath.ToSlash(name), path)
+		return nil
+	})
+	if err != nil {
+		c.failed(dir, err)
+	}
+}
+
+func (c *diagnosticsCollector) addFile(name string, path string) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		c.failed(name, err)
+		return
+	}
+	c.add(name, []byte(c.sanitizer.Sanitize(string(content))))
+}
+
+// addLogFile adds the log file sanitized line by line, with only the end of the file if it is larger than the max size
+func (c *diagnosticsCollector) addLogFile(name string, path string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		c.failed(name, err)
+		return
+	}
+	var buf bytes.Buffer
+	var lines []string
+	if info.Size() > c.maxLogFileSize {
+		fmt.Fprintf(&buf, DIAGNOSTICS_TRUNCATED_LOG_FMT+"\n", c.maxLogFileSize/(1024*1024), info.Size())
+		lines, err = readLogFileTail(path, c.maxLogFileSize)
+	} else {
+		lines, err = readDiagnosticsLogFile(path)
+	}
+	if err != nil {
+		c.failed(name, err)
+		return
+	}
+	for _, line := range lines {
+		buf.WriteString(c.sanitizer.Sanitize(line))
+		buf.WriteByte('\n')
+	}
+	c.add(name, buf.Bytes())
+}
+
+func readDiagnosticsLogFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+func (c *diagnosticsCollector) addJSON(name string, v any) {
+	content, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		c.failed(name, err)
+		return
+	}
+	c.add(name, []byte(c.sanitizer.Sanitize(string(content))))
+}
+
+func (c *diagnosticsCollector) add(name string, content []byte) {
+	c.files = append(c.files, &diagnosticsFile{name: name, content: content})
+	c.summary.CollectedFiles = append(c.summary.CollectedFiles, name)
+}
+
+func (c *diagnosticsCollector) failed(name string, err error) {
+	log.Warnf("collect %q for diagnostics: %v", name, err)
+	c.summary.FailedToCollect = append(c.summary.FailedToCollect, c.sanitizer.Sanitize(fmt.Sprintf("%s: %v", name, err)))
+}
+
+// writeArchive writes the summary and the collected files to the tar.gz archive, under a dir of the same name as the archive
+func (c *diagnosticsCollector) writeArchive(outputFile string) (err error) {
+	summary, err := json.MarshalIndent(c.summary, "", "    ")
+	if err != nil {
+		return fmt.Errorf("marshal summary: %w", err)
+	}
+	files := append([]*diagnosticsFile{{name: "summary.json", content: []byte(c.sanitizer.Sanitize(string(summary)))}}, c.files...)
+
+	err = os.MkdirAll(filepath.Dir(outputFile), 0755)
+	if err != nil {
+		return fmt.Errorf("create dir: %w", err)
+	}
+	f, err := os.OpenFile(outputFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, DIAGNOSTICS_FILE_PERMISSIONS)
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+	defer func() {
+		closeErr := f.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("close file: %w", closeErr)
+		}
+	}()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	rootDir := strings.TrimSuffix(filepath.Base(outputFile), ".tar.gz")
+	for _, file := range files {
+		header := &tar.Header{
+			Name:    rootDir + "/" + file.name,
+			Mode:    DIAGNOSTICS_FILE_PERMISSIONS,
+			Size:    int64(len(file.content)),
+			ModTime: c.summary.CollectedAt.Truncate(time.Second),
+		}
+		err = tw.WriteHeader(header)
+		if err != nil {
+			return fmt.Errorf("write header of %q: %w", file.name, err)
+		}
+		_, err = tw.Write(file.content)
+		if err != nil {
+			return fmt.Errorf("write %q: %w", file.name, err)
+		}
+	}
+	err = tw.Close()
+	if err != nil {
+		return fmt.Errorf("close tar writer: %w", err)
+	}
+	err = gw.Close()
+	if err != nil {
+		return fmt.Errorf("close gzip writer: %w", err)
+	}
+	return nil
+}
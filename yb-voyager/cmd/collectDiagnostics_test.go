@@ -0,0 +1,138 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/srcdb"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/tgtdb"
+)
+
+func readDiagnosticsArchive(t *testing.T, path string) map[string]string {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	result := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		result[header.Name] = string(content)
+	}
+	return result
+}
+
+func writeDiagnosticsTestFile(t *testing.T, path string, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestCollectDiagnostics(t *testing.T) {
+	exportDir := t.TempDir()
+	t.Setenv("TARGET_DB_PASSWORD", "tgt-Secret")
+	writeDiagnosticsTestFile(t, filepath.Join(exportDir, "logs", "yb-voyager-import-data.log"),
+		`2024-11-05 10:15:02 INFO Args: [yb-voyager import data --target-db-host yb.example.com --target-db-password tgt-Secret]
+2024-11-05 10:15:03 ERROR copy into public.orders on yb.example.com(10.1.2.3:5433) as yb_admin: failed
+`)
+	writeDiagnosticsTestFile(t, filepath.Join(exportDir, "logs", "debezium-source_db_exporter.log"),
+		"connected to pg.example.com for public.orders\n")
+	writeDiagnosticsTestFile(t, filepath.Join(exportDir, "metainfo", "conf", "application.properties"),
+		"debezium.source.database.hostname=pg.example.com\ndebezium.source.database.password=src-Secret\n")
+	writeDiagnosticsTestFile(t, filepath.Join(exportDir, "metainfo", "dataFileDescriptor.json"),
+		`{"FileList": [{"FilePath": "data/orders_data.sql", "TableName": "public.orders"}]}`)
+	writeDiagnosticsTestFile(t, filepath.Join(exportDir, "data", "orders_data.sql"), "1\tsecret row\n")
+
+	msr := &metadb.MigrationStatusRecord{
+		MigrationUUID:               "3e526cda-6fb3-46a9-ba1a-f4dc7d8d802f",
+		SourceDBConf:                &srcdb.Source{DBType: "postgresql", Host: "pg.example.com", User: "pg_admin", DBVersion: "15.4"},
+		TargetDBConf:                &tgtdb.TargetConf{TargetDBType: "yugabytedb", Host: "yb.example.com", User: "yb_admin", DBVersion: "11.2-YB-2.20.1.0-b0"},
+		TableListExportedFromSource: []string{"public.orders"},
+	}
+	c := newDiagnosticsCollector(exportDir, msr, nil, 1024*1024, time.Now())
+	addDiagnosticsSecretsAndIdentifiers(c.sanitizer, msr)
+	addDiagnosticsTableNames(c.sanitizer, exportDir, msr, nil)
+	c.summary.Environment = getDiagnosticsEnvironment(exportDir)
+	c.collect()
+	outputFile := filepath.Join(exportDir, "reports", "yb-voyager-diagnostics-test.tar.gz")
+	require.NoError(t, c.writeArchive(outputFile))
+
+	files := readDiagnosticsArchive(t, outputFile)
+	root := "yb-voyager-diagnostics-test/"
+	for _, name := range []string{"summary.json", "migration_status_record.json", "metainfo/dataFileDescriptor.json",
+		"metainfo/conf/application.properties", "logs/yb-voyager-import-data.log", "logs/debezium-source_db_exporter.log"} {
+		assert.Contains(t, files, root+name)
+	}
+	assert.NotContains(t, files, root+"data/orders_data.sql")
+	for name, content := range files {
+		for _, sensitive := range []string{"tgt-Secret", "src-Secret", "example.com", "10.1.2.3", "pg_admin", "yb_admin", "orders"} {
+			assert.NotContains(t, content, sensitive, name)
+		}
+	}
+	assert.Contains(t, files[root+"logs/yb-voyager-import-data.log"],
+		"ERROR copy into public.table_1 on target-db-host(x.x.x.x:5433) as target-db-user: failed")
+	assert.Contains(t, files[root+"metainfo/conf/application.properties"], "hostname=source-db-host")
+
+	var summary diagnosticsSummary
+	require.NoError(t, json.Unmarshal([]byte(files[root+"summary.json"]), &summary))
+	assert.Equal(t, msr.MigrationUUID, summary.MigrationUUID)
+	require.Len(t, summary.Databases, 2)
+	assert.Equal(t, "11.2-YB-2.20.1.0-b0", summary.Databases[1].DBVersion)
+	assert.Contains(t, summary.Environment.EnvVarsSet, "TARGET_DB_PASSWORD")
+	assert.Empty(t, summary.FailedToCollect)
+}
+
+func TestCollectDiagnosticsLargeLogFile(t *testing.T) {
+	exportDir := t.TempDir()
+	var sb strings.Builder
+	for i := 0; i < 1000; i++ {
+		sb.WriteString("2024-11-05 10:15:02 INFO some log line of the import data command\n")
+	}
+	sb.WriteString("2024-11-05 10:15:03 ERROR last line\n")
+	writeDiagnosticsTestFile(t, filepath.Join(exportDir, "logs", "yb-voyager-import-data.log"), sb.String())
+
+	c := newDiagnosticsCollector(exportDir, nil, nil, 1024, time.Now())
+	c.summary.Environment = getDiagnosticsEnvironment(exportDir)
+	c.collect()
+	outputFile := filepath.Join(exportDir, "diagnostics.tar.gz")
+	require.NoError(t, c.writeArchive(outputFile))
+
+	log := readDiagnosticsArchive(t, outputFile)["diagnostics/logs/yb-voyager-import-data.log"]
+	assert.True(t, strings.HasPrefix(log, "[yb-voyager collect diagnostics: only the last"), log)
+	assert.True(t, strings.HasSuffix(log, "ERROR last line\n"))
+	assert.Less(t, len(log), 1200)
+}
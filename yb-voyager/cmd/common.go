@@ -67,6 +67,7 @@ var (
 	startTime              time.Time
 	targetDbVersionStrFlag string
 	targetDbVersion        *ybversion.YBVersion
+	customRulesFileFlag    string
 )
 
 func PrintElapsedDuration() {
@@ -724,6 +725,14 @@ func getPassword(cmd *cobra.Command, cliArgName, envVarName string) (string, err
 	if os.Getenv(envVarName) != "" {
 		return os.Getenv(envVarName), nil
 	}
+	// the secret names are same as the password flags
+	password, err := getPasswordFromSecretSource(cliArgName)
+	if err != nil {
+		return "", err
+	}
+	if password != "" {
+		return password, nil
+	}
 	fmt.Printf("Password to connect to %s (In addition, you can also set the password using the environment variable `%s`): ",
 		strings.TrimSuffix(cliArgName, "-password"), envVarName)
 	bytePassword, err := term.ReadPassword(int(syscall.Stdin))
@@ -902,6 +911,73 @@ func initBaseTargetEvent(bev *cp.BaseEvent, eventType string) {
 	}
 }
 
+// getMigrationPhaseForRole returns the migration phase reported to the control plane for an exporter/importer role.
+func getMigrationPhaseForRole(role string) string {
+	switch role {
+	case SOURCE_DB_EXPORTER_ROLE:
+		return "EXPORT DATA"
+	case TARGET_DB_IMPORTER_ROLE:
+		return "IMPORT DATA"
+	case TARGET_DB_EXPORTER_FF_ROLE, TARGET_DB_EXPORTER_FB_ROLE:
+		return "EXPORT DATA FROM TARGET"
+	case SOURCE_REPLICA_DB_IMPORTER_ROLE:
+		return "IMPORT DATA TO SOURCE REPLICA"
+	case SOURCE_DB_IMPORTER_ROLE:
+		return "IMPORT DATA TO SOURCE"
+	default:
+		panic(fmt.Sprintf("invalid role %s", role))
+	}
+}
+
+// initBaseEventForRole initializes the event with the details of the database the exporter/importer is connected to.
+func initBaseEventForRole(bev *cp.BaseEvent, role string) {
+	switch role {
+	case SOURCE_DB_EXPORTER_ROLE, TARGET_DB_EXPORTER_FF_ROLE, TARGET_DB_EXPORTER_FB_ROLE:
+		initBaseSourceEvent(bev, getMigrationPhaseForRole(role))
+	default:
+		initBaseTargetEvent(bev, getMigrationPhaseForRole(role))
+	}
+}
+
+func markLiveMigrationPhaseStarted(role string) {
+	recordPhaseTimestamp(getMigrationPhaseForRole(role), func(ts *metadb.PhaseTimestamps, now time.Time) {
+		ts.StreamingStartedAt = now
+	})
+	ev := &cp.LiveMigrationPhaseStartedEvent{Role: role}
+	initBaseEventForRole(&ev.BaseEvent, role)
+	controlPlane.LiveMigrationPhaseStarted(ev)
+}
+
+// recordPhaseTimestamp saves the timestamps of the migration phases in the MSR for the reports.
+// Failing to do so is not fatal for the migration.
+func recordPhaseTimestamp(phase string, updateFn func(ts *metadb.PhaseTimestamps, now time.Time)) {
+	if metaDB == nil {
+		return
+	}
+	now := time.Now().UTC()
+	err := metaDB.UpdatePhaseTimestamps(phase, func(ts *metadb.PhaseTimestamps) {
+		updateFn(ts, now)
+	})
+	if err != nil {
+		log.Warnf("failed to record timestamp for phase %q: %v", phase, err)
+	}
+}
+
+// recordPhaseStarted records the start of the first run of the phase, unless the phase is started afresh.
+func recordPhaseStarted(phase string, startClean bool) {
+	recordPhaseTimestamp(phase, func(ts *metadb.PhaseTimestamps, now time.Time) {
+		if startClean || ts.StartedAt.IsZero() {
+			*ts = metadb.PhaseTimestamps{StartedAt: now}
+		}
+	})
+}
+
+func recordPhaseCompleted(phase string) {
+	recordPhaseTimestamp(phase, func(ts *metadb.PhaseTimestamps, now time.Time) {
+		ts.CompletedAt = now
+	})
+}
+
 func renameTableIfRequired(table string) (string, bool) {
 	// required to rename the table name from leaf to root partition in case of pg_dump
 	// to be load data in target using via root table
@@ -1051,15 +1127,22 @@ func storeTableListInMSR(tableList []sqlname.NameTuple) error {
 
 // TODO: consider merging all unsupported field with single AssessmentReport struct member as AssessmentIssue
 type AssessmentReport struct {
-	VoyagerVersion                 string                                `json:"VoyagerVersion"`
-	TargetDBVersion                *ybversion.YBVersion                  `json:"TargetDBVersion"`
-	MigrationComplexity            string                                `json:"MigrationComplexity"`
-	MigrationComplexityExplanation string                                `json:"MigrationComplexityExplanation"`
-	SchemaSummary                  utils.SchemaSummary                   `json:"SchemaSummary"`
-	Sizing                         *migassessment.SizingAssessmentReport `json:"Sizing"`
-	Issues                         []AssessmentIssue                     `json:"-"` // disabled in reports till corresponding UI changes are done(json and html reports)
-	TableIndexStats                *[]migassessment.TableIndexStats      `json:"TableIndexStats"`
-	Notes                          []string                              `json:"Notes"`
+	VoyagerVersion                 string                                    `json:"VoyagerVersion"`
+	TargetDBVersion                *ybversion.YBVersion                      `json:"TargetDBVersion"`
+	MigrationComplexity            string                                    `json:"MigrationComplexity"`
+	MigrationComplexityExplanation string                                    `json:"MigrationComplexityExplanation"`
+	MigrationEffort                *MigrationEffort                          `json:"MigrationEffort,omitempty"`
+	SchemaSummary                  utils.SchemaSummary                       `json:"SchemaSummary"`
+	Sizing                         *migassessment.SizingAssessmentReport     `json:"Sizing"`
+	ShardingKeyRecommendations     []migassessment.ShardingKeyRecommendation `json:"ShardingKeyRecommendations,omitempty"`
+	RedundantIndexes               []migassessment.RedundantIndex            `json:"RedundantIndexes,omitempty"`
+	QueryPerformanceRisks          []migassessment.QueryPerformanceRisk      `json:"QueryPerformanceRisks,omitempty"`
+	DataProfile                    *migassessment.DataProfile                `json:"DataProfile,omitempty"`
+	Workload                       *migassessment.WorkloadProfile            `json:"Workload,omitempty"`
+	Issues                         []AssessmentIssue                         `json:"-"` // disabled in reports till corresponding UI changes are done(json and html reports)
+	TableIndexStats                *[]migassessment.TableIndexStats          `json:"TableIndexStats"`
+	Notes                          []string                                  `json:"Notes"`
+	CustomRuleFindings             []CustomRuleFinding                       `json:"CustomRuleFindings,omitempty"`
 
 	// fields going to be deprecated
 	UnsupportedDataTypes       []utils.TableColumnsDataTypes     `json:"UnsupportedDataTypes"`
@@ -1095,23 +1178,38 @@ type UnsupportedFeature struct {
 	MinimumVersionsFixedIn map[string]*ybversion.YBVersion `json:"MinimumVersionsFixedIn"` // key: series (2024.1, 2.21, etc)
 }
 
+// findings of a user-defined rule(--custom-rules-file) in the schema and the queries
+type CustomRuleFinding struct {
+	RuleId      string       `json:"RuleId"`
+	RuleName    string       `json:"RuleName"`
+	Description string       `json:"Description,omitempty"`
+	Impact      string       `json:"Impact"`
+	Suggestion  string       `json:"Suggestion,omitempty"`
+	DocsLink    string       `json:"DocsLink,omitempty"`
+	Objects     []ObjectInfo `json:"Objects"`
+}
+
 type ObjectInfo struct {
 	ObjectType   string `json:"ObjectType,omitempty"`
 	ObjectName   string
 	SqlStatement string
+	IsDynamicSQL bool `json:"IsDynamicSQL,omitempty"`
 }
 
 // ======================================================================
 type BulkAssessmentReport struct {
-	Details []AssessmentDetail `json:"Detail"`
-	Notes   []string           `json:"Notes"`
+	Details                     []AssessmentDetail `json:"Detail"`
+	TotalMigrationEffortInHours float64            `json:"TotalMigrationEffortInHours,omitempty"`
+	Notes                       []string           `json:"Notes"`
 }
 
 type AssessmentDetail struct {
-	Schema             string `json:"Schema"`
-	DatabaseIdentifier string `json:"DatabaseIdentifier"`
-	ReportPath         string `json:"ReportPath"`
-	Status             string `json:"Status"`
+	Schema                 string  `json:"Schema"`
+	DatabaseIdentifier     string  `json:"DatabaseIdentifier"`
+	ReportPath             string  `json:"ReportPath"`
+	Status                 string  `json:"Status"`
+	MigrationComplexity    string  `json:"MigrationComplexity,omitempty"`
+	MigrationEffortInHours float64 `json:"MigrationEffortInHours,omitempty"`
 }
 
 type AssessMigrationDBConfig struct {
@@ -1195,6 +1293,30 @@ func (ar *AssessmentReport) AppendIssues(issues ...AssessmentIssue) {
 	ar.Issues = append(ar.Issues, issues...)
 }
 
+// AddCustomRuleFinding adds the object to the finding of the rule and the corresponding assessment issue
+func (ar *AssessmentReport) AddCustomRuleFinding(issue AssessmentIssue, suggestion string) {
+	idx := slices.IndexFunc(ar.CustomRuleFindings, func(f CustomRuleFinding) bool {
+		return f.RuleId == issue.Type
+	})
+	if idx == -1 {
+		ar.CustomRuleFindings = append(ar.CustomRuleFindings, CustomRuleFinding{
+			RuleId:      issue.Type,
+			RuleName:    issue.Name,
+			Description: issue.Description,
+			Impact:      issue.Impact,
+			Suggestion:  suggestion,
+			DocsLink:    issue.DocsLink,
+		})
+		idx = len(ar.CustomRuleFindings) - 1
+	}
+	ar.CustomRuleFindings[idx].Objects = append(ar.CustomRuleFindings[idx].Objects, ObjectInfo{
+		ObjectType:   issue.ObjectType,
+		ObjectName:   issue.ObjectName,
+		SqlStatement: issue.SqlStatement,
+	})
+	ar.AppendIssues(issue)
+}
+
 func (ar *AssessmentReport) GetShardedTablesRecommendation() ([]string, error) {
 	if ar.Sizing == nil {
 		return nil, fmt.Errorf("sizing report is null, can't fetch sharded tables")
@@ -1203,6 +1325,13 @@ func (ar *AssessmentReport) GetShardedTablesRecommendation() ([]string, error) {
 	return ar.Sizing.SizingRecommendation.ShardedTables, nil
 }
 
+// GetRangeShardingKeyRecommendations returns the primary keys/indexes recommended to be range sharded(ASC/DESC)
+func (ar *AssessmentReport) GetRangeShardingKeyRecommendations() []migassessment.ShardingKeyRecommendation {
+	return lo.Filter(ar.ShardingKeyRecommendations, func(r migassessment.ShardingKeyRecommendation, _ int) bool {
+		return r.IsRangeSharding()
+	})
+}
+
 func (ar *AssessmentReport) GetColocatedTablesRecommendation() ([]string, error) {
 	if ar.Sizing == nil {
 		return nil, fmt.Errorf("sizing report is null, can't fetch colocated tables")
@@ -1385,6 +1514,11 @@ func (dbConfig *AssessMigrationDBConfig) GetAssessmentLogFilePath() string {
 	return fmt.Sprintf("%s/logs/yb-voyager-assess-migration.log", dbConfig.GetAssessmentExportDirPath())
 }
 
+// console output of the assessment when running the assessments in parallel
+func (dbConfig *AssessMigrationDBConfig) GetAssessmentOutputFilePath() string {
+	return filepath.Join(bulkAssessmentDir, "logs", fmt.Sprintf("assess-migration-%s.out", dbConfig.GetSchemaIdentifier()))
+}
+
 // ==========================================================================
 
 func shouldSendCallhome() bool {
@@ -1410,6 +1544,51 @@ func createCallhomePayload() callhome.Payload {
 	return payload
 }
 
+// SendCommandFailedEventOnExit reports the failure of the command to the control plane, if the command exited with an error.
+func SendCommandFailedEventOnExit() {
+	if utils.ErrExitErr == nil || controlPlane == nil {
+		return
+	}
+	ev := &cp.CommandFailedEvent{
+		Command:  currentCommand,
+		ErrorMsg: callhome.SanitizeErrorMsg(utils.ErrExitErr.Error()),
+	}
+	ev.EventType = getMigrationPhaseForCommand(currentCommand)
+	ev.MigrationUUID = migrationUUID
+	controlPlane.CommandFailed(ev)
+	// PersistentPostRun, which finalizes the control plane, is not run in case of errors
+	controlPlane.Finalize()
+}
+
+func getMigrationPhaseForCommand(cmdPath string) string {
+	switch cmdPath {
+	case assessMigrationCmd.CommandPath():
+		return "ASSESS MIGRATION"
+	case exportSchemaCmd.CommandPath():
+		return "EXPORT SCHEMA"
+	case analyzeSchemaCmd.CommandPath():
+		return "ANALYZE SCHEMA"
+	case importSchemaCmd.CommandPath():
+		return "IMPORT SCHEMA"
+	case exportDataCmd.CommandPath(), exportDataFromSrcCmd.CommandPath():
+		return "EXPORT DATA"
+	case exportDataFromTargetCmd.CommandPath():
+		return "EXPORT DATA FROM TARGET"
+	case importDataCmd.CommandPath(), importDataToTargetCmd.CommandPath():
+		return "IMPORT DATA"
+	case importDataToSourceReplicaCmd.CommandPath():
+		return "IMPORT DATA TO SOURCE REPLICA"
+	case importDataToSourceCmd.CommandPath():
+		return "IMPORT DATA TO SOURCE"
+	case endMigrationCmd.CommandPath():
+		return "END MIGRATION"
+	case cutoverToTargetCmd.CommandPath(), cutoverToSourceReplicaCmd.CommandPath(), cutoverToSourceCmd.CommandPath():
+		return "CUTOVER"
+	default:
+		return ""
+	}
+}
+
 func PackAndSendCallhomePayloadOnExit() {
 	if callHomeErrorOrCompletePayloadSent {
 		return
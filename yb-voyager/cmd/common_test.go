@@ -73,6 +73,9 @@ func TestAssessmentReportStructs(t *testing.T) {
 				OptimalInsertConnectionsPerNode int64
 				EstimatedTimeInMinForImport     float64
 				ParallelVoyagerJobs             float64
+				ReplicationFactor               int
+				ExperimentDataset               string
+				InstanceType                    string
 			}{},
 		},
 		{
@@ -125,26 +128,43 @@ func TestAssessmentReportStructs(t *testing.T) {
 				SizeInBytes     *int64  `json:"SizeInBytes"`
 			}{},
 		},
+		{
+			name:       "Validate MigrationEffort Struct Definition",
+			actualType: reflect.TypeOf(MigrationEffort{}),
+			expectedType: struct {
+				TotalHours float64                    `json:"TotalHours"`
+				ByCategory []MigrationEffortBreakdown `json:"ByCategory"`
+				BySchema   []MigrationEffortBreakdown `json:"BySchema"`
+				ByObject   []MigrationEffortBreakdown `json:"ByObject"`
+			}{},
+		},
 		{
 			name:       "Validate AssessmentReport Struct Definition",
 			actualType: reflect.TypeOf(AssessmentReport{}),
 			expectedType: struct {
-				VoyagerVersion                 string                                `json:"VoyagerVersion"`
-				TargetDBVersion                *ybversion.YBVersion                  `json:"TargetDBVersion"`
-				MigrationComplexity            string                                `json:"MigrationComplexity"`
-				MigrationComplexityExplanation string                                `json:"MigrationComplexityExplanation"`
-				SchemaSummary                  utils.SchemaSummary                   `json:"SchemaSummary"`
-				Sizing                         *migassessment.SizingAssessmentReport `json:"Sizing"`
-				Issues                         []AssessmentIssue                     `json:"-"`
-				TableIndexStats                *[]migassessment.TableIndexStats      `json:"TableIndexStats"`
-				Notes                          []string                              `json:"Notes"`
-				UnsupportedDataTypes           []utils.TableColumnsDataTypes         `json:"UnsupportedDataTypes"`
-				UnsupportedDataTypesDesc       string                                `json:"UnsupportedDataTypesDesc"`
-				UnsupportedFeatures            []UnsupportedFeature                  `json:"UnsupportedFeatures"`
-				UnsupportedFeaturesDesc        string                                `json:"UnsupportedFeaturesDesc"`
-				UnsupportedQueryConstructs     []utils.UnsupportedQueryConstruct     `json:"UnsupportedQueryConstructs"`
-				UnsupportedPlPgSqlObjects      []UnsupportedFeature                  `json:"UnsupportedPlPgSqlObjects"`
-				MigrationCaveats               []UnsupportedFeature                  `json:"MigrationCaveats"`
+				VoyagerVersion                 string                                    `json:"VoyagerVersion"`
+				TargetDBVersion                *ybversion.YBVersion                      `json:"TargetDBVersion"`
+				MigrationComplexity            string                                    `json:"MigrationComplexity"`
+				MigrationComplexityExplanation string                                    `json:"MigrationComplexityExplanation"`
+				MigrationEffort                *MigrationEffort                          `json:"MigrationEffort,omitempty"`
+				SchemaSummary                  utils.SchemaSummary                       `json:"SchemaSummary"`
+				Sizing                         *migassessment.SizingAssessmentReport     `json:"Sizing"`
+				ShardingKeyRecommendations     []migassessment.ShardingKeyRecommendation `json:"ShardingKeyRecommendations,omitempty"`
+				RedundantIndexes               []migassessment.RedundantIndex            `json:"RedundantIndexes,omitempty"`
+				QueryPerformanceRisks          []migassessment.QueryPerformanceRisk      `json:"QueryPerformanceRisks,omitempty"`
+				DataProfile                    *migassessment.DataProfile                `json:"DataProfile,omitempty"`
+				Workload                       *migassessment.WorkloadProfile            `json:"Workload,omitempty"`
+				Issues                         []AssessmentIssue                         `json:"-"`
+				TableIndexStats                *[]migassessment.TableIndexStats          `json:"TableIndexStats"`
+				Notes                          []string                                  `json:"Notes"`
+				CustomRuleFindings             []CustomRuleFinding                       `json:"CustomRuleFindings,omitempty"`
+				UnsupportedDataTypes           []utils.TableColumnsDataTypes             `json:"UnsupportedDataTypes"`
+				UnsupportedDataTypesDesc       string                                    `json:"UnsupportedDataTypesDesc"`
+				UnsupportedFeatures            []UnsupportedFeature                      `json:"UnsupportedFeatures"`
+				UnsupportedFeaturesDesc        string                                    `json:"UnsupportedFeaturesDesc"`
+				UnsupportedQueryConstructs     []utils.UnsupportedQueryConstruct         `json:"UnsupportedQueryConstructs"`
+				UnsupportedPlPgSqlObjects      []UnsupportedFeature                      `json:"UnsupportedPlPgSqlObjects"`
+				MigrationCaveats               []UnsupportedFeature                      `json:"MigrationCaveats"`
 			}{},
 		},
 	}
@@ -341,7 +361,10 @@ func TestAssessmentReportJson(t *testing.T) {
 			"OptimalSelectConnectionsPerNode": 10,
 			"OptimalInsertConnectionsPerNode": 10,
 			"EstimatedTimeInMinForImport": 10,
-			"ParallelVoyagerJobs": 10
+			"ParallelVoyagerJobs": 10,
+			"ReplicationFactor": 0,
+			"ExperimentDataset": "",
+			"InstanceType": ""
 		},
 		"FailureReasoning": "Test failure reasoning"
 	},
@@ -0,0 +1,338 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/tebeka/atexit"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/constants"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/migassessment"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
+)
+
+const (
+	// exit code of the compare assessment-reports command when the current report has regressed w.r.t. the baseline
+	ASSESSMENT_REGRESSED_EXIT_CODE = 2
+)
+
+var (
+	baselineAssessmentReportPath string
+	currentAssessmentReportPath  string
+	comparisonOutputFormat       string
+	comparisonOutputFile         string
+	comparisonOutputFormats      = []string{"text", "json", "html"}
+	migrationComplexityOrder     = []string{constants.MIGRATION_COMPLEXITY_LOW, constants.MIGRATION_COMPLEXITY_MEDIUM,
+		constants.MIGRATION_COMPLEXITY_HIGH}
+)
+
+var compareAssessmentReportsCmd = &cobra.Command{
+	Use:   "assessment-reports",
+	Short: "Compare two migration assessment reports to track the progress of the migration readiness.",
+	Long: "Compare a baseline and a current migration assessment JSON report, and report the issues resolved, new and unchanged, " +
+		"the changes in the sizing and sharding recommendations and the change in the migration complexity.\n" +
+		fmt.Sprintf("Exits with code %d if the current report has regressed i.e. has new issues or higher migration complexity.",
+			ASSESSMENT_REGRESSED_EXIT_CODE),
+
+	PreRun: func(cmd *cobra.Command, args []string) {
+		validateReportOutputFormat(comparisonOutputFormats, comparisonOutputFormat)
+		for _, reportPath := range []string{baselineAssessmentReportPath, currentAssessmentReportPath} {
+			if !utils.FileOrFolderExists(reportPath) {
+				utils.ErrExit("assessment report %q does not exist", reportPath)
+			}
+		}
+	},
+
+	Run: func(cmd *cobra.Command, args []string) {
+		comparison, err := compareAssessmentReports(baselineAssessmentReportPath, currentAssessmentReportPath)
+		if err != nil {
+			utils.ErrExit("failed to compare assessment reports: %v", err)
+		}
+		err = writeAssessmentReportsComparison(comparison, strings.ToLower(comparisonOutputFormat), comparisonOutputFile)
+		if err != nil {
+			utils.ErrExit("failed to write assessment reports comparison: %v", err)
+		}
+		if comparison.Regressed {
+			atexit.Exit(ASSESSMENT_REGRESSED_EXIT_CODE)
+		}
+	},
+}
+
+func init() {
+	compareCmd.AddCommand(compareAssessmentReportsCmd)
+
+	compareAssessmentReportsCmd.Flags().StringVar(&baselineAssessmentReportPath, "baseline-report", "",
+		"Path of the baseline(older) migration assessment JSON report")
+	compareAssessmentReportsCmd.Flags().StringVar(&currentAssessmentReportPath, "current-report", "",
+		"Path of the current(newer) migration assessment JSON report")
+	compareAssessmentReportsCmd.Flags().StringVar(&comparisonOutputFormat, "output-format", "text",
+		fmt.Sprintf("format of the comparison. Supported formats are %v", comparisonOutputFormats))
+	compareAssessmentReportsCmd.Flags().StringVar(&comparisonOutputFile, "output-file", "",
+		"Path of the file to write the comparison to. Printed on the console if not provided (optional)")
+
+	compareAssessmentReportsCmd.MarkFlagRequired("baseline-report")
+	compareAssessmentReportsCmd.MarkFlagRequired("current-report")
+}
+
+type ValueChange struct {
+	Name     string `json:"Name"`
+	Baseline string `json:"Baseline"`
+	Current  string `json:"Current"`
+}
+
+type AssessmentReportsComparison struct {
+	BaselineReport      string                     `json:"BaselineReport"`
+	CurrentReport       string                     `json:"CurrentReport"`
+	MigrationComplexity ValueChange                `json:"MigrationComplexity"`
+	ResolvedIssues      []AssessmentIssueYugabyteD `json:"ResolvedIssues"`
+	NewIssues           []AssessmentIssueYugabyteD `json:"NewIssues"`
+	UnchangedIssues     []AssessmentIssueYugabyteD `json:"UnchangedIssues"`
+	SizingChanges       []ValueChange              `json:"SizingChanges"`   // only the changed parameters
+	ShardingChanges     []ValueChange              `json:"ShardingChanges"` // colocated/sharded tables and sharding of the keys
+	Regressed           bool                       `json:"Regressed"`
+	RegressionReasons   []string                   `json:"RegressionReasons,omitempty"`
+}
+
+func compareAssessmentReports(baselineReportPath string, currentReportPath string) (*AssessmentReportsComparison, error) {
+	baseline, err := ParseJSONToAssessmentReport(baselineReportPath)
+	if err != nil {
+		return nil, fmt.Errorf("parsing baseline report: %w", err)
+	}
+	current, err := ParseJSONToAssessmentReport(currentReportPath)
+	if err != nil {
+		return nil, fmt.Errorf("parsing current report: %w", err)
+	}
+
+	comparison := compareAssessmentReportContents(baseline, current)
+	comparison.BaselineReport = baselineReportPath
+	comparison.CurrentReport = currentReportPath
+	return comparison, nil
+}
+
+func compareAssessmentReportContents(baseline *AssessmentReport, current *AssessmentReport) *AssessmentReportsComparison {
+	comparison := &AssessmentReportsComparison{
+		MigrationComplexity: ValueChange{
+			Name:     "Migration Complexity",
+			Baseline: baseline.MigrationComplexity,
+			Current:  current.MigrationComplexity,
+		},
+	}
+
+	// the same key can be reported multiple times(e.g. the same issue in multiple statements of an object),
+	// so the issues are compared as multisets: only the extra occurrences on either side are resolved or new
+	baselineIssues := lo.GroupBy(flattenAssessmentReportToAssessmentIssues(*baseline), getAssessmentIssueKey)
+	currentIssues := lo.GroupBy(flattenAssessmentReportToAssessmentIssues(*current), getAssessmentIssueKey)
+	keys := lo.Uniq(append(lo.Keys(baselineIssues), lo.Keys(currentIssues)...))
+	for _, key := range keys {
+		numUnchanged := min(len(baselineIssues[key]), len(currentIssues[key]))
+		comparison.UnchangedIssues = append(comparison.UnchangedIssues, currentIssues[key][:numUnchanged]...)
+		comparison.ResolvedIssues = append(comparison.ResolvedIssues, baselineIssues[key][numUnchanged:]...)
+		comparison.NewIssues = append(comparison.NewIssues, currentIssues[key][numUnchanged:]...)
+	}
+	for _, issues := range [][]AssessmentIssueYugabyteD{comparison.ResolvedIssues, comparison.NewIssues, comparison.UnchangedIssues} {
+		sort.SliceStable(issues, func(i, j int) bool {
+			return getAssessmentIssueKey(issues[i]) < getAssessmentIssueKey(issues[j])
+		})
+	}
+
+	comparison.SizingChanges = compareSizing(baseline.Sizing, current.Sizing)
+	comparison.ShardingChanges = compareSharding(baseline, current)
+
+	if len(comparison.NewIssues) > 0 {
+		comparison.RegressionReasons = append(comparison.RegressionReasons, fmt.Sprintf("%d new issues", len(comparison.NewIssues)))
+	}
+	if slices.Index(migrationComplexityOrder, current.MigrationComplexity) > slices.Index(migrationComplexityOrder, baseline.MigrationComplexity) {
+		comparison.RegressionReasons = append(comparison.RegressionReasons, fmt.Sprintf("migration complexity increased from %s to %s",
+			baseline.MigrationComplexity, current.MigrationComplexity))
+	}
+	comparison.Regressed = len(comparison.RegressionReasons) > 0
+	return comparison
+}
+
+// issues are identified by the category, type and the object/statement they are reported on
+func getAssessmentIssueKey(issue AssessmentIssueYugabyteD) string {
+	return strings.Join([]string{issue.Type, issue.Subtype, issue.ObjectName, issue.SqlStatement}, "|")
+}
+
+func compareSizing(baseline *migassessment.SizingAssessmentReport, current *migassessment.SizingAssessmentReport) []ValueChange {
+	getSizingParameters := func(sizing *migassessment.SizingAssessmentReport) map[string]string {
+		if sizing == nil {
+			return nil
+		}
+		if sizing.FailureReasoning != "" {
+			return map[string]string{"Failure Reasoning": sizing.FailureReasoning}
+		}
+		rec := sizing.SizingRecommendation
+		return map[string]string{
+			"Num of Nodes":                         fmt.Sprint(rec.NumNodes),
+			"vCPU per instance":                    fmt.Sprint(rec.VCPUsPerInstance),
+			"Memory per instance(GiB)":             fmt.Sprint(rec.MemoryPerInstance),
+			"Optimal select connections per node":  fmt.Sprint(rec.OptimalSelectConnectionsPerNode),
+			"Optimal insert connections per node":  fmt.Sprint(rec.OptimalInsertConnectionsPerNode),
+			"Parallel Voyager Jobs":                fmt.Sprint(rec.ParallelVoyagerJobs),
+			"Estimated time for data import (min)": fmt.Sprint(rec.EstimatedTimeInMinForImport),
+			"Num of colocated tables":              fmt.Sprint(len(rec.ColocatedTables)),
+			"Num of sharded tables":                fmt.Sprint(len(rec.ShardedTables)),
+		}
+	}
+	return diffValues(getSizingParameters(baseline), getSizingParameters(current))
+}
+
+func compareSharding(baseline *AssessmentReport, current *AssessmentReport) []ValueChange {
+	getSharding := func(report *AssessmentReport) map[string]string {
+		result := make(map[string]string)
+		if report.Sizing != nil {
+			for _, table := range report.Sizing.SizingRecommendation.ColocatedTables {
+				result["table "+table] = migassessment.COLOCATED
+			}
+			for _, table := range report.Sizing.SizingRecommendation.ShardedTables {
+				result["table "+table] = migassessment.SHARDED
+			}
+		}
+		for _, rec := range report.ShardingKeyRecommendations {
+			result[fmt.Sprintf("index %s.%s", rec.SchemaName, rec.IndexName)] = rec.Sharding
+		}
+		return result
+	}
+	return diffValues(getSharding(baseline), getSharding(current))
+}
+
+// returns the changes between the values of the two maps sorted by the name, missing values are reported as empty
+func diffValues(baseline map[string]string, current map[string]string) []ValueChange {
+	names := lo.Uniq(append(lo.Keys(baseline), lo.Keys(current)...))
+	sort.Strings(names)
+
+	var changes []ValueChange
+	for _, name := range names {
+		if baseline[name] == current[name] {
+			continue
+		}
+		changes = append(changes, ValueChange{
+			Name:     name,
+			Baseline: baseline[name],
+			Current:  current[name],
+		})
+	}
+	return changes
+}
+
+//go:embed templates/assessment_reports_comparison.template
+var assessmentReportsComparisonHtmlTmpl string
+
+func writeAssessmentReportsComparison(comparison *AssessmentReportsComparison, format string, outputFile string) error {
+	var out io.Writer = os.Stdout
+	if outputFile != "" {
+		file, err := os.Create(outputFile)
+		if err != nil {
+			return fmt.Errorf("create file %q: %w", outputFile, err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	switch format {
+	case "json":
+		bytes, err := json.MarshalIndent(comparison, "", "\t")
+		if err != nil {
+			return fmt.Errorf("marshal comparison to json: %w", err)
+		}
+		_, err = fmt.Fprintln(out, string(bytes))
+		if err != nil {
+			return fmt.Errorf("write json: %w", err)
+		}
+	case "html":
+		tmpl, err := template.New("assessment-reports-comparison").Parse(assessmentReportsComparisonHtmlTmpl)
+		if err != nil {
+			return fmt.Errorf("failed to parse the assessment reports comparison template: %w", err)
+		}
+		err = tmpl.Execute(out, comparison)
+		if err != nil {
+			return fmt.Errorf("failed to execute parsed template file: %w", err)
+		}
+	default:
+		writeAssessmentReportsComparisonText(out, comparison, outputFile == "")
+	}
+
+	if outputFile != "" {
+		fmt.Printf("generated assessment reports comparison at: %s\n", outputFile)
+	}
+	return nil
+}
+
+func writeAssessmentReportsComparisonText(out io.Writer, comparison *AssessmentReportsComparison, colored bool) {
+	green, red := color.New(color.FgGreen), color.New(color.FgRed)
+	if !colored {
+		green.DisableColor()
+		red.DisableColor()
+	}
+
+	fmt.Fprintf(out, "Baseline report: %s\n", comparison.BaselineReport)
+	fmt.Fprintf(out, "Current report:  %s\n", comparison.CurrentReport)
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Migration complexity: %s -> %s\n", comparison.MigrationComplexity.Baseline, comparison.MigrationComplexity.Current)
+	fmt.Fprintf(out, "Issues: %s, %s, %d unchanged\n",
+		green.Sprintf("%d resolved", len(comparison.ResolvedIssues)),
+		red.Sprintf("%d new", len(comparison.NewIssues)),
+		len(comparison.UnchangedIssues))
+
+	printIssues := func(title string, issues []AssessmentIssueYugabyteD) {
+		if len(issues) == 0 {
+			return
+		}
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, title)
+		for _, issue := range issues {
+			object := lo.Ternary(issue.ObjectName != "", issue.ObjectName, issue.SqlStatement)
+			fmt.Fprintf(out, "  - [%s] %s: %s\n", issue.Type, issue.Subtype, object)
+		}
+	}
+	printIssues("Resolved issues:", comparison.ResolvedIssues)
+	printIssues("New issues:", comparison.NewIssues)
+
+	printChanges := func(title string, changes []ValueChange) {
+		if len(changes) == 0 {
+			return
+		}
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, title)
+		for _, change := range changes {
+			fmt.Fprintf(out, "  - %s: %s -> %s\n", change.Name, lo.Ternary(change.Baseline != "", change.Baseline, "--"),
+				lo.Ternary(change.Current != "", change.Current, "--"))
+		}
+	}
+	printChanges("Sizing changes:", comparison.SizingChanges)
+	printChanges("Sharding changes:", comparison.ShardingChanges)
+
+	fmt.Fprintln(out)
+	if comparison.Regressed {
+		fmt.Fprintln(out, red.Sprintf("Regressed: %s", strings.Join(comparison.RegressionReasons, ", ")))
+	} else {
+		fmt.Fprintln(out, green.Sprintf("No regression"))
+	}
+}
@@ -0,0 +1,165 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/constants"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/migassessment"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils/jsonfile"
+)
+
+func getTestAssessmentReportsForComparison() (*AssessmentReport, *AssessmentReport) {
+	baseline := &AssessmentReport{
+		MigrationComplexity: constants.MIGRATION_COMPLEXITY_MEDIUM,
+		Sizing: &migassessment.SizingAssessmentReport{
+			SizingRecommendation: migassessment.SizingRecommendation{
+				ColocatedTables:  []string{"public.t1", "public.t2"},
+				ShardedTables:    []string{"public.t3"},
+				NumNodes:         3,
+				VCPUsPerInstance: 4,
+			},
+		},
+		UnsupportedFeatures: []UnsupportedFeature{
+			{
+				FeatureName: "GIN indexes",
+				Objects: []ObjectInfo{
+					{ObjectName: "idx1", SqlStatement: "CREATE INDEX idx1 ON t1 USING gin(c1, c2)"},
+					{ObjectName: "idx2", SqlStatement: "CREATE INDEX idx2 ON t2 USING gin(c1, c2)"},
+				},
+			},
+		},
+		UnsupportedDataTypes: []utils.TableColumnsDataTypes{
+			{SchemaName: "public", TableName: "t1", ColumnName: "c3", DataType: "txid_snapshot"},
+		},
+	}
+	current := &AssessmentReport{
+		MigrationComplexity: constants.MIGRATION_COMPLEXITY_MEDIUM,
+		Sizing: &migassessment.SizingAssessmentReport{
+			SizingRecommendation: migassessment.SizingRecommendation{
+				ColocatedTables:  []string{"public.t1"},
+				ShardedTables:    []string{"public.t2", "public.t3"},
+				NumNodes:         6,
+				VCPUsPerInstance: 4,
+			},
+		},
+		ShardingKeyRecommendations: []migassessment.ShardingKeyRecommendation{
+			{SchemaName: "public", IndexName: "t3_pkey", Sharding: migassessment.SHARDING_ASC},
+		},
+		UnsupportedFeatures: []UnsupportedFeature{
+			{
+				FeatureName: "GIN indexes",
+				Objects: []ObjectInfo{
+					{ObjectName: "idx2", SqlStatement: "CREATE INDEX idx2 ON t2 USING gin(c1, c2)"},
+				},
+			},
+		},
+	}
+	return baseline, current
+}
+
+func TestCompareAssessmentReportContents(t *testing.T) {
+	baseline, current := getTestAssessmentReportsForComparison()
+
+	comparison := compareAssessmentReportContents(baseline, current)
+	assert.False(t, comparison.Regressed)
+	assert.Empty(t, comparison.NewIssues)
+	assert.Len(t, comparison.UnchangedIssues, 1)
+	assert.Equal(t, "idx2", comparison.UnchangedIssues[0].ObjectName)
+	assert.Len(t, comparison.ResolvedIssues, 2)
+	assert.Equal(t, constants.DATATYPE, comparison.ResolvedIssues[0].Type)
+	assert.Equal(t, "public.t1.c3", comparison.ResolvedIssues[0].ObjectName)
+	assert.Equal(t, "idx1", comparison.ResolvedIssues[1].ObjectName)
+
+	assert.Equal(t, []ValueChange{
+		{Name: "Num of Nodes", Baseline: "3", Current: "6"},
+		{Name: "Num of colocated tables", Baseline: "2", Current: "1"},
+		{Name: "Num of sharded tables", Baseline: "1", Current: "2"},
+	}, comparison.SizingChanges)
+	assert.Equal(t, []ValueChange{
+		{Name: "index public.t3_pkey", Baseline: "", Current: migassessment.SHARDING_ASC},
+		{Name: "table public.t2", Baseline: migassessment.COLOCATED, Current: migassessment.SHARDED},
+	}, comparison.ShardingChanges)
+
+	// reverse comparison has the resolved issues as new issues
+	comparison = compareAssessmentReportContents(current, baseline)
+	assert.True(t, comparison.Regressed)
+	assert.Len(t, comparison.NewIssues, 2)
+	assert.Equal(t, []string{"2 new issues"}, comparison.RegressionReasons)
+}
+
+func TestCompareAssessmentReportContents_ComplexityIncreased(t *testing.T) {
+	baseline, current := getTestAssessmentReportsForComparison()
+	current.MigrationComplexity = constants.MIGRATION_COMPLEXITY_HIGH
+
+	comparison := compareAssessmentReportContents(baseline, current)
+	assert.True(t, comparison.Regressed)
+	assert.Equal(t, []string{"migration complexity increased from MEDIUM to HIGH"}, comparison.RegressionReasons)
+}
+
+func TestCompareAssessmentReportContents_RepeatedIssues(t *testing.T) {
+	baseline, current := getTestAssessmentReportsForComparison()
+	// the same issue reported twice in the current report is one unchanged and one new issue
+	gin := current.UnsupportedFeatures[0].Objects[0]
+	current.UnsupportedFeatures[0].Objects = append(current.UnsupportedFeatures[0].Objects, gin)
+
+	comparison := compareAssessmentReportContents(baseline, current)
+	assert.True(t, comparison.Regressed)
+	assert.Len(t, comparison.UnchangedIssues, 1)
+	assert.Len(t, comparison.NewIssues, 1)
+	assert.Equal(t, "idx2", comparison.NewIssues[0].ObjectName)
+	assert.Len(t, comparison.ResolvedIssues, 2)
+
+	// and removing the repeated occurrence resolves only that one
+	comparison = compareAssessmentReportContents(current, baseline)
+	assert.Len(t, comparison.UnchangedIssues, 1)
+	assert.Equal(t, "idx2", comparison.UnchangedIssues[0].ObjectName)
+	assert.Contains(t, lo.Map(comparison.ResolvedIssues, func(issue AssessmentIssueYugabyteD, _ int) string {
+		return issue.ObjectName
+	}), "idx2")
+}
+
+func TestCompareAssessmentReports(t *testing.T) {
+	baseline, current := getTestAssessmentReportsForComparison()
+	dir := t.TempDir()
+	baselinePath := filepath.Join(dir, "baseline.json")
+	currentPath := filepath.Join(dir, "current.json")
+	assert.NoError(t, jsonfile.NewJsonFile[AssessmentReport](baselinePath).Create(baseline))
+	assert.NoError(t, jsonfile.NewJsonFile[AssessmentReport](currentPath).Create(current))
+
+	comparison, err := compareAssessmentReports(baselinePath, currentPath)
+	assert.NoError(t, err)
+	assert.Len(t, comparison.ResolvedIssues, 2)
+	assert.Equal(t, baselinePath, comparison.BaselineReport)
+
+	for _, format := range comparisonOutputFormats {
+		outputFile := filepath.Join(dir, "comparison."+format)
+		assert.NoError(t, writeAssessmentReportsComparison(comparison, format, outputFile))
+		bytes, err := os.ReadFile(outputFile)
+		assert.NoError(t, err)
+		assert.Contains(t, string(bytes), "idx1", "format: %s", format)
+		assert.Contains(t, string(bytes), "public.t3_pkey", "format: %s", format)
+	}
+}
@@ -0,0 +1,308 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gosuri/uitable"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/tebeka/atexit"
+)
+
+/*
+The config file (--config-file, or ~/.yb-voyager.yaml if not passed) is a YAML file with the following sections,
+refer docs/config_file.md for an example:
+  - global: flags applicable to all the commands e.g. export-dir, log-level
+  - source, target, source-replica: database connection flags without the "source-"/"target-"/"source-replica-" prefix
+    e.g. db-host under target sets --target-db-host
+  - <command>: flags of a single command, the section name is the command with spaces replaced by '-' e.g. import-data-to-target
+
+Value of a flag is resolved with the precedence: command line flag > environment variable > config file > default.
+The environment variable of a flag is YB_VOYAGER_<flag name in upper case with '-' replaced by '_'> e.g. YB_VOYAGER_PARALLEL_JOBS.
+The values from the environment variables and the config file mark the flags as changed, same as passing them on the
+command line(e.g. for the required flags). Use isFlagPassedOnCommandLine() for the checks which are only about the
+command line.
+*/
+
+const (
+	CONFIG_ENV_VAR_PREFIX = "YB_VOYAGER_"
+	GLOBAL_CONFIG_SECTION = "global"
+
+	FLAG_VALUE_SOURCE_FLAG    = "flag"
+	FLAG_VALUE_SOURCE_ENV     = "env"
+	FLAG_VALUE_SOURCE_CONFIG  = "config file"
+	FLAG_VALUE_SOURCE_DEFAULT = "default"
+)
+
+// config file sections for the database connection flags and the prefix of those flags
+var dbConfigSections = map[string]string{
+	"source":         "source-",
+	"target":         "target-",
+	"source-replica": "source-replica-",
+}
+
+// flags which are about the config file itself and can't be set in it
+var configFileFlags = []string{"config-file", "dry-run", "help"}
+
+var dryRun bool
+
+// source of the value of each flag of the command being executed, set by initConfig
+var flagValueSources map[string]string
+
+func registerConfigFileFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&cfgFile, "config-file", "",
+		"path of the YAML config file with the values of the flags (refer docs/config_file.md). "+
+			"Flags on the command line and environment variables take precedence over the config file")
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false,
+		"print the effective configuration of the command (after applying the config file and environment variables) and exit")
+}
+
+// initConfig applies the config file and environment variables to the flags of the command being executed.
+func initConfig() {
+	cmd, _, err := rootCmd.Find(os.Args[1:])
+	if err != nil {
+		// cobra reports the unknown command
+		return
+	}
+
+	if cfgFile != "" {
+		viper.SetConfigFile(cfgFile)
+	} else if home, err := os.UserHomeDir(); err == nil {
+		// Search config in home directory with name ".yb-voyager" (without extension).
+		viper.AddConfigPath(home)
+		viper.SetConfigName(".yb-voyager")
+	}
+	viper.SetConfigType("yaml")
+
+	var configValues map[string]string
+	err = viper.ReadInConfig()
+	if errors.As(err, &viper.ConfigFileNotFoundError{}) {
+		// no config file in the home directory
+	} else if err != nil {
+		// not using utils.ErrExit as logging is not initialized yet
+		fmt.Printf("ERROR: failed to read the config file %q: %v\n", viper.ConfigFileUsed(), err)
+		atexit.Exit(1)
+	} else {
+		if cfgFile == "" {
+			fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+		}
+		errs := validateConfig(viper.AllSettings())
+		if len(errs) > 0 {
+			fmt.Printf("ERROR: invalid config file %q:\n\t%s\n", viper.ConfigFileUsed(), strings.Join(errs, "\n\t"))
+			atexit.Exit(1)
+		}
+		configValues = getConfigValuesForCommand(cmd, viper.AllSettings())
+	}
+
+	flagValueSources, err = applyConfig(cmd, configValues, os.LookupEnv)
+	if err != nil {
+		fmt.Printf("ERROR: %v\n", err)
+		atexit.Exit(1)
+	}
+	if dryRun {
+		printEffectiveConfig(cmd, flagValueSources)
+		atexit.Exit(0)
+	}
+}
+
+func getFlagEnvVarName(flagName string) string {
+	return CONFIG_ENV_VAR_PREFIX + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// isFlagPassedOnCommandLine returns false for the flags set from the environment variables or the config file
+func isFlagPassedOnCommandLine(cmd *cobra.Command, flagName string) bool {
+	source, ok := flagValueSources[flagName]
+	if !ok {
+		return cmd.Flags().Changed(flagName)
+	}
+	return source == FLAG_VALUE_SOURCE_FLAG
+}
+
+// getAllCommands returns the runnable commands by their config file section names e.g. export-data-from-target
+func getAllCommands() map[string]*cobra.Command {
+	result := make(map[string]*cobra.Command)
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		if cmd.Runnable() && cmd != rootCmd {
+			result[GetCommandID(cmd)] = cmd
+		}
+		for _, subCmd := range cmd.Commands() {
+			walk(subCmd)
+		}
+	}
+	walk(rootCmd)
+	return result
+}
+
+func commandHasFlag(cmd *cobra.Command, flagName string) bool {
+	return cmd.Flags().Lookup(flagName) != nil || cmd.InheritedFlags().Lookup(flagName) != nil
+}
+
+// validateConfig returns the errors for the unknown sections and keys in the config file
+func validateConfig(settings map[string]any) []string {
+	commands := getAllCommands()
+	anyCommandHasFlag := func(flagName string) bool {
+		return lo.SomeBy(lo.Values(commands), func(cmd *cobra.Command) bool { return commandHasFlag(cmd, flagName) })
+	}
+
+	var errs []string
+	sections := lo.Keys(settings)
+	slices.Sort(sections)
+	for _, section := range sections {
+		values, ok := settings[section].(map[string]any)
+		if !ok {
+			errs = append(errs, fmt.Sprintf("section %q: expected a mapping of flag names to values", section))
+			continue
+		}
+		var isKnownFlag func(key string) bool
+		prefix, isDBSection := dbConfigSections[section]
+		cmd, isCmdSection := commands[section]
+		switch {
+		case section == GLOBAL_CONFIG_SECTION:
+			isKnownFlag = anyCommandHasFlag
+		case isDBSection:
+			isKnownFlag = func(key string) bool { return anyCommandHasFlag(prefix + key) }
+		case isCmdSection:
+			isKnownFlag = func(key string) bool { return commandHasFlag(cmd, key) }
+		default:
+			errs = append(errs, fmt.Sprintf("unknown section %q", section))
+			continue
+		}
+
+		keys := lo.Keys(values)
+		slices.Sort(keys)
+		for _, key := range keys {
+			switch {
+			case slices.Contains(configFileFlags, key):
+				errs = append(errs, fmt.Sprintf("%s.%s: can't be set in the config file", section, key))
+			case !isKnownFlag(key):
+				errs = append(errs, fmt.Sprintf("%s.%s: unknown key", section, key))
+			default:
+				_, err := configValueToString(values[key])
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("%s.%s: %v", section, key, err))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// getConfigValuesForCommand returns the flag values for the command from the (validated) config file settings.
+// Values in the command's section override the database sections which override the global section.
+func getConfigValuesForCommand(cmd *cobra.Command, settings map[string]any) map[string]string {
+	result := make(map[string]string)
+	apply := func(section string, prefix string) {
+		values, _ := settings[section].(map[string]any)
+		for key, value := range values {
+			if !commandHasFlag(cmd, prefix+key) {
+				continue
+			}
+			result[prefix+key], _ = configValueToString(value)
+		}
+	}
+	apply(GLOBAL_CONFIG_SECTION, "")
+	dbSections := lo.Keys(dbConfigSections)
+	slices.Sort(dbSections)
+	for _, section := range dbSections {
+		apply(section, dbConfigSections[section])
+	}
+	apply(GetCommandID(cmd), "")
+	return result
+}
+
+// configValueToString converts a YAML value into the string accepted by the flag. Lists are joined with ','.
+func configValueToString(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", fmt.Errorf("value is empty")
+	case map[string]any:
+		return "", fmt.Errorf("expected a value, found a mapping")
+	case []any:
+		var items []string
+		for _, item := range v {
+			s, err := configValueToString(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// applyConfig sets the flags which are not set on the command line from the environment variables and the config file values.
+// Returns the source of the value of each flag.
+func applyConfig(cmd *cobra.Command, configValues map[string]string, lookupEnv func(string) (string, bool)) (map[string]string, error) {
+	sources := make(map[string]string)
+	var err error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if err != nil || slices.Contains(configFileFlags, flag.Name) {
+			return
+		}
+		if flag.Changed {
+			sources[flag.Name] = FLAG_VALUE_SOURCE_FLAG
+			return
+		}
+		source := FLAG_VALUE_SOURCE_DEFAULT
+		value, found := lookupEnv(getFlagEnvVarName(flag.Name))
+		found = found && value != ""
+		if found {
+			source = FLAG_VALUE_SOURCE_ENV
+		} else {
+			value, found = configValues[flag.Name]
+			if found {
+				source = FLAG_VALUE_SOURCE_CONFIG
+			}
+		}
+		if found {
+			setErr := cmd.Flags().Set(flag.Name, value)
+			if setErr != nil {
+				err = fmt.Errorf("invalid value %q for flag --%s from %s: %w", value, flag.Name, source, setErr)
+				return
+			}
+		}
+		sources[flag.Name] = source
+	})
+	return sources, err
+}
+
+func printEffectiveConfig(cmd *cobra.Command, sources map[string]string) {
+	fmt.Printf("Effective configuration of '%s':\n\n", cmd.CommandPath())
+	table := uitable.New()
+	addHeader(table, "FLAG", "VALUE", "SOURCE")
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden || slices.Contains(configFileFlags, flag.Name) {
+			return
+		}
+		value := flag.Value.String()
+		if strings.Contains(flag.Name, "password") && value != "" {
+			value = "********"
+		}
+		table.AddRow("--"+flag.Name, value, sources[flag.Name])
+	})
+	fmt.Println(table)
+}
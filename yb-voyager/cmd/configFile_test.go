@@ -0,0 +1,149 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/srcdb"
+)
+
+func TestValidateConfig(t *testing.T) {
+	settings := map[string]any{
+		"global":                map[string]any{"export-dir": "/tmp/export-dir", "log-levl": "debug"},
+		"source":                map[string]any{"db-host": "localhost", "db-schema": []any{"public", "sales"}},
+		"target":                map[string]any{"db-port": 5433, "ssl-mode": map[string]any{"a": 1}},
+		"import-data-to-target": map[string]any{"parallel-jobs": 8, "export-type": "snapshot-only", "config-file": "x.yaml"},
+		"import-datas":          map[string]any{"parallel-jobs": 8},
+		"source-replica":        "localhost",
+	}
+	assert.Equal(t, []string{
+		"global.log-levl: unknown key",
+		"import-data-to-target.config-file: can't be set in the config file",
+		"import-data-to-target.export-type: unknown key",
+		`unknown section "import-datas"`,
+		`section "source-replica": expected a mapping of flag names to values`,
+		"target.ssl-mode: expected a value, found a mapping",
+	}, validateConfig(settings))
+}
+
+func TestConfigValuesForCommand(t *testing.T) {
+	settings := map[string]any{
+		"global":                map[string]any{"export-dir": "/tmp/export-dir", "parallel-jobs": 2, "export-type": "snapshot-only"},
+		"source":                map[string]any{"db-schema": []any{"public", "sales"}},
+		"target":                map[string]any{"db-host": "yb-host", "db-port": 5433},
+		"import-data-to-target": map[string]any{"parallel-jobs": 8},
+	}
+	values := getConfigValuesForCommand(importDataToTargetCmd, settings)
+	assert.Equal(t, map[string]string{
+		"export-dir":     "/tmp/export-dir",
+		"parallel-jobs":  "8", // command section overrides the global section
+		"target-db-host": "yb-host",
+		"target-db-port": "5433",
+	}, values)
+
+	values = getConfigValuesForCommand(exportDataFromSrcCmd, settings)
+	assert.Equal(t, "public,sales", values["source-db-schema"])
+	assert.Equal(t, "snapshot-only", values["export-type"])
+	assert.Equal(t, "2", values["parallel-jobs"])
+}
+
+func TestApplyConfigPrecedence(t *testing.T) {
+	var host, user, schema string
+	var port, parallelJobs int
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().StringVar(&host, "target-db-host", "127.0.0.1", "")
+	cmd.Flags().IntVar(&port, "target-db-port", 5433, "")
+	cmd.Flags().StringVar(&user, "target-db-user", "", "")
+	cmd.Flags().StringVar(&schema, "target-db-schema", "public", "")
+	cmd.Flags().IntVar(&parallelJobs, "parallel-jobs", 1, "")
+	cmd.MarkFlagRequired("target-db-host")
+	assert.NoError(t, cmd.ParseFlags([]string{"--target-db-user", "admin"}))
+
+	env := map[string]string{"YB_VOYAGER_TARGET_DB_PORT": "5544", "YB_VOYAGER_TARGET_DB_USER": "ignored",
+		"YB_VOYAGER_PARALLEL_JOBS": "4"}
+	lookupEnv := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+	configValues := map[string]string{"target-db-host": "yb-host", "target-db-port": "6000", "target-db-user": "ignored"}
+	sources, err := applyConfig(cmd, configValues, lookupEnv)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", user)
+	assert.Equal(t, 5544, port)
+	assert.Equal(t, "yb-host", host)
+	assert.Equal(t, "public", schema)
+	assert.Equal(t, 4, parallelJobs)
+	assert.Equal(t, map[string]string{
+		"target-db-user":   FLAG_VALUE_SOURCE_FLAG,
+		"target-db-port":   FLAG_VALUE_SOURCE_ENV,
+		"target-db-host":   FLAG_VALUE_SOURCE_CONFIG,
+		"target-db-schema": FLAG_VALUE_SOURCE_DEFAULT,
+		"parallel-jobs":    FLAG_VALUE_SOURCE_ENV,
+	}, sources)
+	// values from the env and the config file are treated as set by the user e.g. for the required flags
+	assert.True(t, cmd.Flags().Changed("target-db-host"))
+	assert.True(t, cmd.Flags().Changed("parallel-jobs"))
+	assert.False(t, cmd.Flags().Changed("target-db-schema"))
+	assert.NoError(t, cmd.ValidateRequiredFlags())
+
+	flagValueSources = sources
+	defer func() { flagValueSources = nil }()
+	assert.True(t, isFlagPassedOnCommandLine(cmd, "target-db-user"))
+	assert.False(t, isFlagPassedOnCommandLine(cmd, "target-db-host"))
+	assert.False(t, isFlagPassedOnCommandLine(cmd, "parallel-jobs"))
+
+	cmd = &cobra.Command{Use: "test"}
+	cmd.Flags().IntVar(&port, "target-db-port", 5433, "")
+	_, err = applyConfig(cmd, map[string]string{"target-db-port": "abc"}, func(string) (string, bool) { return "", false })
+	assert.ErrorContains(t, err, `invalid value "abc" for flag --target-db-port from config file`)
+}
+
+// the commands mark their flags required in the PreRun, which runs after the config is applied
+func TestApplyConfigExportSchemaPreRun(t *testing.T) {
+	cmd := exportSchemaCmd
+	t.Cleanup(func() {
+		cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			if flag.Changed {
+				flag.Value.Set(flag.DefValue)
+				flag.Changed = false
+			}
+		})
+		source = srcdb.Source{}
+	})
+	settings := map[string]any{
+		"global": map[string]any{"export-dir": t.TempDir()},
+		"source": map[string]any{"db-type": "postgresql", "db-user": "voyager", "db-password": "secret",
+			"db-name": "orders", "db-schema": []any{"public", "sales"}},
+	}
+	require.NoError(t, cmd.ParseFlags(nil))
+	_, err := applyConfig(cmd, getConfigValuesForCommand(cmd, settings), func(string) (string, bool) { return "", false })
+	require.NoError(t, err)
+
+	cmd.PreRun(cmd, nil)
+	assert.NoError(t, cmd.ValidateRequiredFlags())
+	assert.Equal(t, "orders", source.DBName)
+	assert.Equal(t, "public|sales", source.Schema)
+	assert.Equal(t, "secret", source.Password)
+	assert.Equal(t, POSTGRES_DEFAULT_PORT, source.Port)
+}
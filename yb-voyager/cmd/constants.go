@@ -109,14 +109,17 @@ const (
 	UNSUPPORTED_QUERY_CONSTRUCTS_CATEGORY = "unsupported_query_constructs"
 	UNSUPPORTED_PLPGSQL_OBJECTS_CATEGORY  = "unsupported_plpgsql_objects"
 	MIGRATION_CAVEATS_CATEGORY            = "migration_caveats"
+	CUSTOM_RULES_CATEGORY                 = "custom_rules"
 	REPORT_UNSUPPORTED_QUERY_CONSTRUCTS   = "REPORT_UNSUPPORTED_QUERY_CONSTRUCTS"
 
 	HTML = "html"
 	JSON = "json"
 
 	TABLE     = "TABLE"
+	INDEX     = "INDEX"
 	MVIEW     = "MVIEW"
 	YUGABYTED = "yugabyted"
+	WEBHOOK   = "webhook"
 
 	// assess-migration-bulk
 	SOURCE_DB_TYPE     = "source-db-type"
@@ -183,6 +186,7 @@ const (
 	MIGRATION_CAVEATS_CATEGORY_DESCRIPTION            = "Migration Caveats highlights the current limitations with the migration workflow."
 	UNSUPPORTED_QUERY_CONSTRUCTS_CATEGORY_DESCRIPTION = "Source database queries not supported in YugabyteDB, identified by scanning system tables."
 	UNSUPPPORTED_PLPGSQL_OBJECT_CATEGORY_DESCRIPTION  = "Source schema objects having unsupported statements on the target YugabyteDB in PL/pgSQL code block"
+	CUSTOM_RULES_CATEGORY_DESCRIPTION                 = "Source schema objects and queries matching the user-defined rules provided in the custom rules file."
 	SCHEMA_SUMMARY_DESCRIPTION                        = "Objects that will be created on the target YugabyteDB."
 	SCHEMA_SUMMARY_DESCRIPTION_ORACLE                 = SCHEMA_SUMMARY_DESCRIPTION + " Some of the index and sequence names might be different from those in the source database."
 
@@ -255,6 +259,8 @@ func GetCategoryDescription(category string) string {
 		return UNSUPPPORTED_PLPGSQL_OBJECT_CATEGORY_DESCRIPTION
 	case MIGRATION_CAVEATS_CATEGORY: // or constants.MIGRATION_CAVEATS (identical)
 		return MIGRATION_CAVEATS_CATEGORY_DESCRIPTION
+	case CUSTOM_RULES_CATEGORY, constants.CUSTOM_RULE:
+		return CUSTOM_RULES_CATEGORY_DESCRIPTION
 	default:
 		utils.ErrExit("ERROR: unsupported assessment issue category %q", category)
 	}
@@ -17,8 +17,12 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/cp"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/dbzm"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
@@ -103,10 +107,69 @@ func InitiateCutover(dbRole string, prepareforFallback bool, useYBgRPCConnector
 		utils.PrintAndLog(alreadyInitiatedMsg)
 	} else {
 		utils.PrintAndLog("%s initiated, wait for it to complete", userFacingActionMsg)
+		// the cutover commands don't load the migration UUID
+		err = retrieveMigrationUUID()
+		if err != nil {
+			log.Warnf("failed to get migration UUID: %v", err)
+		}
+		recordPhaseStarted(getCutoverPhase(dbRole), false)
+		ev := &cp.CutoverInitiatedEvent{CutoverType: dbRole, PrepareForFallback: prepareforFallback}
+		ev.EventType = "CUTOVER"
+		ev.MigrationUUID = migrationUUID
+		controlPlane.CutoverInitiated(ev)
 	}
 	return nil
 }
 
+func getCutoverTypeForRole(importerOrExporterRole string) string {
+	switch importerOrExporterRole {
+	case SOURCE_DB_EXPORTER_ROLE, TARGET_DB_IMPORTER_ROLE:
+		return "target"
+	case TARGET_DB_EXPORTER_FF_ROLE, SOURCE_REPLICA_DB_IMPORTER_ROLE:
+		return "source-replica"
+	case TARGET_DB_EXPORTER_FB_ROLE, SOURCE_DB_IMPORTER_ROLE:
+		return "source"
+	default:
+		panic(fmt.Sprintf("invalid role %s", importerOrExporterRole))
+	}
+}
+
+// phase under which the timestamps of the cutover are recorded e.g. "CUTOVER TO SOURCE-REPLICA"
+func getCutoverPhase(cutoverType string) string {
+	return "CUTOVER TO " + strings.ToUpper(cutoverType)
+}
+
+func sendCutoverProcessedEvent(importerOrExporterRole string) {
+	cutoverType := getCutoverTypeForRole(importerOrExporterRole)
+	ev := &cp.CutoverProcessedEvent{CutoverType: cutoverType, Role: importerOrExporterRole}
+	initBaseEventForRole(&ev.BaseEvent, importerOrExporterRole)
+	ev.EventType = "CUTOVER"
+	controlPlane.CutoverProcessed(ev)
+	sendCutoverCompletedEventIfCompleted(cutoverType)
+}
+
+// sendCutoverCompletedEventIfCompleted is called by each of the exporter/importer after updating
+// the cutover related fields in the MSR. The one which completes the cutover sends the event.
+func sendCutoverCompletedEventIfCompleted(cutoverType string) {
+	var status string
+	switch cutoverType {
+	case "target":
+		status = getCutoverStatus()
+	case "source-replica":
+		status = getCutoverToSourceReplicaStatus()
+	case "source":
+		status = getCutoverToSourceStatus()
+	}
+	if status != COMPLETED {
+		return
+	}
+	recordPhaseCompleted(getCutoverPhase(cutoverType))
+	ev := &cp.CutoverCompletedEvent{CutoverType: cutoverType}
+	ev.EventType = "CUTOVER"
+	ev.MigrationUUID = migrationUUID
+	controlPlane.CutoverCompleted(ev)
+}
+
 func markCutoverProcessed(importerOrExporterRole string) error {
 	err := metaDB.UpdateMigrationStatusRecord(func(record *metadb.MigrationStatusRecord) {
 		switch importerOrExporterRole {
@@ -17,6 +17,7 @@ import (
 
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/callhome"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/config"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/cp"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/dbzm"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/lockfile"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
@@ -78,6 +79,7 @@ func endMigrationCommandFn(cmd *cobra.Command, args []string) {
 
 	retrieveMigrationUUID()
 	checkIfEndCommandCanBePerformed(msr)
+	recordPhaseStarted("END MIGRATION", false)
 
 	// backing up the state from the export directory
 	saveMigrationReportsFn(msr)
@@ -97,9 +99,20 @@ func endMigrationCommandFn(cmd *cobra.Command, args []string) {
 
 	cleanupExportDir()
 	utils.PrintAndLog("Migration ended successfully")
+	migrationEndedEvent := createMigrationEndedEvent()
+	controlPlane.MigrationEnded(&migrationEndedEvent)
 	packAndSendEndMigrationPayload(COMPLETE, "")
 }
 
+func createMigrationEndedEvent() cp.MigrationEndedEvent {
+	return cp.MigrationEndedEvent{
+		BaseEvent: cp.BaseEvent{
+			EventType:     "END MIGRATION",
+			MigrationUUID: migrationUUID,
+		},
+	}
+}
+
 func packAndSendEndMigrationPayload(status string, errorMsg string) {
 	if !shouldSendCallhome() {
 		return
@@ -288,6 +301,7 @@ func saveDataMigrationReport(msr *metadb.MigrationStatusRecord) {
 	liveMigrationReportCmd := exec.Command("bash", "-c", strCmd)
 	liveMigrationReportCmd.Env = append(os.Environ(), passwordsEnvVars...)
 	saveCommandOutput(liveMigrationReportCmd, "data migration report", "", dataMigrationReportPath)
+	saveJsonAndHtmlReports(strCmd, passwordsEnvVars, "data migration report", "data-migration-report")
 }
 
 func saveDataExportReport() {
@@ -300,6 +314,7 @@ func saveDataExportReport() {
 	strCmd := fmt.Sprintf("yb-voyager export data status --export-dir %s", exportDir)
 	exportDataStatusCmd := exec.Command("bash", "-c", strCmd)
 	saveCommandOutput(exportDataStatusCmd, "export data status", exportDataStatusMsg, exportDataReportFilePath)
+	saveJsonAndHtmlReports(strCmd, nil, "export data status", "export-data-status-report")
 }
 
 func saveDataImportReport(msr *metadb.MigrationStatusRecord) {
@@ -322,6 +337,29 @@ func saveDataImportReport(msr *metadb.MigrationStatusRecord) {
 	strCmd := fmt.Sprintf("yb-voyager import data status --export-dir %s", exportDir)
 	importDataStatusCmd := exec.Command("bash", "-c", strCmd)
 	saveCommandOutput(importDataStatusCmd, "import data status", importDataStatusMsg, importDataReportFilePath)
+	saveJsonAndHtmlReports(strCmd, nil, "import data status", "import-data-status-report")
+}
+
+// saveJsonAndHtmlReports runs the report/status command with json and html output formats and
+// moves the generated reports from the export-dir to the backup-dir.
+func saveJsonAndHtmlReports(strCmd string, envVars []string, cmdName string, reportFileName string) {
+	for _, format := range []string{"json", "html"} {
+		cmd := exec.Command("bash", "-c", fmt.Sprintf("%s --output-format %s", strCmd, format))
+		cmd.Env = append(os.Environ(), envVars...)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			utils.ErrExit("running %s command with %s output format: %s: %v", cmdName, format, string(output), err)
+		}
+
+		fileName := fmt.Sprintf("%s.%s", reportFileName, format)
+		oldPath := filepath.Join(exportDir, "reports", fileName)
+		newPath := filepath.Join(backupDir, "reports", fileName)
+		output, err = exec.Command("mv", oldPath, newPath).CombinedOutput()
+		if err != nil {
+			utils.ErrExit("moving %s report: %s: %v", cmdName, string(output), err)
+		}
+		log.Infof("moved %s report %q to %q", cmdName, oldPath, newPath)
+	}
 }
 
 func saveCommandOutput(cmd *exec.Cmd, cmdName string, header string, reportFilePath string) {
@@ -393,6 +431,13 @@ func askPassword(destination string, user string, envVar string) (string, error)
 	if os.Getenv(envVar) != "" {
 		return os.Getenv(envVar), nil
 	}
+	password, err := getPasswordFromSecretSource(secretNameForEnvVar(envVar))
+	if err != nil {
+		return "", err
+	}
+	if password != "" {
+		return password, nil
+	}
 
 	if user == "" {
 		fmt.Printf("Password to connect to %s (In addition, you can also set the password using the environment variable '%s'): ",
@@ -88,7 +88,9 @@ func init() {
 	exportDataFromCmd.AddCommand(exportDataFromSrcCmd)
 
 	registerCommonGlobalFlags(exportDataCmd)
+	registerMetricsFlag(exportDataCmd)
 	registerCommonGlobalFlags(exportDataFromSrcCmd)
+	registerMetricsFlag(exportDataFromSrcCmd)
 	registerCommonExportFlags(exportDataCmd)
 	registerCommonExportFlags(exportDataFromSrcCmd)
 	registerSourceDBConnFlags(exportDataCmd, true, true)
@@ -127,8 +129,10 @@ func exportDataCommandFn(cmd *cobra.Command, args []string) {
 		utils.ErrExit("failed to get migration UUID: %w", err)
 	}
 
+	recordPhaseStarted(getMigrationPhaseForRole(exporterRole), bool(startClean))
 	success := exportData()
 	if success {
+		recordPhaseCompleted(getMigrationPhaseForRole(exporterRole))
 		sendPayloadAsPerExporterRole(COMPLETE, "")
 
 		setDataIsExported()
@@ -419,6 +423,7 @@ func exportData() bool {
 			if err != nil {
 				utils.ErrExit("failed to create trigger file after data export: %v", err)
 			}
+			sendCutoverProcessedEvent(exporterRole)
 
 			updateCallhomeExportPhase()
 
@@ -930,6 +935,9 @@ func exportDataOffline(ctx context.Context, cancel context.CancelFunc, finalTabl
 	}
 	displayExportedRowCountSnapshot(false)
 
+	recordPhaseTimestamp(getMigrationPhaseForRole(exporterRole), func(ts *metadb.PhaseTimestamps, now time.Time) {
+		ts.SnapshotCompletedAt = now
+	})
 	if exporterRole == SOURCE_DB_EXPORTER_ROLE {
 		exportDataCompleteEvent := createSnapshotExportCompletedEvent()
 		controlPlane.SnapshotExportCompleted(&exportDataCompleteEvent)
@@ -34,6 +34,7 @@ import (
 
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/callhome"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/config"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/cp"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/datafile"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/dbzm"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
@@ -457,7 +458,16 @@ func calculateStreamingProgress() {
 		if err != nil {
 			utils.ErrExit("failed to get export rate from metadb: %w", err)
 		}
-		if disablePb && callhome.SendDiagnostics {
+		ev := &cp.UpdateStreamingStatsEvent{
+			Role:               exporterRole,
+			TotalEvents:        totalEventCount,
+			EventsInCurrentRun: totalEventCountRun,
+			IngestionRate:      throughputInLast3Min,
+		}
+		initBaseEventForRole(&ev.BaseEvent, exporterRole)
+		controlPlane.UpdateStreamingStats(ev)
+
+		if disablePb && callhome.SendDiagnostics && getControlPlaneType() == "" {
 			// to not do unneccessary frequent calls to metadb in case we only require this info for callhome
 			time.Sleep(12 * time.Minute)
 		} else {
@@ -480,6 +490,9 @@ func checkAndHandleSnapshotComplete(config *dbzm.Config, status *dbzm.ExportStat
 			return false, fmt.Errorf("failed to write data file descriptor: %w", err)
 		}
 		log.Infof("snapshot export is complete.")
+		recordPhaseTimestamp(getMigrationPhaseForRole(exporterRole), func(ts *metadb.PhaseTimestamps, now time.Time) {
+			ts.SnapshotCompletedAt = now
+		})
 		displayExportedRowCountSnapshot(true)
 	}
 
@@ -515,10 +528,13 @@ func checkAndHandleSnapshotComplete(config *dbzm.Config, status *dbzm.ExportStat
 				if err != nil {
 					utils.ErrExit("failed to update migration status record for export data from target start: %v", err)
 				}
+				// cutover to target with fall-forward/fall-back completes only once the export from target starts
+				sendCutoverCompletedEventIfCompleted("target")
 			}
 		}
 		color.Blue("streaming changes to a local queue file...")
-		if !disablePb || callhome.SendDiagnostics {
+		markLiveMigrationPhaseStarted(exporterRole)
+		if !disablePb || callhome.SendDiagnostics || getControlPlaneType() != "" {
 			go calculateStreamingProgress()
 		}
 		if !disablePb {
@@ -63,6 +63,7 @@ var exportDataFromTargetCmd = &cobra.Command{
 func init() {
 	exportDataFromCmd.AddCommand(exportDataFromTargetCmd)
 	registerCommonGlobalFlags(exportDataFromTargetCmd)
+	registerMetricsFlag(exportDataFromTargetCmd)
 	registerTargetDBAsSourceConnFlags(exportDataFromTargetCmd)
 	registerExportDataFlags(exportDataFromTargetCmd)
 	hideExportFlagsInFallForwardOrBackCmds(exportDataFromTargetCmd)
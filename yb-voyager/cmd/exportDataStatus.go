@@ -31,6 +31,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/vbauerster/mpb/v8"
 
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metrics"
 	pbreporter "github.com/yugabyte/yb-voyager/yb-voyager/src/reporter/pb"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/srcdb"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
@@ -231,6 +232,7 @@ func startExportPB(progressContainer *mpb.Progress, mapKey string, quitChan chan
 	go func() { //for continuously increasing PB percentage
 		for !pbr.IsComplete() {
 			pbr.SetExportedRowCount(tableMetadata.CountLiveRows)
+			metrics.ExportedRows.Set(float64(tableMetadata.CountLiveRows), tableName)
 			time.Sleep(time.Millisecond * 500)
 
 			if exporterRole == SOURCE_DB_EXPORTER_ROLE {
@@ -283,6 +285,7 @@ func startExportPB(progressContainer *mpb.Progress, mapKey string, quitChan chan
 		(Mainly for Oracle, MySQL)
 	*/
 	readLines()
+	metrics.ExportedRows.Set(float64(tableMetadata.CountLiveRows), tableName)
 
 	// PB will not change from "100%" -> "completed" until this function call is made
 	pbr.SetTotalRowCount(-1, true) // Completing remaining progress bar by setting current equal to total
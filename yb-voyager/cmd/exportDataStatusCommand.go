@@ -85,28 +85,40 @@ var exportDataStatusCmd = &cobra.Command{
 		if err != nil {
 			utils.ErrExit("error: %s\n", err)
 		}
-		if reportOrStatusCmdOutputFormat == "json" {
-			// Print the report in json format.
-			reportFilePath := filepath.Join(exportDir, "reports", "export-data-status-report.json")
-			reportFile := jsonfile.NewJsonFile[[]*exportTableMigStatusOutputRow](reportFilePath)
-			err := reportFile.Create(&rows)
-			if err != nil {
-				utils.ErrExit("creating into json file: %s: %v", reportFilePath, err)
-			}
-			fmt.Print(color.GreenString("Export data status report is written to %s\n", reportFilePath))
+		if reportOrStatusCmdOutputFormat != "table" {
+			writeExportDataStatusReport(msr, rows)
 			return
 		}
 		displayExportDataStatus(rows)
 	},
 }
 
-var migrationReportFormats = []string{"table", "json"}
+var migrationReportFormats = []string{"table", "json", "html"}
 
 func init() {
 	exportDataCmd.AddCommand(exportDataStatusCmd)
 	exportDataStatusCmd.Flags().StringVar(&reportOrStatusCmdOutputFormat, "output-format", "table",
-		"format in which report will be generated: (table, json)")
-	exportDataStatusCmd.Flags().MarkHidden("output-format") //confirm this if should be hidden or not
+		"format in which report will be generated: (table, json, html)")
+	registerVersionedJsonReportFlag(exportDataStatusCmd)
+}
+
+func writeExportDataStatusReport(msr *metadb.MigrationStatusRecord, rows []*exportTableMigStatusOutputRow) {
+	report := &exportDataStatusReport{
+		dataReportMetadata: newDataReportMetadata("export-data-status", msr),
+		Tables:             rows,
+	}
+	section := &dataReportHtmlSection{
+		Title:  "Tables",
+		Header: []string{"TABLE", "STATUS", "EXPORTED ROWS"},
+	}
+	for _, row := range rows {
+		section.Rows = append(section.Rows, &dataReportHtmlRow{Cells: []any{row.TableName, row.Status, row.ExportedCount}})
+	}
+	writeDataReport(reportOrStatusCmdOutputFormat, "export-data-status-report", "Export data status report", report, report.Tables, &dataReportHtml{
+		Title:    "Export Data Status",
+		Metadata: report.dataReportMetadata,
+		Sections: []*dataReportHtmlSection{section},
+	})
 }
 
 type exportTableMigStatusOutputRow struct {
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/fatih/color"
@@ -31,12 +32,14 @@ import (
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/callhome"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/cp"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/migassessment"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
 )
 
 var skipRecommendations utils.BoolStr
 var assessmentReportPath string
 var assessmentRecommendationsApplied bool
+var excludeRedundantIndexes utils.BoolStr
 
 var exportSchemaCmd = &cobra.Command{
 	Use: "schema",
@@ -160,6 +163,7 @@ func exportSchema() error {
 
 	exportSchemaStartEvent := createExportSchemaStartedEvent()
 	controlPlane.ExportSchemaStarted(&exportSchemaStartEvent)
+	recordPhaseStarted("EXPORT SCHEMA", bool(startClean))
 
 	source.DB().ExportSchema(exportDir, schemaDir)
 
@@ -173,6 +177,11 @@ func exportSchema() error {
 		return fmt.Errorf("failed to apply migration assessment recommendation to the schema files: %w", err)
 	}
 
+	err = excludeRedundantIndexesFromSchema()
+	if err != nil {
+		return fmt.Errorf("failed to exclude the redundant indexes from the schema files: %w", err)
+	}
+
 	utils.PrintAndLog("\nExported schema files created under directory: %s\n\n", filepath.Join(exportDir, "schema"))
 
 	packAndSendExportSchemaPayload(COMPLETE, "")
@@ -182,6 +191,7 @@ func exportSchema() error {
 
 	exportSchemaCompleteEvent := createExportSchemaCompletedEvent()
 	controlPlane.ExportSchemaCompleted(&exportSchemaCompleteEvent)
+	recordPhaseCompleted("EXPORT SCHEMA")
 	return nil
 }
 
@@ -236,6 +246,9 @@ func init() {
 
 	exportSchemaCmd.Flags().StringVar(&assessmentReportPath, "assessment-report-path", "",
 		"path to the generated assessment report file(JSON format) to be used for applying recommendation to exported schema")
+
+	BoolVar(exportSchemaCmd.Flags(), &excludeRedundantIndexes, "exclude-redundant-indexes", false,
+		"exclude the redundant indexes(duplicate, prefix of another index or unused) reported by the migration assessment from the exported schema")
 }
 
 func schemaIsExported() bool {
@@ -287,6 +300,12 @@ func updateIndexesInfoInMetaDB() error {
 	return nil
 }
 
+func getAssessmentReportPathForExportSchema() string {
+	// TODO: copy the reports to "export-dir/assessment/reports" for further usage
+	return lo.Ternary(assessmentReportPath != "", assessmentReportPath,
+		filepath.Join(exportDir, "assessment", "reports", fmt.Sprintf("%s.json", ASSESSMENT_FILE_NAME)))
+}
+
 func applyMigrationAssessmentRecommendations() error {
 	if skipRecommendations {
 		log.Infof("not apply recommendations due to flag --skip-recommendations=true")
@@ -295,9 +314,7 @@ func applyMigrationAssessmentRecommendations() error {
 		return nil
 	}
 
-	// TODO: copy the reports to "export-dir/assessment/reports" for further usage
-	assessmentReportPath := lo.Ternary(assessmentReportPath != "", assessmentReportPath,
-		filepath.Join(exportDir, "assessment", "reports", fmt.Sprintf("%s.json", ASSESSMENT_FILE_NAME)))
+	assessmentReportPath := getAssessmentReportPathForExportSchema()
 	log.Infof("using assessmentReportPath: %s", assessmentReportPath)
 	if !utils.FileOrFolderExists(assessmentReportPath) {
 		utils.PrintAndLog("migration assessment report file doesn't exists at %q, skipping apply recommendations step...", assessmentReportPath)
@@ -324,6 +341,11 @@ func applyMigrationAssessmentRecommendations() error {
 		}
 	}
 
+	err = applyShardingKeyRecommendations(report.GetRangeShardingKeyRecommendations())
+	if err != nil {
+		return fmt.Errorf("failed to apply sharding key recommendation: %w", err)
+	}
+
 	assessmentRecommendationsApplied = true
 	SetAssessmentRecommendationsApplied()
 
@@ -539,6 +561,286 @@ func applyShardingRecommendationIfMatching(sqlInfo *sqlInfo, shardedTables []str
 	return fmt.Sprintf("%s;", modifiedQuery), true, nil
 }
 
+/*
+applyShardingKeyRecommendations changes the leading column of the primary keys and indexes recommended for
+range sharding by the assessment to ASC/DESC, as these are hash sharded by default on YugabyteDB. For example:
+
+	ALTER TABLE ONLY public.events ADD CONSTRAINT events_pkey PRIMARY KEY (id); --> ... PRIMARY KEY (id ASC);
+	CREATE INDEX events_ts_idx ON public.events USING btree (ts); --> CREATE INDEX events_ts_idx ON public.events USING btree (ts DESC);
+*/
+func applyShardingKeyRecommendations(recommendations []migassessment.ShardingKeyRecommendation) error {
+	if len(recommendations) == 0 {
+		log.Infof("no primary keys/indexes are recommended for range sharding")
+		return nil
+	}
+
+	var pkRecommendations, indexRecommendations []migassessment.ShardingKeyRecommendation
+	for _, recommendation := range recommendations {
+		if recommendation.IsPrimaryKey {
+			pkRecommendations = append(pkRecommendations, recommendation)
+		} else {
+			indexRecommendations = append(indexRecommendations, recommendation)
+		}
+	}
+
+	err := applyShardingKeyRecommendationsToFile(TABLE, pkRecommendations, applyPrimaryKeyShardingIfMatching)
+	if err != nil {
+		return err
+	}
+	return applyShardingKeyRecommendationsToFile(INDEX, indexRecommendations, applyIndexShardingIfMatching)
+}
+
+func applyShardingKeyRecommendationsToFile(objType string, recommendations []migassessment.ShardingKeyRecommendation,
+	applyFn func(sqlInfo *sqlInfo, recommendations []migassessment.ShardingKeyRecommendation) (string, bool, error)) error {
+	if len(recommendations) == 0 {
+		return nil
+	}
+	filePath := utils.GetObjectFilePath(schemaDir, objType)
+	if !utils.FileOrFolderExists(filePath) {
+		log.Infof("schema file %s does not exist, skipping the sharding key recommendations for %s", filePath, objType)
+		return nil
+	}
+
+	log.Infof("applying sharding key recommendations to %s", filePath)
+	var newSQLFileContent strings.Builder
+	modified := false
+	for _, sqlInfo := range parseSqlFileForObjectType(filePath, objType) {
+		modifiedSqlStmt, match, err := applyFn(&sqlInfo, recommendations)
+		if err != nil {
+			log.Errorf("failed to apply sharding key recommendation for %q: %v", sqlInfo.objName, err)
+			if match {
+				utils.PrintAndLog("Unable to apply sharding key recommendation for %q, continuing without applying...\n", sqlInfo.objName)
+			}
+		} else if match {
+			log.Infof("original ddl - %s", sqlInfo.stmt)
+			log.Infof("modified ddl - %s", modifiedSqlStmt)
+			modified = true
+		}
+
+		_, err = newSQLFileContent.WriteString(modifiedSqlStmt + "\n\n")
+		if err != nil {
+			return fmt.Errorf("write SQL string to string builder: %w", err)
+		}
+	}
+	if !modified {
+		return nil
+	}
+
+	backupPath, err := backupAndRewriteSchemaFile(filePath, newSQLFileContent.String())
+	if err != nil {
+		return err
+	}
+
+	utils.PrintAndLog("Modified the %s DDLs in %q according to the sharding key recommendations of the assessment report.",
+		lo.Ternary(objType == TABLE, "PRIMARY KEY", "INDEX"), utils.GetRelativePathFromCwd(filePath))
+	utils.PrintAndLog("The original DDLs have been preserved in %q for reference.", utils.GetRelativePathFromCwd(backupPath))
+	return nil
+}
+
+// backupAndRewriteSchemaFile preserves the original schema file as <file>.orig(if not already done) and writes the new content
+func backupAndRewriteSchemaFile(filePath string, content string) (string, error) {
+	// the file might have been backed up already while applying the other recommendations
+	backupPath := filePath + ".orig"
+	if !utils.FileOrFolderExists(backupPath) {
+		log.Infof("renaming existing file '%s' --> '%s'", filePath, backupPath)
+		err := os.Rename(filePath, backupPath)
+		if err != nil {
+			return "", fmt.Errorf("error renaming file %s: %w", filePath, err)
+		}
+	}
+	err := os.WriteFile(filePath, []byte(content), 0644)
+	if err != nil {
+		return "", fmt.Errorf("error writing to file '%q' storing the modified recommended schema: %w", filePath, err)
+	}
+	return backupPath, nil
+}
+
+func findShardingKeyRecommendation(recommendations []migassessment.ShardingKeyRecommendation,
+	schemaName string, matchFn func(r migassessment.ShardingKeyRecommendation) bool) (migassessment.ShardingKeyRecommendation, bool) {
+	return lo.Find(recommendations, func(r migassessment.ShardingKeyRecommendation) bool {
+		// schema is not present in the DDL in case of default search_path
+		return (schemaName == "" || r.SchemaName == schemaName) && matchFn(r)
+	})
+}
+
+var rePrimaryKeyColumnList = regexp.MustCompile(`(?i)(PRIMARY\s+KEY\s*\(\s*)("[^"]+"|[^\s,)]+)(\s*[,)])`)
+
+/*
+applyPrimaryKeyShardingIfMatching adds the ordering to the first column of the PRIMARY KEY constraint in
+CREATE TABLE or ALTER TABLE ADD CONSTRAINT DDLs. pg_query can't deparse the ordering in the constraints(YugabyteDB syntax)
+hence the column list of the constraint is modified in the DDL text.
+*/
+func applyPrimaryKeyShardingIfMatching(sqlInfo *sqlInfo, recommendations []migassessment.ShardingKeyRecommendation) (string, bool, error) {
+	formattedStmt := sqlInfo.formattedStmt
+	parseTree, err := pg_query.Parse(sqlInfo.stmt)
+	if err != nil {
+		return formattedStmt, false, fmt.Errorf("error parsing the stmt-%s: %v", sqlInfo.stmt, err)
+	}
+	if len(parseTree.Stmts) == 0 {
+		return formattedStmt, false, nil
+	}
+
+	var relation *pg_query.RangeVar
+	var constraints []*pg_query.Node
+	switch node := parseTree.Stmts[0].Stmt.Node.(type) {
+	case *pg_query.Node_CreateStmt:
+		relation = node.CreateStmt.Relation
+		for _, tableElt := range node.CreateStmt.TableElts {
+			if columnDef := tableElt.GetColumnDef(); columnDef != nil {
+				constraints = append(constraints, columnDef.Constraints...)
+			} else {
+				constraints = append(constraints, tableElt)
+			}
+		}
+	case *pg_query.Node_AlterTableStmt:
+		relation = node.AlterTableStmt.Relation
+		for _, cmd := range node.AlterTableStmt.Cmds {
+			alterTableCmd := cmd.GetAlterTableCmd()
+			if alterTableCmd != nil && alterTableCmd.Subtype == pg_query.AlterTableType_AT_AddConstraint {
+				constraints = append(constraints, alterTableCmd.Def)
+			}
+		}
+	default:
+		return formattedStmt, false, nil
+	}
+
+	hasPrimaryKeyConstraint := lo.ContainsBy(constraints, func(node *pg_query.Node) bool {
+		return node.GetConstraint() != nil && node.GetConstraint().Contype == pg_query.ConstrType_CONSTR_PRIMARY
+	})
+	if relation == nil || !hasPrimaryKeyConstraint {
+		return formattedStmt, false, nil
+	}
+	recommendation, match := findShardingKeyRecommendation(recommendations, relation.Schemaname,
+		func(r migassessment.ShardingKeyRecommendation) bool { return r.TableName == relation.Relname })
+	if !match {
+		return formattedStmt, false, nil
+	}
+
+	submatches := rePrimaryKeyColumnList.FindStringSubmatchIndex(formattedStmt)
+	if submatches == nil {
+		// column constraint i.e. "id bigint PRIMARY KEY" can't specify the ordering
+		return formattedStmt, true, fmt.Errorf("PRIMARY KEY column list not found in the DDL")
+	}
+	column := formattedStmt[submatches[4]:submatches[5]]
+	if strings.Trim(column, `"`) != recommendation.ColumnName && strings.ToLower(column) != recommendation.ColumnName {
+		return formattedStmt, true, fmt.Errorf("first column %s of PRIMARY KEY doesn't match the recommended column %s",
+			column, recommendation.ColumnName)
+	}
+	modifiedStmt := formattedStmt[:submatches[5]] + " " + recommendation.Sharding + formattedStmt[submatches[5]:]
+	log.Infof("applied %s sharding to the primary key of table %s", recommendation.Sharding, sqlInfo.objName)
+	return modifiedStmt, true, nil
+}
+
+// applyIndexShardingIfMatching sets the ordering of the first key column of the recommended CREATE INDEX DDLs
+func applyIndexShardingIfMatching(sqlInfo *sqlInfo, recommendations []migassessment.ShardingKeyRecommendation) (string, bool, error) {
+	formattedStmt := sqlInfo.formattedStmt
+	parseTree, err := pg_query.Parse(sqlInfo.stmt)
+	if err != nil {
+		return formattedStmt, false, fmt.Errorf("error parsing the stmt-%s: %v", sqlInfo.stmt, err)
+	}
+	if len(parseTree.Stmts) == 0 {
+		return formattedStmt, false, nil
+	}
+
+	indexStmt := parseTree.Stmts[0].Stmt.GetIndexStmt()
+	if indexStmt == nil || indexStmt.Relation == nil || len(indexStmt.IndexParams) == 0 {
+		return formattedStmt, false, nil
+	}
+	recommendation, match := findShardingKeyRecommendation(recommendations, indexStmt.Relation.Schemaname,
+		func(r migassessment.ShardingKeyRecommendation) bool { return r.IndexName == indexStmt.Idxname })
+	if !match {
+		return formattedStmt, false, nil
+	}
+
+	indexElem := indexStmt.IndexParams[0].GetIndexElem()
+	if indexElem == nil || indexElem.Name != recommendation.ColumnName {
+		return formattedStmt, true, fmt.Errorf("first key column of the index doesn't match the recommended column %s",
+			recommendation.ColumnName)
+	}
+	indexElem.Ordering = lo.Ternary(recommendation.Sharding == migassessment.SHARDING_DESC,
+		pg_query.SortByDir_SORTBY_DESC, pg_query.SortByDir_SORTBY_ASC)
+
+	modifiedQuery, err := pg_query.Deparse(parseTree)
+	if err != nil {
+		return formattedStmt, true, fmt.Errorf("error deparsing the parseTree into the query: %w", err)
+	}
+	log.Infof("applied %s sharding to the index %s", recommendation.Sharding, indexStmt.Idxname)
+	return fmt.Sprintf("%s;", modifiedQuery), true, nil
+}
+
+/*
+excludeRedundantIndexesFromSchema removes the CREATE INDEX DDLs of the redundant indexes reported by the
+migration assessment from the exported schema. The original DDLs are preserved in the .orig file.
+*/
+func excludeRedundantIndexesFromSchema() error {
+	if !excludeRedundantIndexes || source.DBType != POSTGRESQL {
+		return nil
+	}
+
+	reportPath := getAssessmentReportPathForExportSchema()
+	if !utils.FileOrFolderExists(reportPath) {
+		utils.PrintAndLog("migration assessment report file doesn't exists at %q, skipping the exclusion of redundant indexes...", reportPath)
+		return nil
+	}
+	report, err := ParseJSONToAssessmentReport(reportPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse json report file %q: %w", reportPath, err)
+	}
+	if len(report.RedundantIndexes) == 0 {
+		utils.PrintAndLog("No redundant indexes reported in the migration assessment report.")
+		return nil
+	}
+
+	filePath := utils.GetObjectFilePath(schemaDir, INDEX)
+	if !utils.FileOrFolderExists(filePath) {
+		log.Infof("schema file %s does not exist, skipping the exclusion of redundant indexes", filePath)
+		return nil
+	}
+
+	var newSQLFileContent strings.Builder
+	var excludedIndexes []string
+	for _, sqlInfo := range parseSqlFileForObjectType(filePath, INDEX) {
+		indexName, isRedundant := isRedundantIndexDDL(&sqlInfo, report.RedundantIndexes)
+		if isRedundant {
+			log.Infof("excluding the redundant index DDL - %s", sqlInfo.stmt)
+			excludedIndexes = append(excludedIndexes, indexName)
+			continue
+		}
+		_, err = newSQLFileContent.WriteString(sqlInfo.formattedStmt + "\n\n")
+		if err != nil {
+			return fmt.Errorf("write SQL string to string builder: %w", err)
+		}
+	}
+	if len(excludedIndexes) == 0 {
+		return nil
+	}
+
+	backupPath, err := backupAndRewriteSchemaFile(filePath, newSQLFileContent.String())
+	if err != nil {
+		return err
+	}
+	utils.PrintAndLog("Excluded %d redundant indexes from %q: %s", len(excludedIndexes),
+		utils.GetRelativePathFromCwd(filePath), strings.Join(excludedIndexes, ", "))
+	utils.PrintAndLog("The original DDLs have been preserved in %q for reference.", utils.GetRelativePathFromCwd(backupPath))
+	return nil
+}
+
+func isRedundantIndexDDL(sqlInfo *sqlInfo, redundantIndexes []migassessment.RedundantIndex) (string, bool) {
+	parseTree, err := pg_query.Parse(sqlInfo.stmt)
+	if err != nil || len(parseTree.Stmts) == 0 {
+		return "", false
+	}
+	indexStmt := parseTree.Stmts[0].Stmt.GetIndexStmt()
+	if indexStmt == nil || indexStmt.Relation == nil {
+		return "", false
+	}
+	schemaName := indexStmt.Relation.Schemaname
+	isRedundant := lo.ContainsBy(redundantIndexes, func(r migassessment.RedundantIndex) bool {
+		return (schemaName == "" || r.SchemaName == schemaName) && r.IndexName == indexStmt.Idxname
+	})
+	return utils.BuildObjectName(schemaName, indexStmt.Idxname), isRedundant
+}
+
 func createExportSchemaStartedEvent() cp.ExportSchemaStartedEvent {
 	result := cp.ExportSchemaStartedEvent{}
 	initBaseSourceEvent(&result.BaseEvent, "EXPORT SCHEMA")
@@ -23,6 +23,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/migassessment"
 )
 
 func TestShardingRecommendations(t *testing.T) {
@@ -102,3 +104,66 @@ func TestShardingRecommendations(t *testing.T) {
 		strings.ToLower(sqlInfo_table3.stmt))
 	assert.Equal(t, matchTable, false)
 }
+
+func TestShardingKeyRecommendations(t *testing.T) {
+	recommendations := []migassessment.ShardingKeyRecommendation{
+		{SchemaName: "public", TableName: "events", IndexName: "events_pkey", IsPrimaryKey: true, ColumnName: "id", Sharding: migassessment.SHARDING_ASC},
+		{SchemaName: "public", TableName: "events", IndexName: "events_ts_idx", ColumnName: "ts", Sharding: migassessment.SHARDING_DESC},
+	}
+	newSqlInfo := func(stmt string) *sqlInfo {
+		return &sqlInfo{objName: "events", stmt: stmt, formattedStmt: stmt}
+	}
+
+	modifiedStmt, match, err := applyPrimaryKeyShardingIfMatching(newSqlInfo(
+		"ALTER TABLE ONLY public.events ADD CONSTRAINT events_pkey PRIMARY KEY (id, ts);"), recommendations)
+	assert.NoError(t, err)
+	assert.True(t, match)
+	assert.Equal(t, "ALTER TABLE ONLY public.events ADD CONSTRAINT events_pkey PRIMARY KEY (id ASC, ts);", modifiedStmt)
+
+	modifiedStmt, match, err = applyPrimaryKeyShardingIfMatching(newSqlInfo(
+		"CREATE TABLE public.events (id bigint NOT NULL, ts timestamp, PRIMARY KEY (id));"), recommendations)
+	assert.NoError(t, err)
+	assert.True(t, match)
+	assert.Equal(t, "CREATE TABLE public.events (id bigint NOT NULL, ts timestamp, PRIMARY KEY (id ASC));", modifiedStmt)
+
+	// column constraints can't specify the ordering
+	stmt := "CREATE TABLE public.events (id bigint PRIMARY KEY, ts timestamp);"
+	modifiedStmt, match, err = applyPrimaryKeyShardingIfMatching(newSqlInfo(stmt), recommendations)
+	assert.Error(t, err)
+	assert.True(t, match)
+	assert.Equal(t, stmt, modifiedStmt)
+
+	stmt = "ALTER TABLE ONLY public.orders ADD CONSTRAINT orders_pkey PRIMARY KEY (id);"
+	modifiedStmt, match, err = applyPrimaryKeyShardingIfMatching(newSqlInfo(stmt), recommendations)
+	assert.NoError(t, err)
+	assert.False(t, match)
+	assert.Equal(t, stmt, modifiedStmt)
+
+	modifiedStmt, match, err = applyIndexShardingIfMatching(newSqlInfo(
+		"CREATE INDEX events_ts_idx ON public.events USING btree (ts, id);"), recommendations)
+	assert.NoError(t, err)
+	assert.True(t, match)
+	assert.Equal(t, "CREATE INDEX events_ts_idx ON public.events USING btree (ts DESC, id);", modifiedStmt)
+
+	stmt = "CREATE INDEX events_id_idx ON public.events USING btree (id);"
+	modifiedStmt, match, err = applyIndexShardingIfMatching(newSqlInfo(stmt), recommendations)
+	assert.NoError(t, err)
+	assert.False(t, match)
+	assert.Equal(t, stmt, modifiedStmt)
+}
+
+func TestIsRedundantIndexDDL(t *testing.T) {
+	redundantIndexes := []migassessment.RedundantIndex{
+		{SchemaName: "public", IndexName: "orders_status_idx2", TableName: "orders", Reason: migassessment.DUPLICATE_INDEX},
+	}
+	tests := map[string]bool{
+		"CREATE INDEX orders_status_idx2 ON public.orders USING btree (status);":      true,
+		"CREATE INDEX orders_status_idx1 ON public.orders USING btree (status);":      false,
+		"CREATE INDEX orders_status_idx2 ON sales.orders USING btree (status);":       false,
+		"ALTER TABLE ONLY public.orders ADD CONSTRAINT orders_pkey PRIMARY KEY (id);": false,
+	}
+	for stmt, expected := range tests {
+		_, isRedundant := isRedundantIndexDDL(&sqlInfo{stmt: stmt, formattedStmt: stmt}, redundantIndexes)
+		assert.Equal(t, expected, isRedundant, stmt)
+	}
+}
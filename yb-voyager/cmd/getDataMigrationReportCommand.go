@@ -24,6 +24,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/google/uuid"
 	"github.com/gosuri/uitable"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/config"
@@ -300,15 +301,8 @@ func getDataMigrationReportCmdFn(msr *metadb.MigrationStatusRecord) {
 			addHeader(uitbl, secondHeader...)
 		}
 	}
-	if reportOrStatusCmdOutputFormat == "json" {
-		// Print the report in json format.
-		reportFilePath := filepath.Join(exportDir, "reports", "data-migration-report.json")
-		reportFile := jsonfile.NewJsonFile[[]*rowData](reportFilePath)
-		err := reportFile.Create(&reportData)
-		if err != nil {
-			utils.ErrExit("creating into json file: %s: %v", reportFilePath, err)
-		}
-		fmt.Print(color.GreenString("Data migration report is written to %s\n", reportFilePath))
+	if reportOrStatusCmdOutputFormat != "table" {
+		writeDataMigrationReport(msr)
 		return
 	}
 	if uitbl.Rows != nil {
@@ -320,7 +314,7 @@ func getDataMigrationReportCmdFn(msr *metadb.MigrationStatusRecord) {
 }
 
 func addRowInTheTable(uitbl *uitable.Table, row rowData, nameTup sqlname.NameTuple) {
-	if reportOrStatusCmdOutputFormat == "json" {
+	if reportOrStatusCmdOutputFormat != "table" {
 		row.TableName = nameTup.ForKey()
 		row.FinalRowCount = getFinalRowCount(row)
 		reportData = append(reportData, &row)
@@ -329,6 +323,61 @@ func addRowInTheTable(uitbl *uitable.Table, row rowData, nameTup sqlname.NameTup
 	uitbl.AddRow(row.TableName, row.DBType, row.ExportedSnapshotRows, row.ImportedSnapshotRows, row.ExportedInserts, row.ExportedUpdates, row.ExportedDeletes, row.ImportedInserts, row.ImportedUpdates, row.ImportedDeletes, getFinalRowCount(row))
 }
 
+func writeDataMigrationReport(msr *metadb.MigrationStatusRecord) {
+	report := &dataMigrationReport{
+		dataReportMetadata: newDataReportMetadata("data-migration-report", msr),
+		Tables:             reportData,
+		EventCounts:        getDataMigrationReportEventCounts(reportData),
+		Mismatches:         getDataMigrationReportMismatches(reportData, msr.TargetDBConf != nil),
+	}
+	if reportOrStatusCmdOutputFormat == "json" {
+		writeDataReport("json", "data-migration-report", "Data migration report", report, report.Tables, nil)
+		return
+	}
+
+	tablesWithMismatch := lo.SliceToMap(report.Mismatches, func(m *tableDataMismatch) (string, bool) {
+		return m.TableName, true
+	})
+	countsSection := &dataReportHtmlSection{
+		Title: "Snapshot and Change Events",
+		Note:  "Tables with mismatches in the counts are highlighted.",
+		Header: []string{"TABLE", "DB_TYPE", "EXPORTED SNAPSHOT_ROWS", "IMPORTED SNAPSHOT_ROWS", "EXPORTED INSERTS", "EXPORTED UPDATES",
+			"EXPORTED DELETES", "IMPORTED INSERTS", "IMPORTED UPDATES", "IMPORTED DELETES", "FINAL_ROW_COUNT"},
+	}
+	for _, row := range report.Tables {
+		countsSection.Rows = append(countsSection.Rows, &dataReportHtmlRow{
+			Cells: []any{row.TableName, row.DBType, row.ExportedSnapshotRows, row.ImportedSnapshotRows, row.ExportedInserts, row.ExportedUpdates,
+				row.ExportedDeletes, row.ImportedInserts, row.ImportedUpdates, row.ImportedDeletes, row.FinalRowCount},
+			Mismatch: tablesWithMismatch[row.TableName],
+		})
+	}
+	eventsSection := &dataReportHtmlSection{
+		Title:  "Change Events per Exporter/Importer",
+		Header: []string{"TABLE", "ROLE", "INSERTS", "UPDATES", "DELETES"},
+	}
+	for _, count := range report.EventCounts {
+		eventsSection.Rows = append(eventsSection.Rows, &dataReportHtmlRow{
+			Cells: []any{count.TableName, count.Role, count.Inserts, count.Updates, count.Deletes},
+		})
+	}
+	mismatchesSection := &dataReportHtmlSection{
+		Title:  "Mismatches",
+		Note:   "The counts are expected to differ while the migration is in progress and match once the cutover is complete.",
+		Header: []string{"TABLE", "DESCRIPTION"},
+	}
+	for _, mismatch := range report.Mismatches {
+		mismatchesSection.Rows = append(mismatchesSection.Rows, &dataReportHtmlRow{
+			Cells:    []any{mismatch.TableName, mismatch.Description},
+			Mismatch: true,
+		})
+	}
+	writeDataReport("html", "data-migration-report", "Data migration report", report, report.Tables, &dataReportHtml{
+		Title:    "Data Migration Report",
+		Metadata: report.dataReportMetadata,
+		Sections: []*dataReportHtmlSection{countsSection, eventsSection, mismatchesSection},
+	})
+}
+
 func updateExportedSnapshotRowsInTheRow(msr *metadb.MigrationStatusRecord, row *rowData, nameTup sqlname.NameTuple, dbzmSnapshotRowCount *utils.StructMap[sqlname.NameTuple, int64], exportedSnapshotPGRowsMap *utils.StructMap[sqlname.NameTuple, int64]) error {
 	// TODO: read only from one place(data file descriptor). Right now, data file descriptor does not store schema names.
 	if msr.IsSnapshotExportedViaDebezium() {
@@ -427,8 +476,8 @@ func init() {
 	getDataMigrationReportCmd.PersistentFlags().StringVarP(&config.LogLevel, "log-level", "l", "info",
 		"log level for yb-voyager. Accepted values: (trace, debug, info, warn, error, fatal, panic)")
 	getDataMigrationReportCmd.Flags().StringVar(&reportOrStatusCmdOutputFormat, "output-format", "table",
-		"format in which report will be generated: (table, json)")
-	getDataMigrationReportCmd.Flags().MarkHidden("output-format") //confirm this if should be hidden or not
+		"format in which report will be generated: (table, json, html)")
+	registerVersionedJsonReportFlag(getDataMigrationReportCmd)
 
 	getDataMigrationReportCmd.Flags().StringVar(&sourceReplicaDbPassword, "source-replica-db-password", "",
 		"password with which to connect to the target Source-Replica DB server. Alternatively, you can also specify the password by setting the environment variable SOURCE_REPLICA_DB_PASSWORD. If you don't provide a password via the CLI, yb-voyager will prompt you at runtime for a password. If the password contains special characters that are interpreted by the shell (for example, # and $), enclose the password in single quotes.")
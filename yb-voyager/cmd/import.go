@@ -243,7 +243,7 @@ Note that for the cases where a table doesn't have a primary key, this may lead
 
 func registerImportSchemaFlags(cmd *cobra.Command) {
 	BoolVar(cmd.Flags(), &startClean, "start-clean", false,
-		"Delete all schema objects and start a fresh import (default false)")
+		"Drop all the schema objects created by the previous runs of import schema and start a fresh import (default false)")
 	cmd.Flags().StringVar(&tconf.ImportObjects, "object-type-list", "",
 		"comma separated list of schema object types to include while importing schema")
 	cmd.Flags().StringVar(&tconf.ExcludeImportObjects, "exclude-object-type-list", "",
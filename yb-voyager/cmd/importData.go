@@ -42,6 +42,7 @@ import (
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/datastore"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/dbzm"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metrics"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/namereg"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/tgtdb"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
@@ -487,7 +488,11 @@ func importData(importFileTasks []*ImportFileTask) {
 		importDataStartEvent := createSnapshotImportStartedEvent()
 		controlPlane.SnapshotImportStarted(&importDataStartEvent)
 	}
+	if importerRole != IMPORT_FILE_ROLE {
+		recordPhaseStarted(getMigrationPhaseForRole(importerRole), bool(startClean))
+	}
 	updateTargetConfInMigrationStatus()
+	registerConnectionPoolMetrics()
 	msr, err := metaDB.GetMigrationStatusRecord()
 	if err != nil {
 		utils.ErrExit("Failed to get migration status record: %s", err)
@@ -626,6 +631,11 @@ func importData(importFileTasks []*ImportFileTask) {
 			time.Sleep(time.Second * 2)
 		}
 		utils.PrintAndLog("snapshot data import complete\n\n")
+		if importerRole != IMPORT_FILE_ROLE {
+			recordPhaseTimestamp(getMigrationPhaseForRole(importerRole), func(ts *metadb.PhaseTimestamps, now time.Time) {
+				ts.SnapshotCompletedAt = now
+			})
+		}
 	}
 
 	if changeStreamingIsEnabled(importType) {
@@ -664,6 +674,7 @@ func importData(importFileTasks []*ImportFileTask) {
 		if err != nil {
 			utils.ErrExit("failed to mark cutover as processed: %s", err)
 		}
+		sendCutoverProcessedEvent(importerRole)
 		utils.PrintAndLog("\nRun the following command to get the current report of the migration:\n" +
 			color.CyanString("yb-voyager get data-migration-report --export-dir %q", exportDir))
 	} else {
@@ -688,6 +699,9 @@ func importData(importFileTasks []*ImportFileTask) {
 	}
 
 	fmt.Printf("\nImport data complete.\n")
+	if importerRole != IMPORT_FILE_ROLE {
+		recordPhaseCompleted(getMigrationPhaseForRole(importerRole))
+	}
 
 	switch importerRole {
 	case TARGET_DB_IMPORTER_ROLE:
@@ -1160,11 +1174,14 @@ func executePostSnapshotImportSqls() error {
 }
 
 func submitBatch(batch *Batch, updateProgressFn func(int64), importBatchArgsProto *tgtdb.ImportBatchArgs) {
+	metrics.ImportBatchesInFlight.Inc()
 	batchImportPool.Go(func() {
 		// There are `poolSize` number of competing go-routines trying to invoke COPY.
 		// But the `connPool` will allow only `parallelism` number of connections to be
 		// used at a time. Thus limiting the number of concurrent COPYs to `parallelism`.
 		importBatch(batch, importBatchArgsProto)
+		metrics.ImportBatchesInFlight.Dec()
+		metrics.ImportedRows.Add(float64(batch.RecordCount), batch.TableNameTup.ForOutput())
 		if reportProgressInBytes {
 			updateProgressFn(batch.ByteCount)
 		} else {
@@ -1189,7 +1206,9 @@ func importBatch(batch *Batch, importBatchArgsProto *tgtdb.ImportBatchArgs) {
 	sleepIntervalSec := 0
 	for attempt := 0; attempt < COPY_MAX_RETRY_COUNT; attempt++ {
 		tableSchema, _ := TableNameToSchema.Get(batch.TableNameTup)
+		copyStartTime := time.Now()
 		rowsAffected, err = tdb.ImportBatch(batch, &importBatchArgs, exportDir, tableSchema)
+		metrics.ImportCopyDuration.Observe(time.Since(copyStartTime).Seconds())
 		if err == nil || tdb.IsNonRetryableCopyError(err) {
 			break
 		}
@@ -1312,7 +1331,9 @@ func init() {
 	registerFlagsForTarget(importDataCmd)
 	registerFlagsForTarget(importDataToTargetCmd)
 	registerCommonGlobalFlags(importDataCmd)
+	registerMetricsFlag(importDataCmd)
 	registerCommonGlobalFlags(importDataToTargetCmd)
+	registerMetricsFlag(importDataToTargetCmd)
 	registerCommonImportFlags(importDataCmd)
 	registerCommonImportFlags(importDataToTargetCmd)
 	importDataCmd.Flags().MarkHidden("continue-on-error")
@@ -108,6 +108,8 @@ func prepareForImportDataCmd(importFileTasks []*ImportFileTask) {
 	err := metaDB.UpdateMigrationStatusRecord(func(record *metadb.MigrationStatusRecord) {
 		source.DBType = POSTGRESQL
 		record.SourceDBConf = source.Clone()
+		record.SourceDBConf.Password = ""
+		record.SourceDBConf.Uri = ""
 	})
 	if err != nil {
 		utils.ErrExit("failed to update migration status record: %v", err)
@@ -438,6 +440,7 @@ func escapeFileOptsCharsIfRequired() {
 func init() {
 	importDataCmd.AddCommand(importDataFileCmd)
 	registerCommonGlobalFlags(importDataFileCmd)
+	registerMetricsFlag(importDataFileCmd)
 	registerTargetDBConnFlags(importDataFileCmd)
 	registerImportDataCommonFlags(importDataFileCmd)
 	registerFlagsForTarget(importDataFileCmd)
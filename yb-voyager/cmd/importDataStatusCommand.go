@@ -28,9 +28,9 @@ import (
 
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/datafile"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/datastore"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/namereg"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
-	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils/jsonfile"
 )
 
 const importDataStatusMsg = "Import Data Status for TargetDB\n"
@@ -72,8 +72,8 @@ var reportOrStatusCmdOutputFormat string
 func init() {
 	importDataCmd.AddCommand(importDataStatusCmd)
 	importDataStatusCmd.Flags().StringVar(&reportOrStatusCmdOutputFormat, "output-format", "table",
-		"format in which report will be generated: (table, json)")
-	importDataStatusCmd.Flags().MarkHidden("output-format") //confirm this if should be hidden or not
+		"format in which report will be generated: (table, json, html)")
+	registerVersionedJsonReportFlag(importDataStatusCmd)
 }
 
 // totalCount and importedCount store row-count for import data command and byte-count for import data file command.
@@ -96,15 +96,12 @@ func runImportDataStatusCmd() error {
 	if err != nil {
 		return fmt.Errorf("prepare import data status table: %w", err)
 	}
-	if reportOrStatusCmdOutputFormat == "json" {
-		// Print the report in json format.
-		reportFilePath := filepath.Join(exportDir, "reports", "import-data-status-report.json")
-		reportFile := jsonfile.NewJsonFile[[]*tableMigStatusOutputRow](reportFilePath)
-		err := reportFile.Create(&rows)
+	if reportOrStatusCmdOutputFormat != "table" {
+		msr, err := metaDB.GetMigrationStatusRecord()
 		if err != nil {
-			utils.ErrExit("creating into json file: %s: %v", reportFilePath, err)
+			return fmt.Errorf("get migration status record: %w", err)
 		}
-		fmt.Print(color.GreenString("Import data status report is written to %s\n", reportFilePath))
+		writeImportDataStatusReport(msr, rows)
 		return nil
 	}
 	color.Cyan(importDataStatusMsg)
@@ -137,6 +134,39 @@ func runImportDataStatusCmd() error {
 	return nil
 }
 
+func writeImportDataStatusReport(msr *metadb.MigrationStatusRecord, rows []*tableMigStatusOutputRow) {
+	report := &importDataStatusReport{
+		dataReportMetadata: newDataReportMetadata("import-data-status", msr),
+		Tables:             rows,
+	}
+	section := &dataReportHtmlSection{
+		Title:  "Tables",
+		Header: []string{"TABLE", "STATUS", "TOTAL ROWS", "IMPORTED ROWS", "PERCENTAGE"},
+	}
+	if reportProgressInBytes {
+		section.Header = []string{"TABLE", "FILE", "STATUS", "TOTAL SIZE", "IMPORTED SIZE", "PERCENTAGE"}
+	} else {
+		section.Note = "Tables whose import is done but the imported rows don't match the total rows are highlighted."
+	}
+	for _, row := range rows {
+		perc := fmt.Sprintf("%.2f", row.PercentageComplete)
+		if reportProgressInBytes {
+			section.Rows = append(section.Rows, &dataReportHtmlRow{Cells: []any{row.TableName, row.FileName, row.Status,
+				utils.HumanReadableByteCount(row.TotalCount), utils.HumanReadableByteCount(row.ImportedCount), perc}})
+		} else {
+			section.Rows = append(section.Rows, &dataReportHtmlRow{
+				Cells:    []any{row.TableName, row.Status, row.TotalCount, row.ImportedCount, perc},
+				Mismatch: row.Status == "DONE" && row.ImportedCount != row.TotalCount,
+			})
+		}
+	}
+	writeDataReport(reportOrStatusCmdOutputFormat, "import-data-status-report", "Import data status report", report, report.Tables, &dataReportHtml{
+		Title:    "Import Data Status",
+		Metadata: report.dataReportMetadata,
+		Sections: []*dataReportHtmlSection{section},
+	})
+}
+
 func prepareDummyDescriptor(state *ImportDataState) (*datafile.Descriptor, error) {
 	var dataFileDescriptor datafile.Descriptor
 
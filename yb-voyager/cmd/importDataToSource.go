@@ -49,6 +49,7 @@ var importDataToSourceCmd = &cobra.Command{
 func init() {
 	importDataToCmd.AddCommand(importDataToSourceCmd)
 	registerCommonGlobalFlags(importDataToSourceCmd)
+	registerMetricsFlag(importDataToSourceCmd)
 	registerCommonImportFlags(importDataToSourceCmd)
 	registerSourceDBAsTargetConnFlags(importDataToSourceCmd)
 	registerFlagsForSourceReplica(importDataToSourceCmd)
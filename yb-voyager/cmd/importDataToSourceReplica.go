@@ -64,6 +64,7 @@ func setTargetConfSpecifics(cmd *cobra.Command) {
 func init() {
 	importDataToCmd.AddCommand(importDataToSourceReplicaCmd)
 	registerCommonGlobalFlags(importDataToSourceReplicaCmd)
+	registerMetricsFlag(importDataToSourceReplicaCmd)
 	registerCommonImportFlags(importDataToSourceReplicaCmd)
 	registerSourceReplicaDBAsTargetConnFlags(importDataToSourceReplicaCmd)
 	registerFlagsForSourceReplica(importDataToSourceReplicaCmd)
@@ -17,12 +17,14 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -30,6 +32,7 @@ import (
 
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/callhome"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/cp"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/srcdb"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/tgtdb"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
@@ -129,6 +132,7 @@ func importSchema() error {
 
 	importSchemaStartEvent := createImportSchemaStartedEvent()
 	controlPlane.ImportSchemaStarted(&importSchemaStartEvent)
+	recordPhaseStarted("IMPORT SCHEMA", bool(startClean))
 
 	conn, err := pgx.Connect(context.Background(), tconf.GetConnectionUri())
 	if err != nil {
@@ -239,6 +243,7 @@ func importSchema() error {
 
 	importSchemaCompleteEvent := createImportSchemaCompletedEvent()
 	controlPlane.ImportSchemaCompleted(&importSchemaCompleteEvent)
+	recordPhaseCompleted("IMPORT SCHEMA")
 
 	return nil
 }
@@ -432,27 +437,17 @@ func createTargetSchemas(conn *pgx.Conn) {
 	}
 	targetSchemas = utils.ToCaseInsensitiveNames(targetSchemas)
 
+	if startClean {
+		// drop only the objects created by voyager, anything else present in the target schemas is left as it is
+		dropObjectsCreatedByImportSchema(conn)
+	}
+
 	utils.PrintAndLog("schemas to be present in target database %q: %v\n", tconf.DBName, targetSchemas)
 	for _, targetSchema := range targetSchemas {
 		//check if target schema exists or not
 		schemaExists := checkIfTargetSchemaExists(conn, targetSchema)
-		dropSchemaQuery := fmt.Sprintf("DROP SCHEMA %s CASCADE", targetSchema)
-
 		if schemaExists {
-			if startClean {
-				promptMsg := fmt.Sprintf("do you really want to drop the '%s' schema", targetSchema)
-				if !utils.AskPrompt(promptMsg) {
-					continue
-				}
-
-				utils.PrintAndLog("dropping schema '%s' in target database", targetSchema)
-				_, err := conn.Exec(context.Background(), dropSchemaQuery)
-				if err != nil {
-					utils.ErrExit("Failed to drop schema: %q: %s", targetSchema, err)
-				}
-			} else {
-				utils.PrintAndLog("schema '%s' already present in target database, continuing with it..\n", targetSchema)
-			}
+			utils.PrintAndLog("schema '%s' already present in target database, continuing with it..\n", targetSchema)
 		}
 	}
 
@@ -476,6 +471,60 @@ func createTargetSchemas(conn *pgx.Conn) {
 	}
 }
 
+/*
+dropObjectsCreatedByImportSchema drops the objects created by the previous runs of import schema, in the
+reverse order of their creation, and resets the import state of all the DDLs.
+Objects which were already present on the target before import schema are not touched. The objects are not
+dropped with CASCADE, so it fails if any other object on the target depends on an object created by import schema.
+*/
+func dropObjectsCreatedByImportSchema(conn *pgx.Conn) {
+	stmts, err := metaDB.GetImportSchemaStmtsCreatingObjects()
+	if err != nil {
+		utils.ErrExit("get objects created by import schema: %s", err)
+	}
+	if len(stmts) > 0 {
+		promptMsg := fmt.Sprintf("do you really want to drop the %d objects created by the previous runs of import schema", len(stmts))
+		if !utils.AskPrompt(promptMsg) {
+			utils.ErrExit("User selected not to drop the objects. Exiting.")
+		}
+	}
+	err = dropImportSchemaObjects(stmts, func(dropStmt string) error {
+		_, err := conn.Exec(context.Background(), dropStmt)
+		return err
+	})
+	if err != nil {
+		utils.ErrExit("%s", err)
+	}
+}
+
+// SQLSTATE of the error on dropping an object which other objects depend on, without CASCADE
+const DEPENDENT_OBJECTS_STILL_EXIST_ERR_CODE = "2BP01"
+
+func dropImportSchemaObjects(stmts []*metadb.ImportSchemaStmt, execDropStmt func(string) error) error {
+	for _, stmt := range stmts {
+		utils.PrintAndLog("dropping %s %s in target database", strings.ToLower(stmt.ObjectType), stmt.ObjectName)
+		err := execDropStmt(stmt.DropStmt)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == DEPENDENT_OBJECTS_STILL_EXIST_ERR_CODE {
+			return fmt.Errorf("failed to drop %s %q as other objects in the target database depend on it: %s\n%s\n"+
+				"Drop the dependent objects or remove the dependency on the target database and rerun import schema with --start-clean",
+				stmt.ObjectType, stmt.ObjectName, pgErr.Message, pgErr.Detail)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to drop %s %q: %s: %w", stmt.ObjectType, stmt.ObjectName, stmt.DropStmt, err)
+		}
+		err = metaDB.DeleteImportSchemaStmt(stmt.FileName, stmt.StmtHash)
+		if err != nil {
+			return fmt.Errorf("delete import state of %s %q: %w", stmt.ObjectType, stmt.ObjectName, err)
+		}
+	}
+	err := metaDB.ResetImportSchemaStmts()
+	if err != nil {
+		return fmt.Errorf("reset import schema state: %w", err)
+	}
+	return nil
+}
+
 func checkIfTargetSchemaExists(conn *pgx.Conn, targetSchema string) bool {
 	checkSchemaExistQuery := fmt.Sprintf("select nspname from pg_namespace n where n.nspname = '%s'", targetSchema)
 
@@ -29,6 +29,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/exp/slices"
 
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/query/queryparser"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
 )
@@ -105,6 +106,16 @@ func executeSqlFile(file string, objType string, skipFn func(string, string) boo
 			log.Infof("Skipping DDL: %s", sqlInfo.stmt)
 			continue
 		}
+		if !setOrSelectStmt {
+			imported, err := isSchemaStmtAlreadyImported(sqlInfo)
+			if err != nil {
+				return err
+			}
+			if imported {
+				log.Infof("Skipping DDL as it is already imported in a previous run: %s", sqlInfo.stmt)
+				continue
+			}
+		}
 
 		err = executeSqlStmtWithRetries(&conn, sqlInfo, objType)
 		if err != nil {
@@ -167,7 +178,7 @@ func executeSqlStmtWithRetries(conn **pgx.Conn, sqlInfo sqlInfo, objType string)
 		if err == nil {
 			utils.PrintSqlStmtIfDDL(sqlInfo.stmt, utils.GetObjectFileName(filepath.Join(exportDir, "schema"), objType),
 				getNoticeMessage(stmtNotice))
-			return nil
+			return updateImportSchemaStmtState(sqlInfo, objType, metadb.SCHEMA_STMT_DONE, "", true)
 		}
 
 		log.Errorf("DDL Execution Failed for %q: %s", sqlInfo.formattedStmt, err)
@@ -208,6 +219,8 @@ func executeSqlStmtWithRetries(conn **pgx.Conn, sqlInfo sqlInfo, objType string)
 			// "already exists" error. Ignore the error.
 			if bool(tconf.IgnoreIfExists) || strings.EqualFold(strings.Trim(sqlInfo.stmt, " \n"), "CREATE SCHEMA public;") {
 				err = nil
+				// the object was not created by this statement, so it must not be dropped on --start-clean
+				return updateImportSchemaStmtState(sqlInfo, objType, metadb.SCHEMA_STMT_DONE, "", false)
 			}
 		}
 		break // no more iteration in case of non retriable error
@@ -216,8 +229,15 @@ func executeSqlStmtWithRetries(conn **pgx.Conn, sqlInfo sqlInfo, objType string)
 		(*conn).Close(context.Background())
 		*conn = nil
 		if missingRequiredSchemaObject(err) {
-			// Do nothing for deferred case
+			err2 := updateImportSchemaStmtState(sqlInfo, objType, metadb.SCHEMA_STMT_PENDING, err.Error(), false)
+			if err2 != nil {
+				return err2
+			}
 		} else {
+			err2 := updateImportSchemaStmtState(sqlInfo, objType, metadb.SCHEMA_STMT_FAILED, err.Error(), false)
+			if err2 != nil {
+				return err2
+			}
 			utils.PrintSqlStmtIfDDL(sqlInfo.stmt, utils.GetObjectFileName(filepath.Join(exportDir, "schema"), objType),
 				getNoticeMessage(stmtNotice))
 			color.Red(fmt.Sprintf("%s\n", err.Error()))
@@ -266,6 +286,10 @@ func importDeferredStatements() {
 				if noticeMsg != "" {
 					utils.PrintAndLog(color.YellowString("%s\n", noticeMsg))
 				}
+				err = updateImportSchemaStmtState(deferredSqlStmts[j], "", metadb.SCHEMA_STMT_DONE, "", true)
+				if err != nil {
+					utils.ErrExit("%s", err)
+				}
 				// removing successfully executed SQL
 				deferredSqlStmts = append(deferredSqlStmts[:j], deferredSqlStmts[j+1:]...)
 				break
@@ -288,6 +312,12 @@ func importDeferredStatements() {
 			// no need for further iterations since the deferred list will remain same
 			log.Infof("none of the deferred statements executed successfully in the %d iteration", i)
 			finalFailedDeferredStmts = failedSqlStmtInIthIteration
+			for _, deferredSqlStmt := range deferredSqlStmts {
+				err = updateImportSchemaStmtState(deferredSqlStmt, "", metadb.SCHEMA_STMT_FAILED, "failed to execute deferred statement", false)
+				if err != nil {
+					utils.ErrExit("%s", err)
+				}
+			}
 			break
 		}
 	}
@@ -494,3 +524,52 @@ func execStmtAndGetNotice(conn *pgx.Conn, stmt string) (*pgconn.Notice, error) {
 	_, err := conn.Exec(context.Background(), stmt)
 	return notice, err
 }
+
+func isSchemaStmtAlreadyImported(sqlInfo sqlInfo) (bool, error) {
+	status, err := metaDB.GetImportSchemaStmtStatus(filepath.Base(sqlInfo.fileName), metadb.GetSchemaStmtHash(sqlInfo.stmt))
+	if err != nil {
+		return false, fmt.Errorf("get import state of DDL [%s]: %w", sqlInfo.stmt, err)
+	}
+	return status == metadb.SCHEMA_STMT_DONE, nil
+}
+
+// updateImportSchemaStmtState records the outcome of a DDL in the metaDB so that a re-run of import schema
+// only executes the statements which are not yet imported. If objectCreated is true, the DROP statement of the
+// created object is also stored, which is used to clean up the object on --start-clean.
+func updateImportSchemaStmtState(sqlInfo sqlInfo, objType string, status string, errMsg string, objectCreated bool) error {
+	upperStmt := strings.ToUpper(sqlInfo.stmt)
+	if strings.HasPrefix(upperStmt, "SET ") || strings.HasPrefix(upperStmt, "SELECT ") {
+		// session level statements are executed in every run
+		return nil
+	}
+	stmt := &metadb.ImportSchemaStmt{
+		FileName:   filepath.Base(sqlInfo.fileName),
+		StmtHash:   metadb.GetSchemaStmtHash(sqlInfo.stmt),
+		ObjectType: objType,
+		ObjectName: sqlInfo.objName,
+		Status:     status,
+		ErrorMsg:   errMsg,
+	}
+	if objectCreated {
+		stmt.DropStmt = getDropStmtForDDL(sqlInfo.stmt)
+	}
+	err := metaDB.UpsertImportSchemaStmt(stmt)
+	if err != nil {
+		return fmt.Errorf("update import state of DDL [%s]: %w", sqlInfo.stmt, err)
+	}
+	return nil
+}
+
+func getDropStmtForDDL(stmt string) string {
+	parseTree, err := queryparser.Parse(stmt)
+	if err != nil {
+		log.Warnf("failed to parse the DDL[%s] for building its DROP statement: %v", stmt, err)
+		return ""
+	}
+	dropStmt, err := queryparser.GetDropStmtForCreateDDL(parseTree)
+	if err != nil {
+		log.Warnf("failed to build DROP statement for DDL[%s]: %v", stmt, err)
+		return ""
+	}
+	return dropStmt
+}
@@ -0,0 +1,130 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
+)
+
+func setupImportSchemaTestMetaDB(t *testing.T) {
+	testExportDir := t.TempDir()
+	require.NoError(t, metadb.CreateAndInitMetaDBIfRequired(testExportDir))
+	m, err := metadb.NewMetaDB(testExportDir)
+	require.NoError(t, err)
+	prevMetaDB := metaDB
+	metaDB = m
+	t.Cleanup(func() { metaDB = prevMetaDB })
+}
+
+var importSchemaTestStmts = []sqlInfo{
+	{objName: "public.customers", stmt: "CREATE TABLE public.customers (id int PRIMARY KEY);", fileName: "/e/schema/tables/table.sql"},
+	{objName: "public.orders", stmt: "CREATE TABLE public.orders (id int, cust_id int);", fileName: "/e/schema/tables/table.sql"},
+	{objName: "public.orders", stmt: "ALTER TABLE ONLY public.orders ADD CONSTRAINT orders_fk FOREIGN KEY (cust_id) REFERENCES public.customers(id);", fileName: "/e/schema/tables/FOREIGN_KEYS.sql"},
+	{objName: "public.orders_v", stmt: "CREATE VIEW public.orders_v AS SELECT * FROM public.orders;", fileName: "/e/schema/views/view.sql"},
+}
+
+func TestImportSchemaResume(t *testing.T) {
+	setupImportSchemaTestMetaDB(t)
+
+	// first run: the tables and the foreign key are created, the view fails
+	for _, stmt := range importSchemaTestStmts[:3] {
+		require.NoError(t, updateImportSchemaStmtState(stmt, "TABLE", metadb.SCHEMA_STMT_DONE, "", true))
+	}
+	require.NoError(t, updateImportSchemaStmtState(importSchemaTestStmts[3], "VIEW", metadb.SCHEMA_STMT_FAILED, "syntax error", false))
+	// session level statements are not tracked
+	require.NoError(t, updateImportSchemaStmtState(sqlInfo{stmt: "SET search_path = public;", fileName: "table.sql"}, "TABLE", metadb.SCHEMA_STMT_DONE, "", false))
+
+	// rerun: only the failed statement is executed again, even if the schema files are formatted differently
+	for i, stmt := range importSchemaTestStmts {
+		stmt.fileName = "/other/export-dir/" + stmt.fileName
+		stmt.stmt = "  " + stmt.stmt + "\n"
+		imported, err := isSchemaStmtAlreadyImported(stmt)
+		require.NoError(t, err)
+		assert.Equal(t, i < 3, imported, stmt.stmt)
+	}
+	imported, err := isSchemaStmtAlreadyImported(sqlInfo{stmt: "SET search_path = public;", fileName: "table.sql"})
+	require.NoError(t, err)
+	assert.False(t, imported)
+
+	require.NoError(t, updateImportSchemaStmtState(importSchemaTestStmts[3], "VIEW", metadb.SCHEMA_STMT_DONE, "", true))
+	imported, err = isSchemaStmtAlreadyImported(importSchemaTestStmts[3])
+	require.NoError(t, err)
+	assert.True(t, imported)
+}
+
+func TestImportSchemaStartClean(t *testing.T) {
+	setupImportSchemaTestMetaDB(t)
+	for _, stmt := range importSchemaTestStmts {
+		require.NoError(t, updateImportSchemaStmtState(stmt, "TABLE", metadb.SCHEMA_STMT_DONE, "", true))
+	}
+	// already present on the target, so not dropped
+	existing := sqlInfo{objName: "public.audit", stmt: "CREATE TABLE public.audit (id int);", fileName: "table.sql"}
+	require.NoError(t, updateImportSchemaStmtState(existing, "TABLE", metadb.SCHEMA_STMT_DONE, "", false))
+
+	stmts, err := metaDB.GetImportSchemaStmtsCreatingObjects()
+	require.NoError(t, err)
+
+	// a view of the user on the target depends on public.orders
+	var executed []string
+	err = dropImportSchemaObjects(stmts, func(dropStmt string) error {
+		executed = append(executed, dropStmt)
+		if dropStmt == "DROP TABLE IF EXISTS public.orders" {
+			return &pgconn.PgError{Code: DEPENDENT_OBJECTS_STILL_EXIST_ERR_CODE,
+				Message: "cannot drop table orders because other objects depend on it",
+				Detail:  "view user_orders_report depends on table orders"}
+		}
+		return nil
+	})
+	assert.ErrorContains(t, err, "other objects in the target database depend on it")
+	assert.ErrorContains(t, err, "view user_orders_report depends on table orders")
+	// reverse order of creation, without CASCADE
+	assert.Equal(t, []string{
+		"DROP VIEW IF EXISTS public.orders_v",
+		"ALTER TABLE IF EXISTS public.orders DROP CONSTRAINT IF EXISTS orders_fk",
+		"DROP TABLE IF EXISTS public.orders",
+	}, executed)
+
+	// the objects already dropped are not dropped again on the rerun
+	for _, stmt := range importSchemaTestStmts[2:] {
+		imported, err := isSchemaStmtAlreadyImported(stmt)
+		require.NoError(t, err)
+		assert.False(t, imported, stmt.stmt)
+	}
+	stmts, err = metaDB.GetImportSchemaStmtsCreatingObjects()
+	require.NoError(t, err)
+	executed = nil
+	err = dropImportSchemaObjects(stmts, func(dropStmt string) error {
+		executed = append(executed, dropStmt)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"DROP TABLE IF EXISTS public.orders", "DROP TABLE IF EXISTS public.customers"}, executed)
+
+	// import state of all the statements is reset
+	for _, stmt := range append(importSchemaTestStmts, existing) {
+		imported, err := isSchemaStmtAlreadyImported(stmt)
+		require.NoError(t, err)
+		assert.False(t, imported, stmt.stmt)
+	}
+}
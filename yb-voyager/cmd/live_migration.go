@@ -31,7 +31,9 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/callhome"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/cp"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metrics"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/namereg"
 	reporter "github.com/yugabyte/yb-voyager/yb-voyager/src/reporter/stats"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/tgtdb"
@@ -43,6 +45,9 @@ var NUM_EVENT_CHANNELS int
 var EVENT_CHANNEL_SIZE int // has to be > MAX_EVENTS_PER_BATCH
 var MAX_EVENTS_PER_BATCH int
 var MAX_INTERVAL_BETWEEN_BATCHES int //ms
+// interval at which the streaming stats of the importer are sent to the control plane
+const STREAMING_STATS_UPDATE_INTERVAL = 30 * time.Second
+
 var END_OF_QUEUE_SEGMENT_EVENT = &tgtdb.Event{Op: "end_of_source_queue_segment"}
 var FLUSH_BATCH_EVENT = &tgtdb.Event{Op: "flush_batch"}
 var eventQueue *EventQueue
@@ -88,6 +93,20 @@ func streamChanges(state *ImportDataState, tableNames []sqlname.NameTuple) error
 		go statsReporter.ReportStats(ctx)
 		defer statsReporter.Finalize()
 	}
+	if metricsEnabled() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go updateStreamingImportMetrics(ctx)
+	}
+	if getControlPlaneType() != "" {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		if disablePb {
+			go statsReporter.TrackStats(ctx)
+		}
+		go sendStreamingImportStats(ctx)
+	}
+	markLiveMigrationPhaseStarted(importerRole)
 
 	eventQueue = NewEventQueue(exportDir)
 	// setup target event channels
@@ -121,6 +140,29 @@ func streamChanges(state *ImportDataState, tableNames []sqlname.NameTuple) error
 // used to determine if cache reinitialization is needed
 var prevExporterRole = ""
 
+func sendStreamingImportStats(ctx context.Context) {
+	ticker := time.NewTicker(STREAMING_STATS_UPDATE_INTERVAL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		stats := statsReporter.GetStreamingStats()
+		ev := &cp.UpdateStreamingStatsEvent{
+			Role:               importerRole,
+			TotalEvents:        stats.TotalEventsImported,
+			EventsInCurrentRun: stats.CurrImportedEvents,
+			IngestionRate:      stats.IngestionRate,
+			RemainingEvents:    stats.RemainingEvents,
+			LagSeconds:         int64(stats.EstimatedTimeToCatchUp.Seconds()),
+		}
+		initBaseEventForRole(&ev.BaseEvent, importerRole)
+		controlPlane.UpdateStreamingStats(ev)
+	}
+}
+
 func streamChangesFromSegment(
 	segment *EventQueueSegment,
 	evChans []chan *tgtdb.Event,
@@ -372,6 +414,9 @@ func processEvents(chanNo int, evChan chan *tgtdb.Event, lastAppliedVsn int64, d
 		}
 		conflictDetectionCache.RemoveEvents(eventBatch.Events...)
 		statsReporter.BatchImported(eventBatch.EventCounts.NumInserts, eventBatch.EventCounts.NumUpdates, eventBatch.EventCounts.NumDeletes)
+		metrics.ImportedEvents.Add(float64(eventBatch.EventCounts.NumInserts), "insert")
+		metrics.ImportedEvents.Add(float64(eventBatch.EventCounts.NumUpdates), "update")
+		metrics.ImportedEvents.Add(float64(eventBatch.EventCounts.NumDeletes), "delete")
 		log.Debugf("processEvents from channel %v: Executed Batch of size - %d successfully in time %s",
 			chanNo, len(batch), time.Since(start).String())
 	}
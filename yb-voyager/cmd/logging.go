@@ -20,6 +20,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -78,11 +79,18 @@ func InitLogging(logDir string, logLevel string, disableLogging bool, cmdName st
 	return nil
 }
 
+var passwordFlags = []string{"--source-db-password", "--target-db-password", "--source-replica-db-password"}
+
+// redactPasswordFromArgs redacts the passwords passed as `--<flag> <password>` or `--<flag>=<password>`.
 func redactPasswordFromArgs() {
 	for i := 0; i < len(os.Args); i++ {
 		opt := os.Args[i]
-		if opt == "--source-db-password" || opt == "--target-db-password" || opt == "--source-replica-db-password" {
-			os.Args[i+1] = "XXX"
+		for _, flag := range passwordFlags {
+			if opt == flag && i+1 < len(os.Args) {
+				os.Args[i+1] = "XXX"
+			} else if strings.HasPrefix(opt, flag+"=") {
+				os.Args[i] = flag + "=XXX"
+			}
 		}
 	}
 }
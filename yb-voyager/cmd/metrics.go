@@ -0,0 +1,94 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/adaptiveparallelism"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metrics"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
+)
+
+var metricsListenAddress string
+
+const STREAMING_METRICS_UPDATE_INTERVAL = 10 * time.Second
+
+// registered only on the long running commands i.e. export/import data and their variants
+func registerMetricsFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&metricsListenAddress, "metrics-listen-address", "",
+		"address (host:port) on which to expose the migration progress metrics in the prometheus format at /metrics. "+
+			"Metrics are not exposed if this flag is not set. Example: localhost:9464")
+}
+
+func startMetricsServerIfRequired() {
+	if metricsListenAddress == "" {
+		return
+	}
+	err := metrics.StartServer(metricsListenAddress)
+	if err != nil {
+		utils.ErrExit("failed to start the metrics server: %v", err)
+	}
+	utils.PrintAndLog("Serving metrics on http://%s/metrics", metricsListenAddress)
+}
+
+func metricsEnabled() bool {
+	return metricsListenAddress != ""
+}
+
+func registerConnectionPoolMetrics() {
+	yb, ok := tdb.(adaptiveparallelism.TargetYugabyteDBWithConnectionPool)
+	if !ok {
+		return
+	}
+	metrics.ImportConnectionPoolSize.SetFunc(func() float64 {
+		return float64(yb.GetNumConnectionsInPool())
+	})
+	metrics.ImportConnectionPoolMaxSize.SetFunc(func() float64 {
+		return float64(yb.GetNumMaxConnectionsInPool())
+	})
+}
+
+// updateStreamingImportMetrics periodically refreshes the metrics which need a lookup in the metaDB.
+func updateStreamingImportMetrics(ctx context.Context) {
+	segmentsExporterRole := ""
+	if importerRole == SOURCE_DB_IMPORTER_ROLE {
+		segmentsExporterRole = TARGET_DB_EXPORTER_FB_ROLE
+	}
+	ticker := time.NewTicker(STREAMING_METRICS_UPDATE_INTERVAL)
+	defer ticker.Stop()
+	for {
+		statsReporter.UpdateRemainingEvents()
+		metrics.ImportRemainingEvents.Set(float64(statsReporter.GetRemainingEvents()))
+
+		numSegments, err := metaDB.GetNumSegmentsExportedByAndNotImportedBy(importerRole, segmentsExporterRole)
+		if err != nil {
+			log.Warnf("failed to get the number of segments to be imported for metrics: %v", err)
+		} else {
+			metrics.ImportSegmentBacklog.Set(float64(numSegments))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
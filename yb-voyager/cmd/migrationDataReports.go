@@ -0,0 +1,308 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"bytes"
+	"cmp"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils/jsonfile"
+)
+
+// Reports generated by `export data status`, `import data status` and `get data-migration-report` commands.
+// By default the json reports are just the array of tables. With --versioned-json they are an object carrying the
+// schema version and migration metadata along with the tables. The schema version is bumped on any backward
+// incompatible change in the structure of that object.
+const DATA_REPORTS_SCHEMA_VERSION = 1
+
+var versionedJsonReport utils.BoolStr
+
+// order in which the phases are listed in the reports
+var migrationPhasesOrder = []string{
+	"ASSESS MIGRATION",
+	"EXPORT SCHEMA",
+	"ANALYZE SCHEMA",
+	"IMPORT SCHEMA",
+	"EXPORT DATA",
+	"IMPORT DATA",
+	"CUTOVER TO TARGET",
+	"EXPORT DATA FROM TARGET",
+	"IMPORT DATA TO SOURCE REPLICA",
+	"CUTOVER TO SOURCE-REPLICA",
+	"IMPORT DATA TO SOURCE",
+	"CUTOVER TO SOURCE",
+	"END MIGRATION",
+}
+
+type dataReportMetadata struct {
+	SchemaVersion  int                      `json:"schema_version"`
+	ReportType     string                   `json:"report_type"`
+	MigrationUUID  string                   `json:"migration_uuid"`
+	VoyagerVersion string                   `json:"voyager_version"`
+	GeneratedAt    time.Time                `json:"generated_at"`
+	Phases         []*migrationPhaseSummary `json:"phases"`
+}
+
+// timestamps are nil for the events which haven't happened yet
+type migrationPhaseSummary struct {
+	Phase               string     `json:"phase"`
+	StartedAt           *time.Time `json:"started_at"`
+	SnapshotCompletedAt *time.Time `json:"snapshot_completed_at,omitempty"`
+	StreamingStartedAt  *time.Time `json:"streaming_started_at,omitempty"`
+	CompletedAt         *time.Time `json:"completed_at"`
+}
+
+type exportDataStatusReport struct {
+	dataReportMetadata
+	Tables []*exportTableMigStatusOutputRow `json:"tables"`
+}
+
+type importDataStatusReport struct {
+	dataReportMetadata
+	Tables []*tableMigStatusOutputRow `json:"tables"`
+}
+
+type dataMigrationReport struct {
+	dataReportMetadata
+	Tables      []*rowData             `json:"tables"` // one row per table per database
+	EventCounts []*tableRoleEventCount `json:"event_counts"`
+	Mismatches  []*tableDataMismatch   `json:"mismatches"`
+}
+
+type tableRoleEventCount struct {
+	TableName string `json:"table_name"`
+	Role      string `json:"role"` // exporter/importer role e.g. source_db_exporter, target_db_importer
+	Inserts   int64  `json:"inserts"`
+	Updates   int64  `json:"updates"`
+	Deletes   int64  `json:"deletes"`
+}
+
+type tableDataMismatch struct {
+	TableName   string `json:"table_name"`
+	Description string `json:"description"`
+}
+
+func newDataReportMetadata(reportType string, msr *metadb.MigrationStatusRecord) dataReportMetadata {
+	return dataReportMetadata{
+		SchemaVersion:  DATA_REPORTS_SCHEMA_VERSION,
+		ReportType:     reportType,
+		MigrationUUID:  msr.MigrationUUID,
+		VoyagerVersion: utils.YB_VOYAGER_VERSION,
+		GeneratedAt:    time.Now().UTC(),
+		Phases:         getMigrationPhaseSummaries(msr),
+	}
+}
+
+func getMigrationPhaseSummaries(msr *metadb.MigrationStatusRecord) []*migrationPhaseSummary {
+	rank := func(phase string) int {
+		i := slices.Index(migrationPhasesOrder, phase)
+		if i == -1 {
+			// unknown phases at the end
+			return len(migrationPhasesOrder)
+		}
+		return i
+	}
+	phases := lo.Keys(msr.PhaseTimestamps)
+	slices.SortFunc(phases, func(a, b string) int {
+		return cmp.Or(cmp.Compare(rank(a), rank(b)), cmp.Compare(a, b))
+	})
+
+	timeOrNil := func(t time.Time) *time.Time {
+		if t.IsZero() {
+			return nil
+		}
+		return &t
+	}
+	var result []*migrationPhaseSummary
+	for _, phase := range phases {
+		ts := msr.PhaseTimestamps[phase]
+		result = append(result, &migrationPhaseSummary{
+			Phase:               phase,
+			StartedAt:           timeOrNil(ts.StartedAt),
+			SnapshotCompletedAt: timeOrNil(ts.SnapshotCompletedAt),
+			StreamingStartedAt:  timeOrNil(ts.StreamingStartedAt),
+			CompletedAt:         timeOrNil(ts.CompletedAt),
+		})
+	}
+	return result
+}
+
+//=========================================================================================
+
+// getDataMigrationReportEventCounts returns the events exported/imported per table by each of the exporter/importer roles.
+func getDataMigrationReportEventCounts(rows []*rowData) []*tableRoleEventCount {
+	var result []*tableRoleEventCount
+	add := func(tableName string, role string, inserts, updates, deletes int64) {
+		result = append(result, &tableRoleEventCount{
+			TableName: tableName, Role: role, Inserts: inserts, Updates: updates, Deletes: deletes,
+		})
+	}
+	for _, row := range rows {
+		switch row.DBType {
+		case "source":
+			add(row.TableName, SOURCE_DB_EXPORTER_ROLE, row.ExportedInserts, row.ExportedUpdates, row.ExportedDeletes)
+			if fBEnabled {
+				add(row.TableName, SOURCE_DB_IMPORTER_ROLE, row.ImportedInserts, row.ImportedUpdates, row.ImportedDeletes)
+			}
+		case "target":
+			add(row.TableName, TARGET_DB_IMPORTER_ROLE, row.ImportedInserts, row.ImportedUpdates, row.ImportedDeletes)
+			if fFEnabled {
+				add(row.TableName, TARGET_DB_EXPORTER_FF_ROLE, row.ExportedInserts, row.ExportedUpdates, row.ExportedDeletes)
+			} else if fBEnabled {
+				add(row.TableName, TARGET_DB_EXPORTER_FB_ROLE, row.ExportedInserts, row.ExportedUpdates, row.ExportedDeletes)
+			}
+		case "source-replica":
+			add(row.TableName, SOURCE_REPLICA_DB_IMPORTER_ROLE, row.ImportedInserts, row.ImportedUpdates, row.ImportedDeletes)
+		}
+	}
+	return result
+}
+
+// getDataMigrationReportMismatches compares the counts of what is exported from a database with what is imported into the
+// other databases. The counts are expected to differ while the migration is in progress and match once the cutover is complete.
+func getDataMigrationReportMismatches(rows []*rowData, importStarted bool) []*tableDataMismatch {
+	rowsByTable := lo.GroupBy(rows, func(row *rowData) string { return row.TableName })
+	var result []*tableDataMismatch
+	for _, tableName := range lo.Uniq(lo.Map(rows, func(row *rowData, _ int) string { return row.TableName })) {
+		rowByDBType := lo.KeyBy(rowsByTable[tableName], func(row *rowData) string { return row.DBType })
+		addMismatch := func(format string, args ...any) {
+			result = append(result, &tableDataMismatch{TableName: tableName, Description: fmt.Sprintf(format, args...)})
+		}
+		compareEvents := func(exporter *rowData, importer *rowData) {
+			if exporter.ExportedInserts != importer.ImportedInserts || exporter.ExportedUpdates != importer.ImportedUpdates ||
+				exporter.ExportedDeletes != importer.ImportedDeletes {
+				addMismatch("events exported from %s (inserts=%d, updates=%d, deletes=%d) don't match the events imported into %s (inserts=%d, updates=%d, deletes=%d)",
+					exporter.DBType, exporter.ExportedInserts, exporter.ExportedUpdates, exporter.ExportedDeletes,
+					importer.DBType, importer.ImportedInserts, importer.ImportedUpdates, importer.ImportedDeletes)
+			}
+		}
+
+		sourceRow, targetRow, replicaRow := rowByDBType["source"], rowByDBType["target"], rowByDBType["source-replica"]
+		if sourceRow == nil || targetRow == nil || !importStarted {
+			continue
+		}
+		if targetRow.ImportedSnapshotRows != sourceRow.ExportedSnapshotRows {
+			addMismatch("snapshot rows imported into target (%d) don't match the snapshot rows exported from source (%d)",
+				targetRow.ImportedSnapshotRows, sourceRow.ExportedSnapshotRows)
+		}
+		compareEvents(sourceRow, targetRow)
+		if replicaRow != nil {
+			if replicaRow.ImportedSnapshotRows != sourceRow.ExportedSnapshotRows {
+				addMismatch("snapshot rows imported into source-replica (%d) don't match the snapshot rows exported from source (%d)",
+					replicaRow.ImportedSnapshotRows, sourceRow.ExportedSnapshotRows)
+			}
+			compareEvents(targetRow, replicaRow)
+		}
+		if fBEnabled {
+			compareEvents(targetRow, sourceRow)
+		}
+
+		finalRowCounts := lo.Map(rowsByTable[tableName], func(row *rowData, _ int) int64 { return getFinalRowCount(*row) })
+		if len(lo.Uniq(finalRowCounts)) > 1 {
+			addMismatch("final row counts differ across the databases: %s", strings.Join(lo.Map(rowsByTable[tableName],
+				func(row *rowData, _ int) string {
+					return fmt.Sprintf("%s=%d", row.DBType, getFinalRowCount(*row))
+				}), ", "))
+		}
+	}
+	return result
+}
+
+//=========================================================================================
+
+//go:embed templates/data_report.template
+var dataReportHtmlTmpl string
+
+// dataReportHtml is the generic structure rendered by the html template for all the data reports.
+type dataReportHtml struct {
+	Title    string
+	Metadata dataReportMetadata
+	Sections []*dataReportHtmlSection
+}
+
+type dataReportHtmlSection struct {
+	Title  string
+	Note   string
+	Header []string
+	Rows   []*dataReportHtmlRow
+}
+
+type dataReportHtmlRow struct {
+	Cells    []any
+	Mismatch bool // highlighted in the report
+}
+
+func registerVersionedJsonReportFlag(cmd *cobra.Command) {
+	BoolVar(cmd.Flags(), &versionedJsonReport, "versioned-json", false,
+		"write the json report as an object with the schema version, migration metadata and phase timeline along with the tables, "+
+			"instead of just the array of tables (applicable only with --output-format json)")
+}
+
+// writeDataReport writes the report in the given format (json or html) to <export-dir>/reports/<fileName>.<format>
+// The json report is the whole versionedReport with --versioned-json, and just the tables otherwise.
+func writeDataReport(format string, fileName string, reportName string, versionedReport any, tables any, htmlReport *dataReportHtml) {
+	reportFilePath := filepath.Join(exportDir, "reports", fmt.Sprintf("%s.%s", fileName, format))
+	switch format {
+	case "json":
+		jsonReport := tables
+		if versionedJsonReport {
+			jsonReport = versionedReport
+		}
+		reportFile := jsonfile.NewJsonFile[any](reportFilePath)
+		err := reportFile.Create(&jsonReport)
+		if err != nil {
+			utils.ErrExit("creating into json file: %s: %v", reportFilePath, err)
+		}
+	case "html":
+		tmpl, err := template.New("data_report").Funcs(template.FuncMap{
+			"formatTime": func(t *time.Time) string {
+				if t == nil {
+					return "-"
+				}
+				return t.Format(time.RFC3339)
+			},
+		}).Parse(dataReportHtmlTmpl)
+		if err != nil {
+			utils.ErrExit("failed to parse the %s template: %v", reportName, err)
+		}
+		var buf bytes.Buffer
+		err = tmpl.Execute(&buf, htmlReport)
+		if err != nil {
+			utils.ErrExit("failed to execute the %s template: %v", reportName, err)
+		}
+		err = os.WriteFile(reportFilePath, buf.Bytes(), 0644)
+		if err != nil {
+			utils.ErrExit("writing %s to %q: %v", reportName, reportFilePath, err)
+		}
+	default:
+		panic(fmt.Sprintf("invalid report format: %q", format))
+	}
+	fmt.Print(color.GreenString("%s is written to %s\n", reportName, reportFilePath))
+}
@@ -0,0 +1,125 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
+)
+
+func TestMigrationPhaseSummariesAreOrdered(t *testing.T) {
+	startedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	msr := &metadb.MigrationStatusRecord{
+		PhaseTimestamps: map[string]*metadb.PhaseTimestamps{
+			"CUTOVER TO TARGET": {StartedAt: startedAt.Add(2 * time.Hour)},
+			"IMPORT DATA":       {StartedAt: startedAt.Add(time.Hour)},
+			"EXPORT DATA":       {StartedAt: startedAt, CompletedAt: startedAt.Add(3 * time.Hour)},
+		},
+	}
+	phases := getMigrationPhaseSummaries(msr)
+	assert.Len(t, phases, 3)
+	assert.Equal(t, "EXPORT DATA", phases[0].Phase)
+	assert.Equal(t, startedAt, *phases[0].StartedAt)
+	assert.Equal(t, startedAt.Add(3*time.Hour), *phases[0].CompletedAt)
+	assert.Nil(t, phases[0].SnapshotCompletedAt)
+	assert.Equal(t, "IMPORT DATA", phases[1].Phase)
+	assert.Nil(t, phases[1].CompletedAt)
+	assert.Equal(t, "CUTOVER TO TARGET", phases[2].Phase)
+}
+
+func TestDataMigrationReportMismatchesAndEventCounts(t *testing.T) {
+	fFEnabled, fBEnabled = false, false
+	rows := []*rowData{
+		{TableName: "public.orders", DBType: "source", ExportedSnapshotRows: 10, ExportedInserts: 5, ExportedDeletes: 1},
+		{TableName: "public.orders", DBType: "target", ImportedSnapshotRows: 10, ImportedInserts: 5, ImportedDeletes: 1},
+		{TableName: "public.users", DBType: "source", ExportedSnapshotRows: 10, ExportedUpdates: 2},
+		{TableName: "public.users", DBType: "target", ImportedSnapshotRows: 9, ImportedUpdates: 1},
+	}
+
+	mismatches := getDataMigrationReportMismatches(rows, true)
+	assert.Len(t, mismatches, 3)
+	for _, mismatch := range mismatches {
+		assert.Equal(t, "public.users", mismatch.TableName)
+	}
+	assert.Contains(t, mismatches[0].Description, "snapshot rows imported into target (9)")
+	assert.Contains(t, mismatches[1].Description, "events exported from source")
+	assert.Contains(t, mismatches[2].Description, "source=10, target=9")
+
+	// counts aren't compared before the import has started
+	assert.Empty(t, getDataMigrationReportMismatches(rows, false))
+
+	eventCounts := getDataMigrationReportEventCounts(rows)
+	assert.Len(t, eventCounts, 4)
+	assert.Equal(t, &tableRoleEventCount{TableName: "public.orders", Role: SOURCE_DB_EXPORTER_ROLE, Inserts: 5, Deletes: 1}, eventCounts[0])
+	assert.Equal(t, &tableRoleEventCount{TableName: "public.users", Role: TARGET_DB_IMPORTER_ROLE, Updates: 1}, eventCounts[3])
+}
+
+func TestDataReportFormats(t *testing.T) {
+	exportDir = t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(exportDir, "reports"), 0755))
+	msr := &metadb.MigrationStatusRecord{MigrationUUID: "f3e3e1a4-1111-2222-3333-444455556666"}
+	report := &exportDataStatusReport{
+		dataReportMetadata: newDataReportMetadata("export-data-status", msr),
+		Tables:             []*exportTableMigStatusOutputRow{{TableName: "<orders>", Status: "DONE", ExportedCount: 3}},
+	}
+
+	// only the tables by default
+	versionedJsonReport = false
+	writeDataReport("json", "test-report", "Test report", report, report.Tables, nil)
+	bytes, err := os.ReadFile(filepath.Join(exportDir, "reports", "test-report.json"))
+	assert.NoError(t, err)
+	var tables []map[string]any
+	assert.NoError(t, json.Unmarshal(bytes, &tables))
+	assert.Len(t, tables, 1)
+	assert.Equal(t, "<orders>", tables[0]["table_name"])
+
+	versionedJsonReport = true
+	defer func() { versionedJsonReport = false }()
+	writeDataReport("json", "test-report", "Test report", report, report.Tables, nil)
+	bytes, err = os.ReadFile(filepath.Join(exportDir, "reports", "test-report.json"))
+	assert.NoError(t, err)
+	var parsed map[string]any
+	assert.NoError(t, json.Unmarshal(bytes, &parsed))
+	assert.Equal(t, float64(DATA_REPORTS_SCHEMA_VERSION), parsed["schema_version"])
+	assert.Equal(t, "export-data-status", parsed["report_type"])
+	assert.Equal(t, msr.MigrationUUID, parsed["migration_uuid"])
+	assert.Len(t, parsed["tables"], 1)
+
+	writeDataReport("html", "test-report", "Test report", report, report.Tables, &dataReportHtml{
+		Title:    "Export Data Status",
+		Metadata: report.dataReportMetadata,
+		Sections: []*dataReportHtmlSection{{
+			Title:  "Tables",
+			Header: []string{"TABLE"},
+			Rows:   []*dataReportHtmlRow{{Cells: []any{"<orders>"}, Mismatch: true}},
+		}},
+	})
+	bytes, err = os.ReadFile(filepath.Join(exportDir, "reports", "test-report.html"))
+	assert.NoError(t, err)
+	html := string(bytes)
+	assert.Contains(t, html, `<tr class="mismatch">`)
+	assert.Contains(t, html, "&lt;orders&gt;")
+	assert.Contains(t, html, msr.MigrationUUID)
+}
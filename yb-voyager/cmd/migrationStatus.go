@@ -0,0 +1,370 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/gosuri/uitable"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/lockfile"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
+)
+
+const (
+	PHASE_NOT_STARTED = "NOT STARTED"
+	PHASE_IN_PROGRESS = "IN PROGRESS"
+	PHASE_STOPPED     = "STOPPED" // started earlier but neither completed nor running now
+	PHASE_COMPLETED   = "COMPLETED"
+)
+
+var migrationCmd = &cobra.Command{
+	Use:   "migration",
+	Short: PARENT_COMMAND_USAGE,
+	Long:  ``,
+}
+
+var migrationStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Prints the status of all the phases of the migration",
+	Long: `Prints a timeline of the migration phases (assess, export/analyze/import schema, snapshot export/import, streaming, cutover, fall-forward/fall-back and end migration)
+along with their status, timestamps, the voyager commands running currently and the next recommended command.`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		reportMigrationStatus()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrationCmd)
+	migrationCmd.AddCommand(migrationStatusCmd)
+	registerExportDirFlag(migrationStatusCmd)
+}
+
+type migrationPhaseStatus struct {
+	Phase       string
+	Status      string
+	StartedAt   time.Time
+	CompletedAt time.Time
+	Commands    []string // voyager commands which perform this phase, as named in their lock files
+	RunningCmds []string
+	RunningPIDs []int
+}
+
+// cutover statuses (NOT_INITIATED/INITIATED/COMPLETED) of the migration
+type cutoverStatuses struct {
+	toTarget        string
+	toSourceReplica string
+	toSource        string
+}
+
+func reportMigrationStatus() {
+	msr, err := metaDB.GetMigrationStatusRecord()
+	if err != nil {
+		utils.ErrExit("get migration status record: %v", err)
+	}
+	if msr == nil {
+		utils.ErrExit("migration status record not found in export directory: %q", exportDir)
+	}
+	cutover := cutoverStatuses{
+		toTarget:        getCutoverStatus(),
+		toSourceReplica: getCutoverToSourceReplicaStatus(),
+		toSource:        getCutoverToSourceStatus(),
+	}
+	runningCmds := getRunningVoyagerCommands(exportDir)
+	phases := getMigrationPhaseStatuses(msr, cutover, schemaIsAnalyzed(), runningCmds)
+
+	fmt.Printf("Migration UUID: %s\n", msr.MigrationUUID)
+	fmt.Printf("Migration type: %s\n\n", getMigrationTypeDescription(msr))
+
+	table := uitable.New()
+	addHeader(table, "PHASE", "STATUS", "STARTED AT", "COMPLETED AT", "RUNNING PIDS")
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.Local().Format(time.DateTime)
+	}
+	for _, phase := range phases {
+		pids := lo.Map(phase.RunningPIDs, func(pid int, _ int) string { return fmt.Sprint(pid) })
+		table.AddRow(phase.Phase, colorPhaseStatus(phase.Status), formatTime(phase.StartedAt),
+			formatTime(phase.CompletedAt), strings.Join(pids, ", "))
+	}
+	fmt.Println(table)
+
+	fmt.Println()
+	if len(runningCmds) == 0 {
+		fmt.Println("Running voyager commands: none")
+	} else {
+		fmt.Println("Running voyager commands:")
+		cmdNames := lo.Keys(runningCmds)
+		slices.Sort(cmdNames)
+		for _, cmdName := range cmdNames {
+			fmt.Printf("  yb-voyager %s (PID %d)\n", cmdName, runningCmds[cmdName])
+		}
+	}
+	fmt.Printf("\nNext step: %s\n", getMigrationNextStep(msr, phases))
+}
+
+func colorPhaseStatus(status string) string {
+	switch status {
+	case PHASE_COMPLETED:
+		return color.GreenString(status)
+	case PHASE_IN_PROGRESS:
+		return color.YellowString(status)
+	case PHASE_STOPPED:
+		return color.RedString(status)
+	default:
+		return status
+	}
+}
+
+func getMigrationTypeDescription(msr *metadb.MigrationStatusRecord) string {
+	switch {
+	case !changeStreamingIsEnabled(msr.ExportType):
+		return "offline"
+	case msr.FallForwardEnabled:
+		return "live migration with fall-forward"
+	case msr.FallbackEnabled:
+		return "live migration with fall-back"
+	default:
+		return "live migration"
+	}
+}
+
+// getRunningVoyagerCommands returns the voyager commands (e.g. "export data") currently running on the export dir with their PIDs
+func getRunningVoyagerCommands(exportDir string) map[string]int {
+	lockFiles, err := filepath.Glob(filepath.Join(exportDir, ".*.lck"))
+	if err != nil {
+		utils.ErrExit("finding lock files in export dir %q: %v", exportDir, err)
+	}
+	result := make(map[string]int)
+	for _, lockFilePath := range lockFiles {
+		lockFile := lockfile.NewLockfile(lockFilePath)
+		if !lockFile.IsPIDActive() {
+			continue
+		}
+		pid, err := lockFile.GetCmdPID()
+		if err != nil {
+			log.Warnf("getting PID of command %q: %v", lockFile.GetCmdName(), err)
+			continue
+		}
+		result[lockFile.GetCmdName()] = pid
+	}
+	return result
+}
+
+// getMigrationPhaseStatuses returns the phases applicable to the migration in the order in which they are performed.
+func getMigrationPhaseStatuses(msr *metadb.MigrationStatusRecord, cutover cutoverStatuses,
+	schemaAnalyzed bool, runningCmds map[string]int) []*migrationPhaseStatus {
+
+	timestamps := func(phase string) metadb.PhaseTimestamps {
+		if ts := msr.PhaseTimestamps[phase]; ts != nil {
+			return *ts
+		}
+		return metadb.PhaseTimestamps{}
+	}
+	var result []*migrationPhaseStatus
+	addPhase := func(phase string, startedAt time.Time, completedAt time.Time, done bool, cmds ...string) {
+		p := &migrationPhaseStatus{Phase: phase, StartedAt: startedAt, Commands: cmds}
+		for _, cmdName := range cmds {
+			if pid, ok := runningCmds[cmdName]; ok {
+				p.RunningCmds = append(p.RunningCmds, cmdName)
+				p.RunningPIDs = append(p.RunningPIDs, pid)
+			}
+		}
+		switch {
+		case done:
+			p.Status = PHASE_COMPLETED
+			p.CompletedAt = completedAt
+		case len(p.RunningPIDs) > 0:
+			p.Status = PHASE_IN_PROGRESS
+		case !startedAt.IsZero():
+			p.Status = PHASE_STOPPED
+		default:
+			p.Status = PHASE_NOT_STARTED
+		}
+		result = append(result, p)
+	}
+	addCutoverPhase := func(phase string, status string) {
+		ts := timestamps(phase)
+		addPhase(phase, ts.StartedAt, ts.CompletedAt, status == COMPLETED)
+		if status == INITIATED {
+			// cutover is processed by the export/import commands, so it is in progress even if none of them is running
+			result[len(result)-1].Status = PHASE_IN_PROGRESS
+		}
+	}
+
+	ts := timestamps("ASSESS MIGRATION")
+	addPhase("ASSESS MIGRATION", ts.StartedAt, ts.CompletedAt, msr.MigrationAssessmentDone, "assess migration")
+	ts = timestamps("EXPORT SCHEMA")
+	addPhase("EXPORT SCHEMA", ts.StartedAt, ts.CompletedAt, msr.ExportSchemaDone, "export schema")
+	ts = timestamps("ANALYZE SCHEMA")
+	addPhase("ANALYZE SCHEMA", ts.StartedAt, ts.CompletedAt, schemaAnalyzed, "analyze schema")
+	ts = timestamps("IMPORT SCHEMA")
+	addPhase("IMPORT SCHEMA", ts.StartedAt, ts.CompletedAt, !ts.CompletedAt.IsZero(), "import schema")
+
+	liveMigration := changeStreamingIsEnabled(msr.ExportType)
+	snapshotCompletedAt := func(ts metadb.PhaseTimestamps) time.Time {
+		if ts.SnapshotCompletedAt.IsZero() && !liveMigration {
+			return ts.CompletedAt
+		}
+		return ts.SnapshotCompletedAt
+	}
+	exportTs := timestamps("EXPORT DATA")
+	addPhase("SNAPSHOT EXPORT", exportTs.StartedAt, snapshotCompletedAt(exportTs), msr.ExportDataDone,
+		"export data", "export data from source")
+	importTs := timestamps("IMPORT DATA")
+	addPhase("SNAPSHOT IMPORT", importTs.StartedAt, snapshotCompletedAt(importTs), !snapshotCompletedAt(importTs).IsZero(),
+		"import data", "import data to target")
+
+	if liveMigration {
+		cutoverTs := timestamps("CUTOVER TO TARGET")
+		addPhase("STREAMING CHANGES", exportTs.StreamingStartedAt, cutoverTs.CompletedAt, cutover.toTarget == COMPLETED,
+			"export data", "export data from source", "import data", "import data to target")
+		addCutoverPhase("CUTOVER TO TARGET", cutover.toTarget)
+	}
+
+	if msr.FallForwardEnabled || msr.FallbackEnabled {
+		cutoverPhase, cutoverStatus := "CUTOVER TO SOURCE-REPLICA", cutover.toSourceReplica
+		importPhase, importCmd := "IMPORT DATA TO SOURCE REPLICA", "import data to source replica"
+		if msr.FallbackEnabled {
+			cutoverPhase, cutoverStatus = "CUTOVER TO SOURCE", cutover.toSource
+			importPhase, importCmd = "IMPORT DATA TO SOURCE", "import data to source"
+		}
+		cutoverTs := timestamps(cutoverPhase)
+		ts = timestamps("EXPORT DATA FROM TARGET")
+		addPhase("EXPORT DATA FROM TARGET", ts.StartedAt, cutoverTs.CompletedAt, cutoverStatus == COMPLETED,
+			"export data from target")
+		ts = timestamps(importPhase)
+		addPhase(importPhase, ts.StartedAt, cutoverTs.CompletedAt, cutoverStatus == COMPLETED, importCmd)
+		addCutoverPhase(cutoverPhase, cutoverStatus)
+	}
+
+	// the export dir is cleaned up once the migration has ended, so it is never reported as completed
+	ts = timestamps("END MIGRATION")
+	addPhase("END MIGRATION", ts.StartedAt, time.Time{}, false, "end migration")
+	if endPhase := result[len(result)-1]; endPhase.Status == PHASE_NOT_STARTED && msr.EndMigrationRequested {
+		endPhase.Status = PHASE_STOPPED
+	}
+	return result
+}
+
+// getMigrationNextStep recommends what to do next based on the first phase which is not completed yet.
+func getMigrationNextStep(msr *metadb.MigrationStatusRecord, phases []*migrationPhaseStatus) string {
+	liveMigration := changeStreamingIsEnabled(msr.ExportType)
+	command := func(cmdName string) string {
+		return fmt.Sprintf("yb-voyager %s --export-dir %q", cmdName, exportDir)
+	}
+	phaseCommand := func(phase *migrationPhaseStatus) string {
+		switch phase.Phase {
+		case "EXPORT SCHEMA":
+			return command("export schema")
+		case "ANALYZE SCHEMA":
+			return command("analyze-schema")
+		case "IMPORT SCHEMA":
+			return command("import schema")
+		case "SNAPSHOT EXPORT":
+			return lo.Ternary(liveMigration, command("export data from source"), command("export data"))
+		case "SNAPSHOT IMPORT":
+			return lo.Ternary(liveMigration, command("import data to target"), command("import data"))
+		case "STREAMING CHANGES":
+			return command("initiate cutover to target")
+		case "CUTOVER TO TARGET", "CUTOVER TO SOURCE-REPLICA", "CUTOVER TO SOURCE":
+			return command("cutover status")
+		case "EXPORT DATA FROM TARGET":
+			return command("export data from target")
+		case "IMPORT DATA TO SOURCE REPLICA":
+			return command("import data to source-replica")
+		case "IMPORT DATA TO SOURCE":
+			return command("import data to source")
+		default:
+			return command("end migration")
+		}
+	}
+
+	// both the exporter and the importer have to be running to stream the changes and to process the cutover
+	streamingCmdsNotRunning := func(phase *migrationPhaseStatus) []string {
+		var result []string
+		if !lo.Contains(phase.RunningCmds, "export data") && !lo.Contains(phase.RunningCmds, "export data from source") {
+			result = append(result, command("export data from source"))
+		}
+		if !lo.Contains(phase.RunningCmds, "import data") && !lo.Contains(phase.RunningCmds, "import data to target") {
+			result = append(result, command("import data to target"))
+		}
+		return result
+	}
+
+	for i, phase := range phases {
+		if phase.Phase == "ASSESS MIGRATION" || phase.Status == PHASE_COMPLETED {
+			// assessment is optional
+			continue
+		}
+		if phase.Phase == "STREAMING CHANGES" && phase.Status != PHASE_NOT_STARTED {
+			if notRunning := streamingCmdsNotRunning(phase); len(notRunning) > 0 {
+				return fmt.Sprintf("streaming of changes is not running, resume it: %s", strings.Join(notRunning, " and "))
+			}
+			if phases[i+1].Status == PHASE_NOT_STARTED {
+				return fmt.Sprintf("changes are being streamed to the target; once the target is in sync, initiate the cutover: %s",
+					phaseCommand(phase))
+			}
+			// cutover is initiated
+			continue
+		}
+		switch phase.Status {
+		case PHASE_IN_PROGRESS:
+			if strings.HasPrefix(phase.Phase, "CUTOVER TO") {
+				return fmt.Sprintf("wait for the cutover to complete, check its status with: %s", phaseCommand(phase))
+			}
+			// the import runs alongside the export in live migration, as does the import to source/source-replica with the export from target
+			if i+1 < len(phases) && (liveMigration && phase.Phase == "SNAPSHOT EXPORT" || phase.Phase == "EXPORT DATA FROM TARGET") &&
+				lo.Contains([]string{PHASE_NOT_STARTED, PHASE_STOPPED}, phases[i+1].Status) {
+				return fmt.Sprintf("start the import in parallel with the running export: %s", phaseCommand(phases[i+1]))
+			}
+			return fmt.Sprintf("wait for the running command(s) of %s to complete (PID %s)", strings.ToLower(phase.Phase),
+				strings.Join(lo.Map(phase.RunningPIDs, func(pid int, _ int) string { return fmt.Sprint(pid) }), ", "))
+		case PHASE_STOPPED:
+			if phase.Phase == "END MIGRATION" {
+				return fmt.Sprintf("end migration was started earlier, run it again to complete: %s", phaseCommand(phase))
+			}
+			return fmt.Sprintf("%s was started earlier but is not running, resume it: %s", strings.ToLower(phase.Phase), phaseCommand(phase))
+		default:
+			switch phase.Phase {
+			case "CUTOVER TO SOURCE-REPLICA":
+				return command("initiate cutover to source-replica")
+			case "CUTOVER TO SOURCE":
+				return command("initiate cutover to source")
+			case "CUTOVER TO TARGET":
+				return command("initiate cutover to target")
+			case "EXPORT SCHEMA":
+				if !msr.MigrationAssessmentDone {
+					return fmt.Sprintf("%s (optionally, assess the migration first: %s)", phaseCommand(phase), command("assess-migration"))
+				}
+			}
+			return phaseCommand(phase)
+		}
+	}
+	return command("end migration")
+}
@@ -0,0 +1,134 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
+)
+
+func phaseStatusesByName(phases []*migrationPhaseStatus) map[string]string {
+	return lo.SliceToMap(phases, func(p *migrationPhaseStatus) (string, string) { return p.Phase, p.Status })
+}
+
+func TestOfflineMigrationStatus(t *testing.T) {
+	exportDir = "/tmp/export-dir"
+	startedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	msr := &metadb.MigrationStatusRecord{
+		ExportType:       SNAPSHOT_ONLY,
+		ExportSchemaDone: true,
+		PhaseTimestamps: map[string]*metadb.PhaseTimestamps{
+			"IMPORT SCHEMA": {StartedAt: startedAt, CompletedAt: startedAt.Add(time.Minute)},
+			"EXPORT DATA":   {StartedAt: startedAt.Add(time.Hour)},
+		},
+	}
+	cutover := cutoverStatuses{NOT_INITIATED, NOT_INITIATED, NOT_INITIATED}
+
+	phases := getMigrationPhaseStatuses(msr, cutover, true, map[string]int{"export data": 1234})
+	assert.Equal(t, []string{"ASSESS MIGRATION", "EXPORT SCHEMA", "ANALYZE SCHEMA", "IMPORT SCHEMA",
+		"SNAPSHOT EXPORT", "SNAPSHOT IMPORT", "END MIGRATION"},
+		lo.Map(phases, func(p *migrationPhaseStatus, _ int) string { return p.Phase }))
+	statuses := phaseStatusesByName(phases)
+	assert.Equal(t, PHASE_NOT_STARTED, statuses["ASSESS MIGRATION"])
+	assert.Equal(t, PHASE_COMPLETED, statuses["IMPORT SCHEMA"])
+	assert.Equal(t, startedAt.Add(time.Minute), phases[3].CompletedAt)
+	assert.Equal(t, PHASE_IN_PROGRESS, statuses["SNAPSHOT EXPORT"])
+	assert.Equal(t, []int{1234}, phases[4].RunningPIDs)
+	assert.Contains(t, getMigrationNextStep(msr, phases), "wait for the running command(s) of snapshot export to complete (PID 1234)")
+
+	// export stopped midway
+	phases = getMigrationPhaseStatuses(msr, cutover, true, nil)
+	assert.Equal(t, PHASE_STOPPED, phaseStatusesByName(phases)["SNAPSHOT EXPORT"])
+	assert.Contains(t, getMigrationNextStep(msr, phases), `resume it: yb-voyager export data --export-dir "/tmp/export-dir"`)
+
+	msr.ExportDataDone = true
+	msr.PhaseTimestamps["IMPORT DATA"] = &metadb.PhaseTimestamps{StartedAt: startedAt, CompletedAt: startedAt.Add(2 * time.Hour)}
+	phases = getMigrationPhaseStatuses(msr, cutover, true, nil)
+	assert.Equal(t, PHASE_COMPLETED, phaseStatusesByName(phases)["SNAPSHOT IMPORT"])
+	assert.Equal(t, `yb-voyager end migration --export-dir "/tmp/export-dir"`, getMigrationNextStep(msr, phases))
+}
+
+func TestLiveMigrationWithFallForwardStatus(t *testing.T) {
+	exportDir = "/tmp/export-dir"
+	msr := &metadb.MigrationStatusRecord{
+		ExportType:              SNAPSHOT_AND_CHANGES,
+		FallForwardEnabled:      true,
+		MigrationAssessmentDone: true,
+		ExportSchemaDone:        true,
+		ExportDataDone:          true,
+		PhaseTimestamps: map[string]*metadb.PhaseTimestamps{
+			"IMPORT SCHEMA": {StartedAt: time.Now(), CompletedAt: time.Now()},
+			"EXPORT DATA":   {StartedAt: time.Now(), SnapshotCompletedAt: time.Now(), StreamingStartedAt: time.Now()},
+		},
+	}
+	cutover := cutoverStatuses{NOT_INITIATED, NOT_INITIATED, NOT_INITIATED}
+
+	// import hasn't started yet while the export is streaming changes
+	phases := getMigrationPhaseStatuses(msr, cutover, true, map[string]int{"export data": 10})
+	statuses := phaseStatusesByName(phases)
+	assert.Equal(t, PHASE_COMPLETED, statuses["SNAPSHOT EXPORT"])
+	assert.Equal(t, PHASE_NOT_STARTED, statuses["SNAPSHOT IMPORT"])
+	assert.Equal(t, PHASE_IN_PROGRESS, statuses["STREAMING CHANGES"])
+	assert.Contains(t, statuses, "IMPORT DATA TO SOURCE REPLICA")
+	assert.NotContains(t, statuses, "IMPORT DATA TO SOURCE")
+	assert.Equal(t, `yb-voyager import data to target --export-dir "/tmp/export-dir"`, getMigrationNextStep(msr, phases))
+
+	msr.PhaseTimestamps["IMPORT DATA"] = &metadb.PhaseTimestamps{StartedAt: time.Now(), SnapshotCompletedAt: time.Now()}
+	phases = getMigrationPhaseStatuses(msr, cutover, true, map[string]int{"export data": 10, "import data": 11})
+	assert.ElementsMatch(t, []int{10, 11}, phases[6].RunningPIDs)
+	assert.Contains(t, getMigrationNextStep(msr, phases), "initiate the cutover: yb-voyager initiate cutover to target")
+
+	cutover.toTarget = INITIATED
+	phases = getMigrationPhaseStatuses(msr, cutover, true, map[string]int{"export data": 10, "import data": 11})
+	assert.Equal(t, PHASE_IN_PROGRESS, phaseStatusesByName(phases)["CUTOVER TO TARGET"])
+	assert.Contains(t, getMigrationNextStep(msr, phases), "yb-voyager cutover status")
+
+	// cutover can't be processed while the importer is stopped
+	phases = getMigrationPhaseStatuses(msr, cutover, true, map[string]int{"export data": 10})
+	assert.Equal(t, `streaming of changes is not running, resume it: yb-voyager import data to target --export-dir "/tmp/export-dir"`,
+		getMigrationNextStep(msr, phases))
+
+	// export from target runs alongside the import to source-replica
+	cutover.toTarget = COMPLETED
+	msr.PhaseTimestamps["EXPORT DATA FROM TARGET"] = &metadb.PhaseTimestamps{StartedAt: time.Now()}
+	phases = getMigrationPhaseStatuses(msr, cutover, true, map[string]int{"export data from target": 12})
+	statuses = phaseStatusesByName(phases)
+	assert.Equal(t, PHASE_COMPLETED, statuses["STREAMING CHANGES"])
+	assert.Equal(t, PHASE_IN_PROGRESS, statuses["EXPORT DATA FROM TARGET"])
+	assert.Contains(t, getMigrationNextStep(msr, phases), "yb-voyager import data to source-replica")
+
+	cutover.toSourceReplica = COMPLETED
+	msr.EndMigrationRequested = true
+	phases = getMigrationPhaseStatuses(msr, cutover, true, nil)
+	assert.Equal(t, PHASE_STOPPED, phaseStatusesByName(phases)["END MIGRATION"])
+	assert.Contains(t, getMigrationNextStep(msr, phases), "run it again to complete: yb-voyager end migration")
+
+	endMigrationStartedAt := time.Now()
+	msr.PhaseTimestamps["END MIGRATION"] = &metadb.PhaseTimestamps{StartedAt: endMigrationStartedAt}
+	phases = getMigrationPhaseStatuses(msr, cutover, true, map[string]int{"end migration": 13})
+	endPhase := phases[len(phases)-1]
+	assert.Equal(t, "END MIGRATION", endPhase.Phase)
+	assert.Equal(t, PHASE_IN_PROGRESS, endPhase.Status)
+	assert.Equal(t, endMigrationStartedAt, endPhase.StartedAt)
+	assert.Equal(t, []int{13}, endPhase.RunningPIDs)
+}
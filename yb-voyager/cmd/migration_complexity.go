@@ -46,7 +46,7 @@ var (
 
 // Migration complexity calculation based on the detected assessment issues
 func calculateMigrationComplexity(sourceDBType string, schemaDirectory string, assessmentReport AssessmentReport) string {
-	if sourceDBType != ORACLE && sourceDBType != POSTGRESQL {
+	if sourceDBType != ORACLE && sourceDBType != POSTGRESQL && sourceDBType != MYSQL {
 		return NOT_AVAILABLE
 	}
 
@@ -59,7 +59,8 @@ func calculateMigrationComplexity(sourceDBType string, schemaDirectory string, a
 			return NOT_AVAILABLE
 		}
 		return migrationComplexity
-	case POSTGRESQL:
+	case POSTGRESQL, MYSQL:
+		// issues for MySQL are detected on the schema converted to PostgreSQL
 		return calculateMigrationComplexityForPG(assessmentReport)
 	default:
 		panic(fmt.Sprintf("unsupported source db type '%s' for migration complexity", sourceDBType))
@@ -246,6 +247,39 @@ const explainTemplateHTML = `
 	<strong>Reasoning:</strong> {{ .ComplexityRationale }}
 </p>
 
+{{- if .Effort }}
+<p>
+	<strong>Estimated Effort:</strong> {{ .Effort.TotalHours }} hour(s)</br>
+	The effort is the sum of the hours for each issue as per the cost model: hours for the issue type (or for its impact level) multiplied by the factor for the object type.
+</p>
+{{- if .EffortDerivation }}
+	<table border="1" cellpadding="5" cellspacing="0" style="border-collapse: collapse;">
+		<thead>
+			<tr>
+				<th>Issue Type</th>
+				<th>Impact</th>
+				<th>Object Type</th>
+				<th>Issues</th>
+				<th>Hours per Issue</th>
+				<th>Hours</th>
+			</tr>
+		</thead>
+		<tbody>
+		{{- range .EffortDerivation }}
+			<tr>
+				<td>{{ .IssueType }}</td>
+				<td>{{ .Impact }}</td>
+				<td>{{ .ObjectType }}</td>
+				<td>{{ .NumIssues }}</td>
+				<td>{{ .HoursPerIssue }}</td>
+				<td>{{ .Hours }}</td>
+			</tr>
+		{{- end }}
+		</tbody>
+	</table>
+{{- end }}
+{{- end }}
+
 <p>
 <strong>Impact Levels:</strong></br>
 	Level-1: Resolutions are available with minimal effort.<br/>
@@ -254,12 +288,20 @@ const explainTemplateHTML = `
 </p>
 `
 
-const explainTemplateText = `Reasoning: {{ .ComplexityRationale }}`
+const explainTemplateText = `Reasoning: {{ .ComplexityRationale }}
+{{- if .Effort }}
+Estimated Effort: {{ .Effort.TotalHours }} hour(s) as per the cost model
+{{- range .EffortDerivation }}
+  {{ .IssueType }} ({{ .Impact }}{{ if .ObjectType }}, {{ .ObjectType }}{{ end }}): {{ .NumIssues }} issue(s) x {{ .HoursPerIssue }} hour(s) = {{ .Hours }} hour(s)
+{{- end }}
+{{- end }}`
 
 type MigrationComplexityExplanationData struct {
 	Summaries           []MigrationComplexityCategorySummary
 	Complexity          string
 	ComplexityRationale string // short reasoning or explanation text
+	Effort              *MigrationEffort
+	EffortDerivation    []MigrationEffortDerivation // how the effort estimate was derived from the cost model
 }
 
 type MigrationComplexityCategorySummary struct {
@@ -278,6 +320,10 @@ func buildMigrationComplexityExplanation(sourceDBType string, assessmentReport A
 	explanation.ComplexityRationale = migrationComplexityRationale
 
 	explanation.Summaries = buildCategorySummary(assessmentReport.Issues)
+	if assessmentReport.MigrationEffort != nil {
+		explanation.Effort = assessmentReport.MigrationEffort
+		explanation.EffortDerivation = buildMigrationEffortDerivation(assessmentReport.Issues, migrationEffortCostModel)
+	}
 
 	var tmpl *template.Template
 	var err error
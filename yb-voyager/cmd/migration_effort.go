@@ -0,0 +1,294 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/constants"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
+)
+
+/*
+Migration effort is estimated from the detected assessment issues using a cost model:
+
+	hours for an issue = (hours for the issue type, if defined, else hours for the impact level of the issue)
+	                     * factor for the object type of the issue (default 1)
+
+The built-in cost model can be overridden with a YAML file passed in --effort-cost-model-file, for example:
+
+	impact_hours:
+	  LEVEL_1: 0.5
+	  LEVEL_2: 2
+	  LEVEL_3: 8
+	issue_type_hours:
+	  INHERITANCE: 24
+	object_type_factors:
+	  FUNCTION: 2
+
+Only the entries present in the file override the built-in ones.
+*/
+
+const UNATTRIBUTED_EFFORT = "(unattributed)"
+
+var (
+	effortCostModelFileFlag  string
+	migrationEffortCostModel = defaultMigrationEffortCostModel()
+)
+
+type MigrationEffortCostModel struct {
+	ImpactHours       map[string]float64 `yaml:"impact_hours"`
+	IssueTypeHours    map[string]float64 `yaml:"issue_type_hours"`
+	ObjectTypeFactors map[string]float64 `yaml:"object_type_factors"`
+}
+
+func defaultMigrationEffortCostModel() *MigrationEffortCostModel {
+	return &MigrationEffortCostModel{
+		ImpactHours: map[string]float64{
+			constants.IMPACT_LEVEL_1: 0.5,
+			constants.IMPACT_LEVEL_2: 2,
+			constants.IMPACT_LEVEL_3: 8,
+		},
+		IssueTypeHours: map[string]float64{},
+		// code objects need to be rewritten and retested, which takes longer than fixing a DDL
+		ObjectTypeFactors: map[string]float64{
+			"FUNCTION":  1.5,
+			"PROCEDURE": 1.5,
+			"TRIGGER":   1.5,
+		},
+	}
+}
+
+// LoadMigrationEffortCostModel reads the cost model from the given YAML file and merges it over the built-in one.
+func LoadMigrationEffortCostModel(filePath string) (*MigrationEffortCostModel, error) {
+	bytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading effort cost model file %q: %w", filePath, err)
+	}
+
+	var userModel MigrationEffortCostModel
+	decoder := yaml.NewDecoder(strings.NewReader(string(bytes)))
+	decoder.KnownFields(true)
+	err = decoder.Decode(&userModel)
+	if err != nil {
+		return nil, fmt.Errorf("parsing effort cost model file %q: %w", filePath, err)
+	}
+
+	model := defaultMigrationEffortCostModel()
+	err = model.merge(&userModel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid effort cost model file %q: %w", filePath, err)
+	}
+	log.Infof("loaded effort cost model from file %q: %+v", filePath, model)
+	return model, nil
+}
+
+func (m *MigrationEffortCostModel) merge(other *MigrationEffortCostModel) error {
+	validImpacts := []string{constants.IMPACT_LEVEL_1, constants.IMPACT_LEVEL_2, constants.IMPACT_LEVEL_3}
+	for impact, hours := range other.ImpactHours {
+		impact = strings.ToUpper(impact)
+		if !lo.Contains(validImpacts, impact) {
+			return fmt.Errorf("impact_hours: invalid impact level %q, expected one of %v", impact, validImpacts)
+		}
+		if hours < 0 {
+			return fmt.Errorf("impact_hours: negative hours %v for %s", hours, impact)
+		}
+		m.ImpactHours[impact] = hours
+	}
+	for issueType, hours := range other.IssueTypeHours {
+		if hours < 0 {
+			return fmt.Errorf("issue_type_hours: negative hours %v for %s", hours, issueType)
+		}
+		m.IssueTypeHours[strings.ToUpper(issueType)] = hours
+	}
+	for objectType, factor := range other.ObjectTypeFactors {
+		if factor < 0 {
+			return fmt.Errorf("object_type_factors: negative factor %v for %s", factor, objectType)
+		}
+		m.ObjectTypeFactors[strings.ToUpper(objectType)] = factor
+	}
+	return nil
+}
+
+// returns the hours per issue for the given issue as per the cost model
+func (m *MigrationEffortCostModel) getHoursForIssue(issue AssessmentIssue) float64 {
+	hours, ok := m.IssueTypeHours[strings.ToUpper(issue.Type)]
+	if !ok {
+		// issues without the impact level are considered as level-1
+		hours = m.ImpactHours[lo.Ternary(issue.Impact != "", issue.Impact, constants.IMPACT_LEVEL_1)]
+	}
+	factor, ok := m.ObjectTypeFactors[strings.ToUpper(issue.ObjectType)]
+	if !ok {
+		factor = 1
+	}
+	return hours * factor
+}
+
+func loadMigrationEffortCostModelFromFileFlag() error {
+	if effortCostModelFileFlag == "" {
+		return nil
+	}
+	model, err := LoadMigrationEffortCostModel(effortCostModelFileFlag)
+	if err != nil {
+		return err
+	}
+	migrationEffortCostModel = model
+	return nil
+}
+
+type MigrationEffort struct {
+	TotalHours float64                    `json:"TotalHours"`
+	ByCategory []MigrationEffortBreakdown `json:"ByCategory"`
+	BySchema   []MigrationEffortBreakdown `json:"BySchema"`
+	ByObject   []MigrationEffortBreakdown `json:"ByObject"`
+}
+
+type MigrationEffortBreakdown struct {
+	Name      string  `json:"Name"`
+	NumIssues int     `json:"NumIssues"`
+	Hours     float64 `json:"Hours"`
+}
+
+func estimateMigrationEffort(sourceDBType string, issues []AssessmentIssue, model *MigrationEffortCostModel) *MigrationEffort {
+	if sourceDBType != ORACLE && sourceDBType != POSTGRESQL && sourceDBType != MYSQL {
+		return nil
+	}
+
+	effort := &MigrationEffort{}
+	byCategory := make(map[string]*MigrationEffortBreakdown)
+	bySchema := make(map[string]*MigrationEffortBreakdown)
+	byObject := make(map[string]*MigrationEffortBreakdown)
+	addToBreakdown := func(breakdown map[string]*MigrationEffortBreakdown, name string, hours float64) {
+		if _, ok := breakdown[name]; !ok {
+			breakdown[name] = &MigrationEffortBreakdown{Name: name}
+		}
+		breakdown[name].NumIssues++
+		breakdown[name].Hours += hours
+	}
+
+	for _, issue := range issues {
+		hours := model.getHoursForIssue(issue)
+		effort.TotalHours += hours
+		addToBreakdown(byCategory, lo.Ternary(issue.Category != "", utils.SnakeCaseToTitleCase(issue.Category), UNATTRIBUTED_EFFORT), hours)
+		addToBreakdown(bySchema, getSchemaNameOfIssueObject(issue), hours)
+		addToBreakdown(byObject, getEffortObjectName(issue), hours)
+	}
+
+	effort.TotalHours = roundEffortHours(effort.TotalHours)
+	effort.ByCategory = sortedEffortBreakdown(byCategory)
+	effort.BySchema = sortedEffortBreakdown(bySchema)
+	effort.ByObject = sortedEffortBreakdown(byObject)
+	log.Infof("estimated migration effort: %v hours for %d issues", effort.TotalHours, len(issues))
+	return effort
+}
+
+/*
+Object names of the issues are like "schema.table", "schema.table.column" or "index_name ON schema.table".
+Issues without a qualified object name(like the unsupported query constructs) are unattributed.
+*/
+func getSchemaNameOfIssueObject(issue AssessmentIssue) string {
+	objectName := issue.ObjectName
+	if idx := strings.Index(strings.ToUpper(objectName), " ON "); idx != -1 {
+		objectName = objectName[idx+len(" ON "):]
+	}
+	parts := strings.Split(strings.TrimSpace(objectName), ".")
+	if len(parts) < 2 || parts[0] == "" {
+		return UNATTRIBUTED_EFFORT
+	}
+	return parts[0]
+}
+
+func getEffortObjectName(issue AssessmentIssue) string {
+	if issue.ObjectName == "" {
+		return UNATTRIBUTED_EFFORT
+	}
+	if issue.ObjectType == "" {
+		return issue.ObjectName
+	}
+	return fmt.Sprintf("%s %s", strings.ToUpper(issue.ObjectType), issue.ObjectName)
+}
+
+// sorted by the hours in descending order so that the costliest ones are on top
+func sortedEffortBreakdown(breakdown map[string]*MigrationEffortBreakdown) []MigrationEffortBreakdown {
+	result := make([]MigrationEffortBreakdown, 0, len(breakdown))
+	for _, b := range breakdown {
+		b.Hours = roundEffortHours(b.Hours)
+		result = append(result, *b)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Hours != result[j].Hours {
+			return result[i].Hours > result[j].Hours
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func roundEffortHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
+
+// MigrationEffortDerivation is a row of the explanation of how the effort estimate was derived
+type MigrationEffortDerivation struct {
+	IssueType     string
+	Impact        string
+	ObjectType    string
+	NumIssues     int
+	HoursPerIssue float64
+	Hours         float64
+}
+
+func buildMigrationEffortDerivation(issues []AssessmentIssue, model *MigrationEffortCostModel) []MigrationEffortDerivation {
+	type derivationKey struct {
+		issueType  string
+		impact     string
+		objectType string
+	}
+	derivations := make(map[derivationKey]*MigrationEffortDerivation)
+	for _, issue := range issues {
+		key := derivationKey{issueType: issue.Type, impact: issue.Impact, objectType: strings.ToUpper(issue.ObjectType)}
+		if _, ok := derivations[key]; !ok {
+			derivations[key] = &MigrationEffortDerivation{
+				IssueType:     key.issueType,
+				Impact:        key.impact,
+				ObjectType:    key.objectType,
+				HoursPerIssue: roundEffortHours(model.getHoursForIssue(issue)),
+			}
+		}
+		derivations[key].NumIssues++
+		derivations[key].Hours += model.getHoursForIssue(issue)
+	}
+
+	result := lo.Map(lo.Values(derivations), func(d *MigrationEffortDerivation, _ int) MigrationEffortDerivation {
+		d.Hours = roundEffortHours(d.Hours)
+		return *d
+	})
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Hours != result[j].Hours {
+			return result[i].Hours > result[j].Hours
+		}
+		return fmt.Sprint(result[i]) < fmt.Sprint(result[j])
+	})
+	return result
+}
@@ -0,0 +1,89 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/constants"
+)
+
+var testEffortIssues = []AssessmentIssue{
+	{Category: UNSUPPORTED_FEATURES_CATEGORY, Type: "GIN_INDEXES", Impact: constants.IMPACT_LEVEL_1, ObjectType: "INDEX", ObjectName: "idx1 ON public.t1"},
+	{Category: UNSUPPORTED_FEATURES_CATEGORY, Type: "INHERITANCE", Impact: constants.IMPACT_LEVEL_3, ObjectType: "TABLE", ObjectName: "sales.child"},
+	{Category: UNSUPPORTED_PLPGSQL_OBJECTS_CATEGORY, Type: "ADVISORY_LOCKS", Impact: constants.IMPACT_LEVEL_2, ObjectType: "FUNCTION", ObjectName: "public.fn1"},
+	{Category: UNSUPPORTED_PLPGSQL_OBJECTS_CATEGORY, Type: "ADVISORY_LOCKS", Impact: constants.IMPACT_LEVEL_2, ObjectType: "FUNCTION", ObjectName: "public.fn1"},
+	{Category: UNSUPPORTED_QUERY_CONSTRUCTS_CATEGORY, Type: "ADVISORY_LOCKS", Impact: constants.IMPACT_LEVEL_2},
+}
+
+func TestEstimateMigrationEffort(t *testing.T) {
+	effort := estimateMigrationEffort(POSTGRESQL, testEffortIssues, defaultMigrationEffortCostModel())
+	// 0.5 + 8 + 2*1.5*2 + 2
+	assert.Equal(t, 16.5, effort.TotalHours)
+	assert.Equal(t, []MigrationEffortBreakdown{
+		{Name: "sales", NumIssues: 1, Hours: 8},
+		{Name: "public", NumIssues: 3, Hours: 6.5},
+		{Name: UNATTRIBUTED_EFFORT, NumIssues: 1, Hours: 2},
+	}, effort.BySchema)
+	assert.Equal(t, MigrationEffortBreakdown{Name: "FUNCTION public.fn1", NumIssues: 2, Hours: 6}, effort.ByObject[1])
+	assert.Equal(t, 3, len(effort.ByCategory))
+
+	assert.Nil(t, estimateMigrationEffort(YUGABYTEDB, testEffortIssues, defaultMigrationEffortCostModel()))
+}
+
+func TestLoadMigrationEffortCostModel(t *testing.T) {
+	writeFile := func(contents string) string {
+		path := filepath.Join(t.TempDir(), "cost_model.yaml")
+		assert.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+		return path
+	}
+
+	model, err := LoadMigrationEffortCostModel(writeFile(`
+impact_hours:
+  level_3: 16
+issue_type_hours:
+  inheritance: 40
+object_type_factors:
+  function: 2
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, model.ImpactHours[constants.IMPACT_LEVEL_1])
+	assert.Equal(t, 16.0, model.ImpactHours[constants.IMPACT_LEVEL_3])
+	assert.Equal(t, 1.5, model.ObjectTypeFactors["TRIGGER"])
+
+	effort := estimateMigrationEffort(POSTGRESQL, testEffortIssues, model)
+	// 0.5 + 40 + 2*2*2 + 2
+	assert.Equal(t, 50.5, effort.TotalHours)
+
+	derivation := buildMigrationEffortDerivation(testEffortIssues, model)
+	assert.Equal(t, MigrationEffortDerivation{IssueType: "INHERITANCE", Impact: constants.IMPACT_LEVEL_3, ObjectType: "TABLE", NumIssues: 1, HoursPerIssue: 40, Hours: 40}, derivation[0])
+	assert.Equal(t, MigrationEffortDerivation{IssueType: "ADVISORY_LOCKS", Impact: constants.IMPACT_LEVEL_2, ObjectType: "FUNCTION", NumIssues: 2, HoursPerIssue: 4, Hours: 8}, derivation[1])
+
+	invalidModels := map[string]string{
+		"unknown key":    "impact:\n  LEVEL_1: 1\n",
+		"invalid impact": "impact_hours:\n  HIGH: 1\n",
+		"negative hours": "issue_type_hours:\n  INHERITANCE: -1\n",
+	}
+	for name, contents := range invalidModels {
+		_, err := LoadMigrationEffortCostModel(writeFile(contents))
+		assert.Error(t, err, name)
+	}
+}
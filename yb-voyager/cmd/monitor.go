@@ -0,0 +1,409 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/gosuri/uitable"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
+)
+
+const (
+	MONITOR_MAX_TABLES         = 20
+	MONITOR_MAX_LOG_ERRORS     = 10
+	MONITOR_LOG_TAIL_SIZE      = 1024 * 1024 // only the tail of the log files is scanned for errors on every refresh
+	MONITOR_CLEAR_SCREEN_ANSI  = "\033[H\033[2J"
+	MONITOR_DEFAULT_REFRESH_IN = 5
+)
+
+var monitorRefreshIntervalInSec int
+
+var monitorCmd = &cobra.Command{
+	Use:   "monitor",
+	Short: "Live dashboard of the migration running on the export directory",
+	Long: `Shows a live dashboard of the migration running on the export directory. It reads the metaDB, stats and log files in the export directory without modifying them.
+Shows per-role event rates, backlog of the queue segments, per-table event counts, disk usage of the export directory, recent errors from the logs and the cutover state.
+The dashboard is printed once if the output is not a terminal.`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if monitorRefreshIntervalInSec <= 0 {
+			utils.ErrExit("invalid value for --refresh-interval: %d, it should be greater than 0", monitorRefreshIntervalInSec)
+		}
+		// the persistent pre-run is skipped as it writes to the export dir (logs, metaDB) and starts callhome and the control plane
+		validateExportDirFlag()
+		if !metaDBIsCreated(exportDir) {
+			utils.ErrExit("Migration has not started yet. Run the commands in the order specified in the documentation: %s",
+				color.BlueString("https://docs.yugabyte.com/preview/yugabyte-voyager/migrate/"))
+		}
+		// logging is not initialized, discard the warnings instead of printing them over the dashboard
+		log.SetOutput(io.Discard)
+		var err error
+		metaDB, err = metadb.NewReadOnlyMetaDB(exportDir)
+		if err != nil {
+			utils.ErrExit("failed to open meta db: %s", err)
+		}
+		monitorMigration()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(monitorCmd)
+	registerExportDirFlag(monitorCmd)
+	monitorCmd.Flags().IntVar(&monitorRefreshIntervalInSec, "refresh-interval", MONITOR_DEFAULT_REFRESH_IN,
+		"interval in seconds at which the dashboard is refreshed")
+}
+
+type monitorSnapshot struct {
+	CollectedAt    time.Time
+	Msr            *metadb.MigrationStatusRecord
+	Cutover        cutoverStatuses
+	RunningCmds    map[string]int
+	ExportedEvents map[string]int64   // by exporter role
+	ExportRates    map[string]float64 // events/sec since the previous refresh, by exporter role
+	ImporterStats  []*monitorImporterStats
+	TableStats     []*metadb.TableExportedEventsStats
+	DiskUsage      []*monitorDiskUsage
+	FreeDiskSpace  uint64
+	LogErrors      []string
+}
+
+type monitorImporterStats struct {
+	ImporterRole string
+	ExporterRole string // whose queue segments are imported
+	metadb.QueueSegmentsStats
+	ImportRate float64 // events/sec since the previous refresh, -1 on the first refresh
+}
+
+type monitorDiskUsage struct {
+	Path  string
+	Bytes int64
+}
+
+func monitorMigration() {
+	interactive := term.IsTerminal(int(os.Stdout.Fd()))
+	var prev *monitorSnapshot
+	for {
+		snapshot := collectMonitorSnapshot(prev)
+		output := renderMonitorSnapshot(snapshot)
+		if !interactive {
+			fmt.Print(output)
+			return
+		}
+		fmt.Print(MONITOR_CLEAR_SCREEN_ANSI + output)
+		fmt.Printf("\nRefreshing every %ds. Press Ctrl+C to exit.\n", monitorRefreshIntervalInSec)
+		prev = snapshot
+		time.Sleep(time.Duration(monitorRefreshIntervalInSec) * time.Second)
+	}
+}
+
+func collectMonitorSnapshot(prev *monitorSnapshot) *monitorSnapshot {
+	msr, err := metaDB.GetMigrationStatusRecord()
+	if err != nil {
+		utils.ErrExit("get migration status record: %v", err)
+	}
+	s := &monitorSnapshot{
+		CollectedAt: time.Now(),
+		Msr:         msr,
+		Cutover: cutoverStatuses{
+			toTarget:        getCutoverStatus(),
+			toSourceReplica: getCutoverToSourceReplicaStatus(),
+			toSource:        getCutoverToSourceStatus(),
+		},
+		RunningCmds: getRunningVoyagerCommands(exportDir),
+		ExportRates: make(map[string]float64),
+	}
+
+	s.ExportedEvents, err = metaDB.GetTotalExportedEventsPerExporterRole()
+	if err != nil {
+		utils.ErrExit("get total exported events: %v", err)
+	}
+	for _, roles := range getMonitorImporterRoles(msr) {
+		stats, err := metaDB.GetQueueSegmentsStats(roles[0], roles[1])
+		if err != nil {
+			utils.ErrExit("get queue segments stats for %s: %v", roles[0], err)
+		}
+		s.ImporterStats = append(s.ImporterStats, &monitorImporterStats{
+			ImporterRole: roles[0], ExporterRole: roles[1], QueueSegmentsStats: *stats, ImportRate: -1})
+	}
+	if prev != nil {
+		calculateMonitorRates(prev, s)
+	}
+
+	s.TableStats, err = metaDB.GetExportedEventsStatsPerTable()
+	if err != nil {
+		utils.ErrExit("get exported events stats per table: %v", err)
+	}
+	slices.SortStableFunc(s.TableStats, func(a, b *metadb.TableExportedEventsStats) int {
+		return cmp.Compare(b.TotalEvents, a.TotalEvents)
+	})
+
+	s.DiskUsage, err = getExportDirDiskUsage(exportDir)
+	if err != nil {
+		log.Warnf("get disk usage of export dir: %v", err)
+	}
+	s.FreeDiskSpace, err = getFreeDiskSpace(exportDir)
+	if err != nil {
+		log.Warnf("get free disk space of export dir: %v", err)
+	}
+	s.LogErrors = getRecentErrorsFromLogs(filepath.Join(exportDir, "logs"), MONITOR_MAX_LOG_ERRORS)
+	return s
+}
+
+// getMonitorImporterRoles returns the (importer role, exporter role) pairs of the queue segments imported in the migration
+func getMonitorImporterRoles(msr *metadb.MigrationStatusRecord) [][2]string {
+	if !changeStreamingIsEnabled(msr.ExportType) {
+		return nil
+	}
+	result := [][2]string{{TARGET_DB_IMPORTER_ROLE, SOURCE_DB_EXPORTER_ROLE}}
+	if msr.FallForwardEnabled {
+		result = append(result, [2]string{SOURCE_REPLICA_DB_IMPORTER_ROLE, TARGET_DB_EXPORTER_FF_ROLE})
+	}
+	if msr.FallbackEnabled {
+		result = append(result, [2]string{SOURCE_DB_IMPORTER_ROLE, TARGET_DB_EXPORTER_FB_ROLE})
+	}
+	return result
+}
+
+func calculateMonitorRates(prev *monitorSnapshot, cur *monitorSnapshot) {
+	elapsed := cur.CollectedAt.Sub(prev.CollectedAt).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	for role, count := range cur.ExportedEvents {
+		if prevCount, ok := prev.ExportedEvents[role]; ok {
+			cur.ExportRates[role] = float64(max(count-prevCount, 0)) / elapsed
+		}
+	}
+	for _, stats := range cur.ImporterStats {
+		prevStats, found := lo.Find(prev.ImporterStats, func(s *monitorImporterStats) bool {
+			return s.ImporterRole == stats.ImporterRole
+		})
+		if found {
+			stats.ImportRate = float64(max(stats.ImportedEvents-prevStats.ImportedEvents, 0)) / elapsed
+		}
+	}
+}
+
+// getExportDirDiskUsage returns the size of each of the top level files/dirs in the export dir, largest first
+func getExportDirDiskUsage(dir string) ([]*monitorDiskUsage, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read dir %q: %w", dir, err)
+	}
+	var result []*monitorDiskUsage
+	for _, entry := range entries {
+		usage := &monitorDiskUsage{Path: entry.Name()}
+		err := filepath.WalkDir(filepath.Join(dir, entry.Name()), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				// files can be deleted (e.g. archived segments) while walking
+				return nil
+			}
+			if info, err := d.Info(); err == nil && !d.IsDir() {
+				usage.Bytes += info.Size()
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("walk %q: %w", entry.Name(), err)
+		}
+		result = append(result, usage)
+	}
+	slices.SortStableFunc(result, func(a, b *monitorDiskUsage) int { return cmp.Compare(b.Bytes, a.Bytes) })
+	return result, nil
+}
+
+// getRecentErrorsFromLogs returns the last n ERROR/FATAL/PANIC lines across the log files of the voyager commands and debezium
+func getRecentErrorsFromLogs(logsDir string, n int) []string {
+	logFiles, err := filepath.Glob(filepath.Join(logsDir, "*.log"))
+	if err != nil {
+		log.Warnf("find log files in %q: %v", logsDir, err)
+		return nil
+	}
+	var result []string
+	for _, logFile := range logFiles {
+		lines, err := readLogFileTail(logFile, MONITOR_LOG_TAIL_SIZE)
+		if err != nil {
+			log.Warnf("read log file %q: %v", logFile, err)
+			continue
+		}
+		for _, line := range lines {
+			if strings.Contains(line, " ERROR ") || strings.Contains(line, " FATAL ") || strings.Contains(line, " PANIC ") {
+				result = append(result, fmt.Sprintf("%s: %s", filepath.Base(logFile), line))
+			}
+		}
+	}
+	// lines start with the timestamp, so sort them by time ignoring the file name
+	slices.SortStableFunc(result, func(a, b string) int {
+		_, lineA, _ := strings.Cut(a, ": ")
+		_, lineB, _ := strings.Cut(b, ": ")
+		return cmp.Compare(lineA, lineB)
+	})
+	return result[max(len(result)-n, 0):]
+}
+
+func readLogFileTail(path string, size int64) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	offset := max(info.Size()-size, 0)
+	buf := make([]byte, info.Size()-offset)
+	_, err = f.ReadAt(buf, offset)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if offset > 0 {
+		// skip the partial first line
+		_, buf, _ = bytes.Cut(buf, []byte("\n"))
+	}
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(buf))
+	scanner.Buffer(make([]byte, 0, 64*1024), int(size))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+func renderMonitorSnapshot(s *monitorSnapshot) string {
+	var sb strings.Builder
+	heading := color.New(color.Bold).SprintFunc()
+	section := func(title string) {
+		fmt.Fprintf(&sb, "\n%s\n", heading(title))
+	}
+
+	fmt.Fprintf(&sb, "%s\n", heading(fmt.Sprintf("yb-voyager monitor - %s", exportDir)))
+	fmt.Fprintf(&sb, "Migration: %s (%s)    Updated at: %s\n", s.Msr.MigrationUUID, getMigrationTypeDescription(s.Msr),
+		s.CollectedAt.Format(time.DateTime))
+	if len(s.RunningCmds) == 0 {
+		fmt.Fprintf(&sb, "Running commands: none\n")
+	} else {
+		cmdNames := lo.Keys(s.RunningCmds)
+		slices.Sort(cmdNames)
+		fmt.Fprintf(&sb, "Running commands: %s\n", strings.Join(lo.Map(cmdNames, func(cmdName string, _ int) string {
+			return fmt.Sprintf("%s (PID %d)", cmdName, s.RunningCmds[cmdName])
+		}), ", "))
+	}
+
+	section("CUTOVER")
+	cutoverTable := uitable.New()
+	if changeStreamingIsEnabled(s.Msr.ExportType) {
+		cutoverTable.AddRow("cutover to target:", colorCutoverStatus(s.Cutover.toTarget))
+		if s.Msr.FallForwardEnabled {
+			cutoverTable.AddRow("cutover to source-replica:", colorCutoverStatus(s.Cutover.toSourceReplica))
+		}
+		if s.Msr.FallbackEnabled {
+			cutoverTable.AddRow("cutover to source:", colorCutoverStatus(s.Cutover.toSource))
+		}
+		fmt.Fprintln(&sb, cutoverTable)
+	} else {
+		fmt.Fprintln(&sb, "not applicable for offline migration")
+	}
+
+	formatRate := func(rate float64) string {
+		if rate < 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.1f", rate)
+	}
+	section("EVENTS")
+	eventsTable := uitable.New()
+	addHeader(eventsTable, "ROLE", "EVENTS", "EVENTS/SEC", "PENDING SEGMENTS", "PENDING EVENTS")
+	exporterRoles := lo.Keys(s.ExportedEvents)
+	slices.Sort(exporterRoles)
+	for _, role := range exporterRoles {
+		rate, found := s.ExportRates[role]
+		eventsTable.AddRow(role, s.ExportedEvents[role], formatRate(lo.Ternary(found, rate, -1)), "", "")
+	}
+	for _, stats := range s.ImporterStats {
+		eventsTable.AddRow(stats.ImporterRole, stats.ImportedEvents, formatRate(stats.ImportRate),
+			stats.PendingSegments, stats.PendingEvents)
+	}
+	if len(exporterRoles) == 0 && len(s.ImporterStats) == 0 {
+		fmt.Fprintln(&sb, "no events exported yet")
+	} else {
+		fmt.Fprintln(&sb, eventsTable)
+	}
+
+	section("TABLES")
+	if len(s.TableStats) == 0 {
+		fmt.Fprintln(&sb, "no events exported yet")
+	} else {
+		tablesTable := uitable.New()
+		addHeader(tablesTable, "TABLE", "EXPORTER ROLE", "TOTAL", "INSERTS", "UPDATES", "DELETES")
+		for _, stats := range s.TableStats[:min(len(s.TableStats), MONITOR_MAX_TABLES)] {
+			tableName := lo.Ternary(stats.SchemaName == "", stats.TableName, stats.SchemaName+"."+stats.TableName)
+			tablesTable.AddRow(tableName, stats.ExporterRole, stats.TotalEvents, stats.NumInserts, stats.NumUpdates, stats.NumDeletes)
+		}
+		fmt.Fprintln(&sb, tablesTable)
+		if len(s.TableStats) > MONITOR_MAX_TABLES {
+			fmt.Fprintf(&sb, "... and %d more\n", len(s.TableStats)-MONITOR_MAX_TABLES)
+		}
+	}
+
+	section("DISK USAGE")
+	diskTable := uitable.New()
+	total := lo.SumBy(s.DiskUsage, func(u *monitorDiskUsage) int64 { return u.Bytes })
+	diskTable.AddRow("export dir:", utils.HumanReadableByteCount(total))
+	for _, usage := range s.DiskUsage {
+		diskTable.AddRow("  "+usage.Path, utils.HumanReadableByteCount(usage.Bytes))
+	}
+	diskTable.AddRow("free space:", utils.HumanReadableByteCount(int64(s.FreeDiskSpace)))
+	fmt.Fprintln(&sb, diskTable)
+
+	section("RECENT ERRORS")
+	if len(s.LogErrors) == 0 {
+		fmt.Fprintln(&sb, "none")
+	}
+	for _, line := range s.LogErrors {
+		fmt.Fprintln(&sb, color.RedString(line))
+	}
+	return sb.String()
+}
+
+func colorCutoverStatus(status string) string {
+	switch status {
+	case COMPLETED:
+		return color.GreenString(status)
+	case INITIATED:
+		return color.YellowString(status)
+	default:
+		return status
+	}
+}
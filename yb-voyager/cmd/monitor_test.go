@@ -0,0 +1,118 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
+)
+
+func TestMonitorRates(t *testing.T) {
+	now := time.Now()
+	prev := &monitorSnapshot{
+		CollectedAt:    now,
+		ExportedEvents: map[string]int64{SOURCE_DB_EXPORTER_ROLE: 100},
+		ImporterStats: []*monitorImporterStats{{ImporterRole: TARGET_DB_IMPORTER_ROLE,
+			QueueSegmentsStats: metadb.QueueSegmentsStats{ImportedEvents: 50}}},
+	}
+	cur := &monitorSnapshot{
+		CollectedAt:    now.Add(10 * time.Second),
+		ExportedEvents: map[string]int64{SOURCE_DB_EXPORTER_ROLE: 300, TARGET_DB_EXPORTER_FF_ROLE: 20},
+		ExportRates:    map[string]float64{},
+		ImporterStats: []*monitorImporterStats{{ImporterRole: TARGET_DB_IMPORTER_ROLE, ImportRate: -1,
+			QueueSegmentsStats: metadb.QueueSegmentsStats{ImportedEvents: 150}}},
+	}
+	calculateMonitorRates(prev, cur)
+	assert.Equal(t, map[string]float64{SOURCE_DB_EXPORTER_ROLE: 20}, cur.ExportRates)
+	assert.Equal(t, float64(10), cur.ImporterStats[0].ImportRate)
+}
+
+func TestMonitorRecentErrorsFromLogs(t *testing.T) {
+	logsDir := t.TempDir()
+	writeLog := func(name string, lines ...string) {
+		assert.NoError(t, os.WriteFile(filepath.Join(logsDir, name), []byte(strings.Join(lines, "\n")+"\n"), 0644))
+	}
+	writeLog("yb-voyager-export-data.log",
+		"2024-01-01 10:00:00.000000 INFO exportData.go:10 started",
+		"2024-01-01 10:00:05.000000 ERROR exportData.go:20 first error",
+		"2024-01-01 10:00:09.000000 ERROR exportData.go:30 third error")
+	writeLog("yb-voyager-import-data.log",
+		"2024-01-01 10:00:07.000000 ERROR importData.go:20 second error")
+
+	errors := getRecentErrorsFromLogs(logsDir, 2)
+	assert.Equal(t, []string{
+		"yb-voyager-import-data.log: 2024-01-01 10:00:07.000000 ERROR importData.go:20 second error",
+		"yb-voyager-export-data.log: 2024-01-01 10:00:09.000000 ERROR exportData.go:30 third error",
+	}, errors)
+
+	// only the tail is read
+	var lines []string
+	for i := 0; i < 100; i++ {
+		lines = append(lines, fmt.Sprintf("2024-01-01 11:00:%02d.000000 ERROR x.go:1 error %d", i%60, i))
+	}
+	writeLog("big.log", lines...)
+	tail, err := readLogFileTail(filepath.Join(logsDir, "big.log"), 200)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tail)
+	assert.Less(t, len(tail), 5)
+	assert.Equal(t, lines[len(lines)-1], tail[len(tail)-1])
+}
+
+func TestMonitorExportDirDiskUsage(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "data", "queue"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "data", "queue", "segment.0.ndjson"), make([]byte, 3000), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "data", "t1_data.sql"), make([]byte, 1000), 0644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "logs"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "logs", "a.log"), make([]byte, 10), 0644))
+
+	usage, err := getExportDirDiskUsage(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []*monitorDiskUsage{{Path: "data", Bytes: 4000}, {Path: "logs", Bytes: 10}}, usage)
+}
+
+func TestRenderMonitorSnapshot(t *testing.T) {
+	exportDir = "/tmp/export-dir"
+	s := &monitorSnapshot{
+		CollectedAt:    time.Now(),
+		Msr:            &metadb.MigrationStatusRecord{ExportType: SNAPSHOT_AND_CHANGES, FallForwardEnabled: true},
+		Cutover:        cutoverStatuses{INITIATED, NOT_INITIATED, NOT_INITIATED},
+		RunningCmds:    map[string]int{"export data from source": 42},
+		ExportedEvents: map[string]int64{SOURCE_DB_EXPORTER_ROLE: 300},
+		ExportRates:    map[string]float64{},
+		ImporterStats: []*monitorImporterStats{{ImporterRole: TARGET_DB_IMPORTER_ROLE, ImportRate: 12.5,
+			QueueSegmentsStats: metadb.QueueSegmentsStats{ImportedEvents: 150, PendingSegments: 2, PendingEvents: 150}}},
+		TableStats: []*metadb.TableExportedEventsStats{{ExporterRole: SOURCE_DB_EXPORTER_ROLE, SchemaName: "public", TableName: "orders"}},
+		LogErrors:  []string{"a.log: boom"},
+	}
+	output := renderMonitorSnapshot(s)
+	assert.Contains(t, output, "export data from source (PID 42)")
+	assert.Contains(t, output, "cutover to source-replica:")
+	assert.NotContains(t, output, "cutover to source:")
+	assert.Contains(t, output, "public.orders")
+	assert.Contains(t, output, "12.5")
+	assert.Contains(t, output, "a.log: boom")
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/vbauerster/mpb/v8"
 
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/dbzm"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metrics"
 	pbreporter "github.com/yugabyte/yb-voyager/yb-voyager/src/reporter/pb"
 )
 
@@ -68,12 +69,14 @@ func (pt *ProgressTracker) UpdateProgress(status *dbzm.ExportStatus) {
 		pt.pb.SetTotalRowCount(pt.totalRowCount[pt.inProgressQualifiedTableName], false)
 	}
 	pt.pb.SetExportedRowCount(exportedRowCount)
+	metrics.ExportedRows.Set(float64(exportedRowCount), pt.inProgressQualifiedTableName)
 }
 
 func (pt *ProgressTracker) Done(status *dbzm.ExportStatus) {
 	if pt.pb != nil {
 		exportedRowCount := status.GetTableExportedRowCount(pt.inProgressTableSno)
 		pt.pb.SetTotalRowCount(exportedRowCount, true /* Mark complete */)
+		metrics.ExportedRows.Set(float64(exportedRowCount), pt.inProgressQualifiedTableName)
 		pt.pb = nil
 	}
 	pt.mpbProgress.Wait()
@@ -28,7 +28,6 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
-	"github.com/spf13/viper"
 	"github.com/tebeka/atexit"
 	"golang.org/x/exp/slices"
 
@@ -36,8 +35,10 @@ import (
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/config"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/cp"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/cp/noopcp"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/cp/webhook"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/cp/yugabyted"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/lockfile"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/secret"
 	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
 )
 
@@ -142,6 +143,7 @@ Refer to docs (https://docs.yugabyte.com/preview/migrate/) for more details like
 				go startPprofServer()
 			}
 			setControlPlane(getControlPlaneType())
+			startMetricsServerIfRequired()
 		}
 	},
 
@@ -206,6 +208,7 @@ var exportDirInitialisedCheckNeededList = []string{
 	"yb-voyager initiate cutover to source",
 	"yb-voyager initiate cutover to source-replica",
 	"yb-voyager initiate cutover to target",
+	"yb-voyager migration status",
 }
 
 var noLockNeededList = []string{
@@ -224,6 +227,13 @@ var noLockNeededList = []string{
 	"yb-voyager initiate",
 	"yb-voyager end",
 	"yb-voyager archive",
+	"yb-voyager compare",
+	"yb-voyager compare assessment-reports",
+	"yb-voyager migration",
+	"yb-voyager migration status",
+	"yb-voyager monitor",
+	"yb-voyager collect",
+	"yb-voyager collect diagnostics",
 }
 
 var noPersistentPreRunNeededList = []string{
@@ -240,6 +250,12 @@ var noPersistentPreRunNeededList = []string{
 	"yb-voyager cutover",
 	"yb-voyager archive",
 	"yb-voyager end",
+	"yb-voyager compare",
+	"yb-voyager compare assessment-reports",
+	"yb-voyager migration",
+	"yb-voyager monitor",
+	"yb-voyager collect",
+	"yb-voyager collect diagnostics",
 }
 
 func shouldLock(cmd *cobra.Command) bool {
@@ -263,6 +279,8 @@ func init() {
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	registerConfigFileFlags(rootCmd)
+	registerSecretSourceFlag(rootCmd)
 
 	callhome.ReadEnvSendDiagnostics()
 }
@@ -290,30 +308,6 @@ func registerExportDirFlag(cmd *cobra.Command) {
 		"export directory is the workspace used to keep the exported schema, data, state, and logs")
 }
 
-// initConfig reads in config file and ENV variables if set.
-func initConfig() {
-	if cfgFile != "" {
-		// Use config file from the flag.
-		viper.SetConfigFile(cfgFile)
-	} else {
-		// Find home directory.
-		home, err := os.UserHomeDir()
-		cobra.CheckErr(err)
-
-		// Search config in home directory with name ".yb-voyager" (without extension).
-		viper.AddConfigPath(home)
-		viper.SetConfigType("yaml")
-		viper.SetConfigName(".yb-voyager")
-	}
-
-	viper.AutomaticEnv() // read in environment variables that match
-
-	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil {
-		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
-	}
-}
-
 func validateExportDirFlag() {
 	if exportDir == "" {
 		utils.ErrExit(`ERROR: required flag "export-dir" not set`)
@@ -369,12 +363,29 @@ func setControlPlane(cpType string) {
 		if ybdConnString == "" {
 			utils.ErrExit("'YUGABYTED_DB_CONN_STRING' environment variable needs to be set if 'CONTROL_PLANE_TYPE' is 'yugabyted'.")
 		}
-		controlPlane = yugabyted.New(exportDir)
+		// the password in the secret source, if any, overrides the one in the connection string
+		ybdPassword, err := getPasswordFromSecretSource(secret.YUGABYTED_DB_PASSWORD)
+		if err != nil {
+			utils.ErrExit("getting yugabyted db password: %v", err)
+		}
+		controlPlane = yugabyted.New(exportDir, ybdPassword)
 		log.Infof("Migration UUID %s", migrationUUID)
-		err := controlPlane.Init()
+		err = controlPlane.Init()
 		if err != nil {
 			utils.ErrExit("ERROR: Failed to initialize the target DB for visualization. %s", err)
 		}
+	case WEBHOOK:
+		webhookURL := os.Getenv("WEBHOOK_URL")
+		if webhookURL == "" {
+			utils.ErrExit("'WEBHOOK_URL' environment variable needs to be set if 'CONTROL_PLANE_TYPE' is 'webhook'.")
+		}
+		// optional, the events are signed with HMAC-SHA256 if set
+		webhookSecret := os.Getenv("WEBHOOK_SECRET")
+		controlPlane = webhook.New(exportDir, webhookURL, webhookSecret)
+		err := controlPlane.Init()
+		if err != nil {
+			utils.ErrExit("ERROR: Failed to initialize the webhook control plane. %s", err)
+		}
 	}
 }
 
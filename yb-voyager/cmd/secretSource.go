@@ -0,0 +1,72 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/secret"
+)
+
+var (
+	secretSourceSpec string
+	// created on first use from secretSourceSpec
+	secretProvider secret.Provider
+)
+
+func registerSecretSourceFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&secretSourceSpec, "secret-source", "",
+		"source of the database and control plane passwords (refer docs/secret_sources.md). Accepted values: "+
+			"file:<path> (password file with permissions 600), exec:<command> (helper command invoked as '<command> get <name>'), "+
+			"vault:<mount>/<path> (secret in the Vault KV engine at VAULT_ADDR, read using VAULT_TOKEN)")
+}
+
+/*
+getPasswordFromSecretSource returns the secret from the --secret-source, if any.
+Returns "" if the secret source is not set or doesn't have the secret, so that the callers can fall back to prompting.
+*/
+func getPasswordFromSecretSource(secretName string) (string, error) {
+	if secretSourceSpec == "" {
+		return "", nil
+	}
+	if secretProvider == nil {
+		var err error
+		secretProvider, err = secret.NewProvider(secretSourceSpec)
+		if err != nil {
+			return "", err
+		}
+	}
+	password, err := secretProvider.GetSecret(secretName)
+	if errors.Is(err, secret.ErrSecretNotFound) {
+		log.Infof("%q not found in %s", secretName, secretProvider)
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get %q from secret source: %w", secretName, err)
+	}
+	log.Infof("using %q from %s", secretName, secretProvider)
+	return password, nil
+}
+
+// secret name of the password env vars e.g. TARGET_DB_PASSWORD -> target-db-password, same as the name of the password flag
+func secretNameForEnvVar(envVar string) string {
+	return strings.ReplaceAll(strings.ToLower(envVar), "_", "-")
+}
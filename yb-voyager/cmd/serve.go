@@ -0,0 +1,658 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/secret"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
+)
+
+const (
+	SERVE_AUTH_TOKEN_ENV_VAR      = "YB_VOYAGER_SERVE_AUTH_TOKEN"
+	SERVE_DEFAULT_LISTEN_ADDRESS  = "localhost:8090"
+	SERVE_LOGS_FOLLOW_INTERVAL    = 500 * time.Millisecond
+	SERVE_MAX_REQUEST_BODY_LENGTH = 1 << 20
+	SERVE_MAX_FINISHED_JOBS       = 100 // finished jobs remembered by the server, their output files are retained
+
+	JOB_RUNNING   = "RUNNING"
+	JOB_SUCCEEDED = "SUCCEEDED"
+	JOB_FAILED    = "FAILED"
+	JOB_STOPPED   = "STOPPED"
+)
+
+var (
+	serveListenAddress string
+	serveTLSCertFile   string
+	serveTLSKeyFile    string
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Starts an HTTP server to run and monitor the phases of the migration",
+	Long: `Starts an HTTP server exposing authenticated endpoints to start, stop and inspect the phases of the migration (assess, export/import schema, export/import data, cutover and end migration) of the export directory.
+The phases are run as yb-voyager subprocesses, whose output can be streamed from the server. Refer docs/serve.md for the endpoints.
+The requests must have the 'Authorization: Bearer <token>' header, where the token is set in the ` + SERVE_AUTH_TOKEN_ENV_VAR + ` environment variable or as 'serve-auth-token' in the --secret-source.`,
+
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if (serveTLSCertFile == "") != (serveTLSKeyFile == "") {
+			utils.ErrExit("both --tls-cert-file and --tls-key-file need to be set to serve over HTTPS")
+		}
+	},
+
+	Run: func(cmd *cobra.Command, args []string) {
+		authToken, err := getServeAuthToken()
+		if err != nil {
+			utils.ErrExit("getting the auth token: %v", err)
+		}
+		if authToken == "" {
+			utils.ErrExit("auth token of the server is not set: set the %s environment variable or 'serve-auth-token' in the --secret-source",
+				SERVE_AUTH_TOKEN_ENV_VAR)
+		}
+		executable, err := os.Executable()
+		if err != nil {
+			utils.ErrExit("finding the yb-voyager executable: %v", err)
+		}
+		server := newVoyagerServer(exportDir, authToken, executable, getServeInheritedArgs(cmd))
+		err = server.listenAndServe(serveListenAddress, serveTLSCertFile, serveTLSKeyFile)
+		if err != nil {
+			utils.ErrExit("serve: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	registerExportDirFlag(serveCmd)
+	serveCmd.Flags().StringVar(&serveListenAddress, "listen-address", SERVE_DEFAULT_LISTEN_ADDRESS,
+		"address (host:port) on which the server listens")
+	serveCmd.Flags().StringVar(&serveTLSCertFile, "tls-cert-file", "",
+		"path of the TLS certificate file to serve over HTTPS")
+	serveCmd.Flags().StringVar(&serveTLSKeyFile, "tls-key-file", "",
+		"path of the TLS private key file to serve over HTTPS")
+}
+
+func getServeAuthToken() (string, error) {
+	if os.Getenv(SERVE_AUTH_TOKEN_ENV_VAR) != "" {
+		return os.Getenv(SERVE_AUTH_TOKEN_ENV_VAR), nil
+	}
+	return getPasswordFromSecretSource(secret.SERVE_AUTH_TOKEN)
+}
+
+// flags set by the server for all the commands, which can't be set in the requests
+var serveReservedFlags = []string{"export-dir", "yes", "help", "dry-run", "secret-source", "config-file", "log-level"}
+
+// args of the server passed on to the commands run by it
+func getServeInheritedArgs(cmd *cobra.Command) map[string]string {
+	args := make(map[string]string)
+	for _, flagName := range []string{"secret-source", "config-file", "log-level"} {
+		flag := cmd.Flag(flagName)
+		if flag != nil && flag.Changed {
+			args[flagName] = flag.Value.String()
+		}
+	}
+	return args
+}
+
+// commands which can be run with the server, a phase is identified by its command ID e.g. export-data-from-source
+func getServePhaseCommands() []*cobra.Command {
+	return []*cobra.Command{
+		assessMigrationCmd,
+		exportSchemaCmd,
+		analyzeSchemaCmd,
+		importSchemaCmd,
+		exportDataFromSrcCmd,
+		importDataToTargetCmd,
+		exportDataFromTargetCmd,
+		importDataToSourceReplicaCmd,
+		importDataToSourceCmd,
+		cutoverToTargetCmd,
+		cutoverToSourceReplicaCmd,
+		cutoverToSourceCmd,
+		archiveChangesCmd,
+		endMigrationCmd,
+	}
+}
+
+func findServePhaseCommand(phase string) *cobra.Command {
+	cmd, _ := lo.Find(getServePhaseCommands(), func(c *cobra.Command) bool {
+		return GetCommandID(c) == phase
+	})
+	return cmd
+}
+
+// getServePhaseCommandNames returns the names of the commands, as in their lock files, which perform the phase.
+// The phase can also be run from the command line with the same command or its alias e.g. export data for export-data-from-source.
+func getServePhaseCommandNames(phase string) []string {
+	cmdName := strings.ReplaceAll(phase, "-", " ")
+	switch phase {
+	case "export-data-from-source":
+		return []string{"export data", cmdName}
+	case "import-data-to-target":
+		return []string{"import data", cmdName}
+	default:
+		return []string{cmdName}
+	}
+}
+
+//=========================================================================================
+
+type voyagerServer struct {
+	exportDir     string
+	authToken     string
+	executable    string            // yb-voyager binary used to run the phases
+	inheritedArgs map[string]string // flags of the server passed to every command
+
+	mu   sync.Mutex
+	jobs map[string]*serveJob
+}
+
+// serveJob is a command run by the server. The fields other than the channel are guarded by voyagerServer.mu.
+type serveJob struct {
+	ID            string     `json:"id"`
+	Phase         string     `json:"phase"`
+	Command       string     `json:"command"` // without the passwords, which are passed through the environment
+	PID           int        `json:"pid"`
+	State         string     `json:"state"`
+	ExitCode      *int       `json:"exit_code"`
+	StartedAt     time.Time  `json:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at"`
+	OutputFile    string     `json:"output_file"`
+	stopRequested bool
+	done          chan struct{} // closed once the command exits
+}
+
+type serveStartRequest struct {
+	// flags of the command other than export-dir, lists can be given as comma separated strings or arrays
+	Flags map[string]any `json:"flags"`
+}
+
+type serveErrorResponse struct {
+	Error string `json:"error"`
+}
+
+type servePhase struct {
+	Phase        string `json:"phase"`
+	Command      string `json:"command"`
+	RunningJobID string `json:"running_job_id,omitempty"`
+}
+
+type serveMigrationStatus struct {
+	ExportDir        string                 `json:"export_dir"`
+	MigrationStarted bool                   `json:"migration_started"`
+	MigrationUUID    string                 `json:"migration_uuid,omitempty"`
+	MigrationType    string                 `json:"migration_type,omitempty"`
+	Phases           []*serveMigrationPhase `json:"phases"`
+	RunningCommands  map[string]int         `json:"running_commands"` // voyager commands running on the export dir, with their PIDs
+	NextStep         string                 `json:"next_step,omitempty"`
+	Jobs             []*serveJob            `json:"jobs"`
+}
+
+type serveMigrationPhase struct {
+	Phase       string     `json:"phase"`
+	Status      string     `json:"status"`
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	RunningPIDs []int      `json:"running_pids"`
+}
+
+func newVoyagerServer(exportDir string, authToken string, executable string, inheritedArgs map[string]string) *voyagerServer {
+	return &voyagerServer{
+		exportDir:     exportDir,
+		authToken:     authToken,
+		executable:    executable,
+		inheritedArgs: inheritedArgs,
+		jobs:          make(map[string]*serveJob),
+	}
+}
+
+func (s *voyagerServer) listenAndServe(addr string, tlsCertFile string, tlsKeyFile string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen on %q: %w", addr, err)
+	}
+	server := &http.Server{
+		Handler:           s.handler(),
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	if tlsCertFile != "" {
+		utils.PrintAndLog("Serving the migration of %q on https://%s", s.exportDir, listener.Addr())
+		return server.ServeTLS(listener, tlsCertFile, tlsKeyFile)
+	}
+	utils.PrintAndLog("Serving the migration of %q on http://%s", s.exportDir, listener.Addr())
+	return server.Serve(listener)
+}
+
+func (s *voyagerServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/status", s.handleStatus)
+	mux.HandleFunc("GET /api/v1/phases", s.handleListPhases)
+	mux.HandleFunc("POST /api/v1/phases/{phase}/start", s.handleStartPhase)
+	mux.HandleFunc("POST /api/v1/phases/{phase}/stop", s.handleStopPhase)
+	mux.HandleFunc("GET /api/v1/jobs", s.handleListJobs)
+	mux.HandleFunc("GET /api/v1/jobs/{id}", s.handleGetJob)
+	mux.HandleFunc("GET /api/v1/jobs/{id}/logs", s.handleJobLogs)
+	return s.authenticate(mux)
+}
+
+func (s *voyagerServer) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(s.authToken)) != 1 {
+			log.Warnf("serve: unauthenticated request %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeServeError(w, http.StatusUnauthorized, "missing or invalid auth token")
+			return
+		}
+		log.Infof("serve: %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *voyagerServer) handleListPhases(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	phases := lo.Map(getServePhaseCommands(), func(c *cobra.Command, _ int) *servePhase {
+		phase := &servePhase{Phase: GetCommandID(c), Command: c.CommandPath()}
+		if job := s.getRunningJob(phase.Phase); job != nil {
+			phase.RunningJobID = job.ID
+		}
+		return phase
+	})
+	writeServeResponse(w, http.StatusOK, phases)
+}
+
+func (s *voyagerServer) handleStartPhase(w http.ResponseWriter, r *http.Request) {
+	phase := r.PathValue("phase")
+	phaseCmd := findServePhaseCommand(phase)
+	if phaseCmd == nil {
+		writeServeError(w, http.StatusNotFound, fmt.Sprintf("unknown phase %q", phase))
+		return
+	}
+	var req serveStartRequest
+	body, err := io.ReadAll(io.LimitReader(r.Body, SERVE_MAX_REQUEST_BODY_LENGTH))
+	if err == nil && len(body) > 0 {
+		err = json.Unmarshal(body, &req)
+	}
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	args, env, err := s.buildPhaseCommand(phaseCmd, req.Flags)
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if job := s.getRunningJob(phase); job != nil {
+		writeServeError(w, http.StatusConflict, fmt.Sprintf("phase %q is already running as job %s", phase, job.ID))
+		return
+	}
+	// the phase could have been started from the command line
+	runningCmds := getRunningVoyagerCommands(s.exportDir)
+	for _, cmdName := range getServePhaseCommandNames(phase) {
+		if pid, ok := runningCmds[cmdName]; ok {
+			writeServeError(w, http.StatusConflict, fmt.Sprintf("phase %q is already running: yb-voyager %s (PID %d)", phase, cmdName, pid))
+			return
+		}
+	}
+	job, err := s.startJob(phase, args, env)
+	if err != nil {
+		writeServeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeServeResponse(w, http.StatusCreated, *job)
+}
+
+func (s *voyagerServer) handleStopPhase(w http.ResponseWriter, r *http.Request) {
+	phase := r.PathValue("phase")
+	if findServePhaseCommand(phase) == nil {
+		writeServeError(w, http.StatusNotFound, fmt.Sprintf("unknown phase %q", phase))
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job := s.getRunningJob(phase)
+	if job == nil {
+		writeServeError(w, http.StatusNotFound, fmt.Sprintf("phase %q is not running", phase))
+		return
+	}
+	// voyager commands shut down gracefully on SIGTERM, the job is marked stopped once the command exits
+	err := signalProcess(job.PID, syscall.SIGTERM)
+	if err != nil {
+		writeServeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	job.stopRequested = true
+	writeServeResponse(w, http.StatusAccepted, *job)
+}
+
+func (s *voyagerServer) handleListJobs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeServeResponse(w, http.StatusOK, s.getJobsSnapshot())
+}
+
+func (s *voyagerServer) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[r.PathValue("id")]
+	if !ok {
+		writeServeError(w, http.StatusNotFound, fmt.Sprintf("job %q not found", r.PathValue("id")))
+		return
+	}
+	writeServeResponse(w, http.StatusOK, *job)
+}
+
+// handleJobLogs streams the output of the job. With follow=true, the output is streamed until the command exits.
+func (s *voyagerServer) handleJobLogs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	job, ok := s.jobs[r.PathValue("id")]
+	s.mu.Unlock()
+	if !ok {
+		writeServeError(w, http.StatusNotFound, fmt.Sprintf("job %q not found", r.PathValue("id")))
+		return
+	}
+	file, err := os.Open(job.OutputFile)
+	if err != nil {
+		writeServeError(w, http.StatusInternalServerError, fmt.Sprintf("open output of job %s: %v", job.ID, err))
+		return
+	}
+	defer file.Close()
+
+	follow := r.URL.Query().Get("follow") == "true"
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	for {
+		_, err := io.Copy(w, file)
+		if err != nil {
+			log.Warnf("serve: streaming output of job %s: %v", job.ID, err)
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if !follow {
+			return
+		}
+		select {
+		case <-job.done:
+			// the rest of the output written before the command exited
+			_, err = io.Copy(w, file)
+			if err != nil {
+				log.Warnf("serve: streaming output of job %s: %v", job.ID, err)
+			}
+			return
+		case <-r.Context().Done():
+			return
+		case <-time.After(SERVE_LOGS_FOLLOW_INTERVAL):
+		}
+	}
+}
+
+func (s *voyagerServer) handleStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := s.getMigrationStatus()
+	if err != nil {
+		writeServeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeServeResponse(w, http.StatusOK, status)
+}
+
+//=========================================================================================
+
+// buildPhaseCommand returns the args and the additional environment variables to run the phase with the given flags.
+func (s *voyagerServer) buildPhaseCommand(phaseCmd *cobra.Command, flags map[string]any) ([]string, []string, error) {
+	args := strings.Fields(strings.TrimPrefix(phaseCmd.CommandPath(), rootCmd.Name()))
+	args = append(args, "--export-dir", s.exportDir, "--yes")
+	var env []string
+	names := lo.Union(lo.Keys(flags), lo.Keys(s.inheritedArgs))
+	slices.Sort(names)
+	for _, name := range names {
+		value, inRequest := flags[name]
+		if inRequest && slices.Contains(serveReservedFlags, name) {
+			return nil, nil, fmt.Errorf("flag %q can't be set in the request", name)
+		}
+		if phaseCmd.Flags().Lookup(name) == nil && phaseCmd.InheritedFlags().Lookup(name) == nil {
+			if inRequest {
+				return nil, nil, fmt.Errorf("unknown flag %q for %q", name, phaseCmd.CommandPath())
+			}
+			continue
+		}
+		if !inRequest {
+			value = s.inheritedArgs[name]
+		}
+		strValue, err := getServeFlagValue(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("flag %q: %w", name, err)
+		}
+		if slices.Contains(passwordFlags, "--"+name) {
+			// passed through the env var of the password e.g. TARGET_DB_PASSWORD so that it is not visible in the process list
+			env = append(env, fmt.Sprintf("%s=%s", strings.ToUpper(strings.ReplaceAll(name, "-", "_")), strValue))
+			continue
+		}
+		args = append(args, fmt.Sprintf("--%s=%s", name, strValue))
+	}
+	return args, env, nil
+}
+
+func getServeFlagValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool, float64:
+		return fmt.Sprint(v), nil
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return "", fmt.Errorf("list items need to be strings")
+			}
+			items[i] = s
+		}
+		return strings.Join(items, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+// startJob starts the command with the args. Needs to be called with s.mu held.
+func (s *voyagerServer) startJob(phase string, args []string, env []string) (*serveJob, error) {
+	job := &serveJob{
+		ID:        uuid.New().String(),
+		Phase:     phase,
+		Command:   strings.Join(append([]string{filepath.Base(s.executable)}, args...), " "),
+		State:     JOB_RUNNING,
+		StartedAt: time.Now().UTC(),
+		done:      make(chan struct{}),
+	}
+	job.OutputFile = filepath.Join(s.exportDir, "logs", "serve", fmt.Sprintf("%s-%s.out", phase, job.ID))
+	err := os.MkdirAll(filepath.Dir(job.OutputFile), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("create directory for the output of the command: %w", err)
+	}
+	outputFile, err := os.Create(job.OutputFile)
+	if err != nil {
+		return nil, fmt.Errorf("create output file of the command: %w", err)
+	}
+
+	command := exec.Command(s.executable, args...)
+	command.Env = append(os.Environ(), env...)
+	// stdin is /dev/null, so the command fails instead of waiting on the prompts e.g. for the passwords
+	command.Stdout = outputFile
+	command.Stderr = outputFile
+	err = command.Start()
+	if err != nil {
+		outputFile.Close()
+		return nil, fmt.Errorf("start %q: %w", job.Command, err)
+	}
+	job.PID = command.Process.Pid
+	s.jobs[job.ID] = job
+	log.Infof("serve: started job %s: %q with PID=%d", job.ID, job.Command, job.PID)
+
+	go func() {
+		err := command.Wait()
+		outputFile.Close()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		exitCode := command.ProcessState.ExitCode()
+		finishedAt := time.Now().UTC()
+		job.ExitCode, job.FinishedAt = &exitCode, &finishedAt
+		var exitErr *exec.ExitError
+		switch {
+		case job.stopRequested:
+			job.State = JOB_STOPPED
+		case err == nil:
+			job.State = JOB_SUCCEEDED
+		case errors.As(err, &exitErr):
+			job.State = JOB_FAILED
+		default:
+			log.Errorf("serve: waiting for job %s: %v", job.ID, err)
+			job.State = JOB_FAILED
+		}
+		log.Infof("serve: job %s exited with code %d: %s", job.ID, exitCode, job.State)
+		close(job.done)
+		s.pruneFinishedJobs()
+	}()
+	return job, nil
+}
+
+// pruneFinishedJobs forgets the oldest finished jobs beyond SERVE_MAX_FINISHED_JOBS. Needs to be called with s.mu held.
+func (s *voyagerServer) pruneFinishedJobs() {
+	finishedJobs := lo.Filter(lo.Values(s.jobs), func(job *serveJob, _ int) bool { return job.State != JOB_RUNNING })
+	if len(finishedJobs) <= SERVE_MAX_FINISHED_JOBS {
+		return
+	}
+	slices.SortFunc(finishedJobs, func(a, b *serveJob) int {
+		return a.FinishedAt.Compare(*b.FinishedAt)
+	})
+	for _, job := range finishedJobs[:len(finishedJobs)-SERVE_MAX_FINISHED_JOBS] {
+		delete(s.jobs, job.ID)
+	}
+}
+
+// getRunningJob needs to be called with s.mu held.
+func (s *voyagerServer) getRunningJob(phase string) *serveJob {
+	for _, job := range s.jobs {
+		if job.Phase == phase && job.State == JOB_RUNNING {
+			return job
+		}
+	}
+	return nil
+}
+
+// getJobsSnapshot returns copies of the jobs in the order they were started. Needs to be called with s.mu held.
+func (s *voyagerServer) getJobsSnapshot() []*serveJob {
+	jobs := lo.Map(lo.Values(s.jobs), func(job *serveJob, _ int) *serveJob {
+		jobCopy := *job
+		return &jobCopy
+	})
+	slices.SortFunc(jobs, func(a, b *serveJob) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+	return jobs
+}
+
+func (s *voyagerServer) getMigrationStatus() (*serveMigrationStatus, error) {
+	s.mu.Lock()
+	status := &serveMigrationStatus{
+		ExportDir: s.exportDir,
+		Phases:    []*serveMigrationPhase{},
+		Jobs:      s.getJobsSnapshot(),
+	}
+	if metaDB == nil && metaDBIsCreated(s.exportDir) {
+		// the metaDB is created by the first command of the migration, which can be run after the server has started
+		metaDB = initMetaDB(s.exportDir)
+	}
+	s.mu.Unlock()
+	status.RunningCommands = getRunningVoyagerCommands(s.exportDir)
+	if metaDB == nil {
+		return status, nil
+	}
+
+	msr, err := metaDB.GetMigrationStatusRecord()
+	if err != nil {
+		return nil, fmt.Errorf("get migration status record: %w", err)
+	}
+	if msr == nil {
+		return status, nil
+	}
+	cutover := cutoverStatuses{
+		toTarget:        getCutoverStatus(),
+		toSourceReplica: getCutoverToSourceReplicaStatus(),
+		toSource:        getCutoverToSourceStatus(),
+	}
+	phases := getMigrationPhaseStatuses(msr, cutover, schemaIsAnalyzed(), status.RunningCommands)
+	timeOrNil := func(t time.Time) *time.Time {
+		if t.IsZero() {
+			return nil
+		}
+		return &t
+	}
+	status.MigrationStarted = true
+	status.MigrationUUID = msr.MigrationUUID
+	status.MigrationType = getMigrationTypeDescription(msr)
+	status.Phases = lo.Map(phases, func(phase *migrationPhaseStatus, _ int) *serveMigrationPhase {
+		return &serveMigrationPhase{
+			Phase:       phase.Phase,
+			Status:      phase.Status,
+			StartedAt:   timeOrNil(phase.StartedAt),
+			CompletedAt: timeOrNil(phase.CompletedAt),
+			RunningPIDs: phase.RunningPIDs,
+		}
+	})
+	status.NextStep = getMigrationNextStep(msr, phases)
+	return status, nil
+}
+
+func writeServeResponse(w http.ResponseWriter, statusCode int, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(response)
+	if err != nil {
+		log.Warnf("serve: writing response: %v", err)
+	}
+}
+
+func writeServeError(w http.ResponseWriter, statusCode int, msg string) {
+	writeServeResponse(w, statusCode, serveErrorResponse{Error: msg})
+}
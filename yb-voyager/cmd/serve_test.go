@@ -0,0 +1,275 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/metadb"
+)
+
+const serveTestToken = "test-token"
+
+// fake yb-voyager which prints its args, and runs until stopped for export data
+const serveTestExecutable = `#!/bin/sh
+echo "args: $*"
+echo "target password: $TARGET_DB_PASSWORD"
+case "$*" in
+  export\ data*)
+    trap 'echo stopping; exit 1' TERM
+    while true; do sleep 0.1; done ;;
+  import\ schema*)
+    echo "failed" >&2; exit 3 ;;
+esac
+`
+
+func newTestVoyagerServer(t *testing.T) (*voyagerServer, *httptest.Server) {
+	dir := t.TempDir()
+	executable := filepath.Join(dir, "yb-voyager")
+	require.NoError(t, os.WriteFile(executable, []byte(serveTestExecutable), 0755))
+	exportDir := filepath.Join(dir, "export-dir")
+	require.NoError(t, os.MkdirAll(exportDir, 0755))
+
+	s := newVoyagerServer(exportDir, serveTestToken, executable, map[string]string{"secret-source": "file:/tmp/passwords"})
+	server := httptest.NewServer(s.handler())
+	t.Cleanup(server.Close)
+	return s, server
+}
+
+func serveTestRequest(t *testing.T, method string, url string, body string, response any) int {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+serveTestToken)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if response != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(response))
+	}
+	return resp.StatusCode
+}
+
+func waitForServeJob(t *testing.T, url string, jobID string) *serveJob {
+	var job serveJob
+	assert.Eventually(t, func() bool {
+		serveTestRequest(t, http.MethodGet, url+"/api/v1/jobs/"+jobID, "", &job)
+		return job.State != JOB_RUNNING
+	}, 10*time.Second, 50*time.Millisecond)
+	return &job
+}
+
+func TestServeAuthentication(t *testing.T) {
+	_, server := newTestVoyagerServer(t)
+
+	for _, token := range []string{"", "Bearer wrong-token", serveTestToken} {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/phases", nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, token)
+	}
+
+	var phases []*servePhase
+	assert.Equal(t, http.StatusOK, serveTestRequest(t, http.MethodGet, server.URL+"/api/v1/phases", "", &phases))
+	phaseNames := make([]string, len(phases))
+	for i, phase := range phases {
+		phaseNames[i] = phase.Phase
+	}
+	assert.Contains(t, phaseNames, "export-data-from-source")
+	assert.Contains(t, phaseNames, "initiate-cutover-to-target")
+	assert.Contains(t, phaseNames, "end-migration")
+}
+
+func TestServeStartPhase(t *testing.T) {
+	s, server := newTestVoyagerServer(t)
+
+	var job serveJob
+	status := serveTestRequest(t, http.MethodPost, server.URL+"/api/v1/phases/import-data-to-target/start",
+		`{"flags": {"target-db-host": "yb.example.com", "target-db-password": "secret-pass", "parallel-jobs": 4, "table-list": ["t1", "t2"]}}`, &job)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "import-data-to-target", job.Phase)
+	// password is passed through the environment
+	assert.NotContains(t, job.Command, "secret-pass")
+
+	finished := waitForServeJob(t, server.URL, job.ID)
+	assert.Equal(t, JOB_SUCCEEDED, finished.State)
+	assert.Equal(t, 0, *finished.ExitCode)
+
+	resp, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/jobs/"+job.ID+"/logs", nil)
+	require.NoError(t, err)
+	resp.Header.Set("Authorization", "Bearer "+serveTestToken)
+	logsResp, err := http.DefaultClient.Do(resp)
+	require.NoError(t, err)
+	defer logsResp.Body.Close()
+	logs, err := io.ReadAll(logsResp.Body)
+	require.NoError(t, err)
+	output := string(logs)
+	assert.Contains(t, output, "args: import data to target --export-dir "+s.exportDir+" --yes")
+	assert.Contains(t, output, "--target-db-host=yb.example.com")
+	assert.Contains(t, output, "--parallel-jobs=4")
+	assert.Contains(t, output, "--table-list=t1,t2")
+	assert.Contains(t, output, "--secret-source=file:/tmp/passwords")
+	assert.NotContains(t, output, "--target-db-password")
+	assert.Contains(t, output, "target password: secret-pass")
+
+	var errResp serveErrorResponse
+	status = serveTestRequest(t, http.MethodPost, server.URL+"/api/v1/phases/import-data-to-target/start",
+		`{"flags": {"source-db-host": "pg.example.com"}}`, &errResp)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, errResp.Error, "unknown flag")
+
+	for _, flags := range []string{`{"export-dir": "/tmp"}`, `{"secret-source": "exec:/tmp/helper"}`, `{"log-level": "debug"}`} {
+		status = serveTestRequest(t, http.MethodPost, server.URL+"/api/v1/phases/import-data-to-target/start",
+			`{"flags": `+flags+`}`, &errResp)
+		assert.Equal(t, http.StatusBadRequest, status, flags)
+		assert.Contains(t, errResp.Error, "can't be set in the request")
+	}
+
+	status = serveTestRequest(t, http.MethodPost, server.URL+"/api/v1/phases/drop-database/start", ``, &errResp)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status = serveTestRequest(t, http.MethodPost, server.URL+"/api/v1/phases/import-schema/start", ``, &job)
+	require.Equal(t, http.StatusCreated, status)
+	finished = waitForServeJob(t, server.URL, job.ID)
+	assert.Equal(t, JOB_FAILED, finished.State)
+	assert.Equal(t, 3, *finished.ExitCode)
+}
+
+func TestServeStopPhase(t *testing.T) {
+	_, server := newTestVoyagerServer(t)
+
+	var errResp serveErrorResponse
+	status := serveTestRequest(t, http.MethodPost, server.URL+"/api/v1/phases/export-data-from-source/stop", ``, &errResp)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	var job serveJob
+	status = serveTestRequest(t, http.MethodPost, server.URL+"/api/v1/phases/export-data-from-source/start", `{}`, &job)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, JOB_RUNNING, job.State)
+
+	status = serveTestRequest(t, http.MethodPost, server.URL+"/api/v1/phases/export-data-from-source/start", `{}`, &errResp)
+	assert.Equal(t, http.StatusConflict, status)
+
+	var phases []*servePhase
+	serveTestRequest(t, http.MethodGet, server.URL+"/api/v1/phases", "", &phases)
+	for _, phase := range phases {
+		if phase.Phase == "export-data-from-source" {
+			assert.Equal(t, job.ID, phase.RunningJobID)
+		}
+	}
+
+	// follow the logs until the job is stopped
+	logsDone := make(chan string)
+	go func() {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/v1/jobs/"+job.ID+"/logs?follow=true", nil)
+		req.Header.Set("Authorization", "Bearer "+serveTestToken)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			logsDone <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		logs, _ := io.ReadAll(resp.Body)
+		logsDone <- string(logs)
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+	status = serveTestRequest(t, http.MethodPost, server.URL+"/api/v1/phases/export-data-from-source/stop", ``, &job)
+	assert.Equal(t, http.StatusAccepted, status)
+	finished := waitForServeJob(t, server.URL, job.ID)
+	assert.Equal(t, JOB_STOPPED, finished.State)
+
+	select {
+	case logs := <-logsDone:
+		assert.Contains(t, logs, "args: export data from source")
+		assert.Contains(t, logs, "stopping")
+	case <-time.After(10 * time.Second):
+		t.Fatal("logs were not streamed till the end of the job")
+	}
+
+	var jobs []*serveJob
+	serveTestRequest(t, http.MethodGet, server.URL+"/api/v1/jobs", "", &jobs)
+	assert.Len(t, jobs, 1)
+}
+
+func TestServeStatusBeforeMigrationStarted(t *testing.T) {
+	s, server := newTestVoyagerServer(t)
+	prevExportDir, prevMetaDB := exportDir, metaDB
+	exportDir, metaDB = s.exportDir, (*metadb.MetaDB)(nil)
+	defer func() { exportDir, metaDB = prevExportDir, prevMetaDB }()
+
+	var status serveMigrationStatus
+	assert.Equal(t, http.StatusOK, serveTestRequest(t, http.MethodGet, server.URL+"/api/v1/status", "", &status))
+	assert.False(t, status.MigrationStarted)
+	assert.Equal(t, s.exportDir, status.ExportDir)
+	assert.Empty(t, status.Phases)
+	assert.Empty(t, status.Jobs)
+}
+
+func TestServeStartPhaseRunningFromCommandLine(t *testing.T) {
+	s, server := newTestVoyagerServer(t)
+	// export data run from the command line, holding the lock file of the export dir
+	lockFilePath := filepath.Join(s.exportDir, ".export-dataLockfile.lck")
+	require.NoError(t, os.WriteFile(lockFilePath, []byte(fmt.Sprint(os.Getpid())), 0644))
+
+	var errResp serveErrorResponse
+	status := serveTestRequest(t, http.MethodPost, server.URL+"/api/v1/phases/export-data-from-source/start", `{}`, &errResp)
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Contains(t, errResp.Error, fmt.Sprintf("yb-voyager export data (PID %d)", os.Getpid()))
+
+	require.NoError(t, os.Remove(lockFilePath))
+	var job serveJob
+	status = serveTestRequest(t, http.MethodPost, server.URL+"/api/v1/phases/import-schema/start", `{}`, &job)
+	assert.Equal(t, http.StatusCreated, status)
+	waitForServeJob(t, server.URL, job.ID)
+}
+
+func TestServePruneFinishedJobs(t *testing.T) {
+	s := newVoyagerServer(t.TempDir(), serveTestToken, "yb-voyager", nil)
+	startedAt := time.Now()
+	for i := 0; i < SERVE_MAX_FINISHED_JOBS+5; i++ {
+		finishedAt := startedAt.Add(time.Duration(i) * time.Second)
+		s.jobs[fmt.Sprint(i)] = &serveJob{ID: fmt.Sprint(i), State: JOB_SUCCEEDED, StartedAt: startedAt, FinishedAt: &finishedAt}
+	}
+	s.jobs["running"] = &serveJob{ID: "running", State: JOB_RUNNING, StartedAt: startedAt}
+
+	s.pruneFinishedJobs()
+	assert.Len(t, s.jobs, SERVE_MAX_FINISHED_JOBS+1)
+	assert.Contains(t, s.jobs, "running")
+	// the oldest finished jobs are forgotten
+	for i := 0; i < 5; i++ {
+		assert.NotContains(t, s.jobs, fmt.Sprint(i))
+	}
+	assert.Contains(t, s.jobs, "5")
+}
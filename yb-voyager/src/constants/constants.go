@@ -34,6 +34,7 @@ const (
 	QUERY_CONSTRUCT   = "query_construct"
 	MIGRATION_CAVEATS = "migration_caveats"
 	PLPGSQL_OBJECT    = "plpgsql_object"
+	CUSTOM_RULE       = "custom_rule"
 
 	// constants for the Impact Buckets
 	IMPACT_LEVEL_1 = "LEVEL_1" // Represents minimal impact like only the schema ddl
@@ -54,7 +54,16 @@ type ControlPlane interface {
 	UpdateImportedRowCount([]*UpdateImportedRowCountEvent)
 	SnapshotImportCompleted(*SnapshotImportCompletedEvent)
 
+	// live migration
+	LiveMigrationPhaseStarted(*LiveMigrationPhaseStartedEvent)
+	UpdateStreamingStats(*UpdateStreamingStatsEvent)
+	CutoverInitiated(*CutoverInitiatedEvent)
+	CutoverProcessed(*CutoverProcessedEvent)
+	CutoverCompleted(*CutoverCompletedEvent)
+
 	MigrationEnded(*MigrationEndedEvent)
+
+	CommandFailed(*CommandFailedEvent)
 }
 
 func GetSchemaList(schema string) []string {
@@ -150,6 +159,49 @@ type SnapshotImportCompletedEvent struct {
 	BaseEvent
 }
 
+// Sent when an exporter/importer starts streaming the changes. With the role, this marks the start of the
+// fall-forward(source_replica_db_importer) and fall-back(target_db_exporter_fb, source_db_importer) phases.
+type LiveMigrationPhaseStartedEvent struct {
+	BaseEvent
+	Role string
+}
+
+// Sent periodically by the exporters/importers while streaming the changes.
+type UpdateStreamingStatsEvent struct {
+	BaseEvent
+	Role               string
+	TotalEvents        int64 // exported/imported events across all the runs
+	EventsInCurrentRun int64
+	IngestionRate      int64 // events per second in the last 3 minutes
+	RemainingEvents    int64 // only for importers, exported events yet to be imported
+	LagSeconds         int64 // only for importers, estimated time to import the remaining events at the current rate
+}
+
+// CutoverType is one of "target", "source-replica" or "source".
+type CutoverInitiatedEvent struct {
+	BaseEvent
+	CutoverType        string
+	PrepareForFallback bool
+}
+
+// Sent by each exporter/importer involved in the cutover once it has processed the cutover.
+type CutoverProcessedEvent struct {
+	BaseEvent
+	CutoverType string
+	Role        string
+}
+
+type CutoverCompletedEvent struct {
+	BaseEvent
+	CutoverType string
+}
+
 type MigrationEndedEvent struct {
 	BaseEvent
 }
+
+type CommandFailedEvent struct {
+	BaseEvent
+	Command  string
+	ErrorMsg string // sanitized, doesn't contain any user data
+}
@@ -75,5 +75,23 @@ func (cp *NoopControlPlane) UpdateImportedRowCount(
 func (cp *NoopControlPlane) SnapshotImportCompleted(snapshotImportEvent *cp.SnapshotImportCompletedEvent) {
 }
 
+func (cp *NoopControlPlane) LiveMigrationPhaseStarted(liveMigrationPhaseStartedEvent *cp.LiveMigrationPhaseStartedEvent) {
+}
+
+func (cp *NoopControlPlane) UpdateStreamingStats(streamingStatsEvent *cp.UpdateStreamingStatsEvent) {
+}
+
+func (cp *NoopControlPlane) CutoverInitiated(cutoverInitiatedEvent *cp.CutoverInitiatedEvent) {
+}
+
+func (cp *NoopControlPlane) CutoverProcessed(cutoverProcessedEvent *cp.CutoverProcessedEvent) {
+}
+
+func (cp *NoopControlPlane) CutoverCompleted(cutoverCompletedEvent *cp.CutoverCompletedEvent) {
+}
+
 func (cp *NoopControlPlane) MigrationEnded(migrationEndedEvent *cp.MigrationEndedEvent) {
 }
+
+func (cp *NoopControlPlane) CommandFailed(commandFailedEvent *cp.CommandFailedEvent) {
+}
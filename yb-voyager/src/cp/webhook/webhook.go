@@ -0,0 +1,522 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	controlPlane "github.com/yugabyte/yb-voyager/yb-voyager/src/cp"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
+)
+
+/*
+Webhook control plane POSTs every control plane event as a JSON envelope to the configured URL.
+
+Events are first written to a spool directory in the export dir and are removed from it only after
+they are delivered, so the events are not lost when the receiver is down; they are delivered (in order)
+by the next voyager command once the receiver is reachable again.
+The spool dir is shared by all the voyager commands running on the export dir(e.g. export and import data
+in live migration), so the delivery is done by one command at a time under an exclusive flock on the spool dir.
+
+Request headers:
+  - X-Voyager-Event:     event type, same as the `event_type` of the envelope
+  - X-Voyager-Delivery:  unique id of the event, same across the retries (for de-duplication on the receiver)
+  - X-Voyager-Signature: "sha256=<hex encoded HMAC-SHA256 of the body>" if WEBHOOK_SECRET is set
+*/
+
+const (
+	// bump this on any backward incompatible change in the envelope or the payloads
+	EVENT_SCHEMA_VERSION = 1
+
+	SPOOL_DIR_NAME       = ".webhook-events"
+	SPOOL_LOCK_FILE_NAME = ".lock"
+
+	EVENT_HEADER     = "X-Voyager-Event"
+	DELIVERY_HEADER  = "X-Voyager-Delivery"
+	SIGNATURE_HEADER = "X-Voyager-Signature"
+
+	STATUS_IN_PROGRESS = "IN PROGRESS"
+	STATUS_COMPLETED   = "COMPLETED"
+	STATUS_ERROR       = "ERROR"
+)
+
+var (
+	REQUEST_TIMEOUT             = 10 * time.Second
+	MAX_ATTEMPTS_PER_DELIVERY   = 5
+	INITIAL_RETRY_BACKOFF       = 1 * time.Second
+	MAX_RETRY_BACKOFF           = 30 * time.Second
+	REDELIVERY_INTERVAL         = 30 * time.Second
+	FINALIZE_TIMEOUT            = 30 * time.Second
+	ROW_COUNT_UPDATE_MIN_PERIOD = 5 * time.Second
+)
+
+type Envelope struct {
+	SchemaVersion   int                           `json:"schema_version"`
+	EventID         string                        `json:"event_id"`
+	EventType       string                        `json:"event_type"`
+	MigrationPhase  string                        `json:"migration_phase"`
+	Status          string                        `json:"status"`
+	MigrationUUID   uuid.UUID                     `json:"migration_uuid"`
+	Timestamp       time.Time                     `json:"timestamp"`
+	VoyagerVersion  string                        `json:"voyager_version"`
+	VoyagerInstance *controlPlane.VoyagerInstance `json:"voyager_instance"`
+	Payload         any                           `json:"payload"`
+}
+
+type Webhook struct {
+	sync.Mutex
+	url         string
+	secret      string
+	spoolDir    string
+	voyagerInfo *controlPlane.VoyagerInstance
+	client      *http.Client
+
+	lastSpoolSeq       int64
+	lastRowCountUpdate map[string]time.Time
+
+	notifyChan   chan struct{}
+	stopChan     chan struct{}
+	doneChan     chan struct{}
+	finalizeOnce sync.Once
+}
+
+func New(exportDir string, url string, secret string) *Webhook {
+	return &Webhook{
+		url:      url,
+		secret:   secret,
+		spoolDir: filepath.Join(exportDir, SPOOL_DIR_NAME),
+		voyagerInfo: &controlPlane.VoyagerInstance{
+			OperatingSystem: runtime.GOOS,
+			ExportDirectory: exportDir,
+		},
+	}
+}
+
+func (cp *Webhook) Init() error {
+	if cp.url == "" {
+		return fmt.Errorf("webhook url is not set")
+	}
+	err := os.MkdirAll(cp.spoolDir, 0755)
+	if err != nil {
+		return fmt.Errorf("create webhook events spool dir %q: %w", cp.spoolDir, err)
+	}
+	ip, err := utils.GetLocalIP()
+	if err != nil {
+		log.Warnf("failed to obtain local IP address: %v", err)
+	}
+	cp.voyagerInfo.IP = ip
+
+	cp.client = &http.Client{Timeout: REQUEST_TIMEOUT}
+	cp.lastRowCountUpdate = make(map[string]time.Time)
+	cp.notifyChan = make(chan struct{}, 1)
+	cp.stopChan = make(chan struct{})
+	cp.doneChan = make(chan struct{})
+	go cp.eventPublisher()
+	// deliver the events left over by the previous commands
+	cp.notify()
+	return nil
+}
+
+// Finalize makes one last attempt to deliver the pending events. The events which couldn't be delivered
+// stay in the spool dir for the next command.
+func (cp *Webhook) Finalize() {
+	if cp.stopChan == nil {
+		return
+	}
+	// called again on exit after a command failure
+	cp.finalizeOnce.Do(func() {
+		close(cp.stopChan)
+		select {
+		case <-cp.doneChan:
+		case <-time.After(FINALIZE_TIMEOUT):
+			log.Warnf("timed out delivering the webhook events, pending events are kept in %q", cp.spoolDir)
+		}
+	})
+}
+
+//=========================================================================================
+
+func (cp *Webhook) eventPublisher() {
+	defer close(cp.doneChan)
+	for {
+		cp.deliverPendingEvents(false)
+		select {
+		case <-cp.stopChan:
+			cp.deliverPendingEvents(true)
+			return
+		case <-cp.notifyChan:
+		case <-time.After(REDELIVERY_INTERVAL):
+		}
+	}
+}
+
+func (cp *Webhook) notify() {
+	select {
+	case cp.notifyChan <- struct{}{}:
+	default:
+	}
+}
+
+// deliverPendingEvents sends the spooled events in order and stops at the first event which could not be delivered.
+// In the final run, each event is attempted only once so that the command doesn't wait on an unavailable receiver.
+func (cp *Webhook) deliverPendingEvents(final bool) {
+	unlock, locked, err := cp.tryLockSpool()
+	if err != nil {
+		log.Warnf("lock webhook events spool dir: %v", err)
+		return
+	}
+	if !locked {
+		// the events(including the ones of this command) are delivered in order by the other command
+		log.Infof("webhook events in %q are being delivered by another voyager command", cp.spoolDir)
+		return
+	}
+	defer unlock()
+
+	files, err := cp.listSpooledEvents()
+	if err != nil {
+		log.Warnf("list spooled webhook events: %v", err)
+		return
+	}
+	for i, file := range files {
+		body, err := os.ReadFile(file)
+		if err != nil {
+			log.Warnf("read spooled webhook event %q: %v", file, err)
+			return
+		}
+		var envelope struct {
+			EventID   string `json:"event_id"`
+			EventType string `json:"event_type"`
+		}
+		err = json.Unmarshal(body, &envelope)
+		if err != nil {
+			log.Warnf("discarding malformed webhook event %q: %v", file, err)
+			cp.removeSpooledEvent(file)
+			continue
+		}
+
+		maxAttempts := MAX_ATTEMPTS_PER_DELIVERY
+		if final {
+			maxAttempts = 1
+		}
+		delivered, retryable := cp.sendWithRetries(envelope.EventType, envelope.EventID, body, maxAttempts)
+		if !delivered && retryable {
+			log.Warnf("webhook receiver is not reachable, %d event(s) are kept in %q for delivery later", len(files)-i, cp.spoolDir)
+			return
+		}
+		if !delivered {
+			log.Warnf("discarding webhook event %s(%s) rejected by the receiver", envelope.EventType, envelope.EventID)
+		}
+		cp.removeSpooledEvent(file)
+	}
+}
+
+func (cp *Webhook) sendWithRetries(eventType string, eventID string, body []byte, maxAttempts int) (delivered bool, retryable bool) {
+	backoff := INITIAL_RETRY_BACKOFF
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		var err error
+		retryable, err = cp.send(eventType, eventID, body)
+		if err == nil {
+			return true, false
+		}
+		log.Warnf("sending webhook event %s(%s) attempt %d: %v", eventType, eventID, attempt, err)
+		if !retryable || attempt == maxAttempts {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+		case <-cp.stopChan:
+			// stop retrying, the pending events are attempted once more in Finalize()
+			return false, true
+		}
+		backoff = min(backoff*2, MAX_RETRY_BACKOFF)
+	}
+	return false, retryable
+}
+
+// send returns whether the error(if any) is retryable.
+func (cp *Webhook) send(eventType string, eventID string, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, cp.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "yb-voyager/"+utils.YB_VOYAGER_VERSION)
+	req.Header.Set(EVENT_HEADER, eventType)
+	req.Header.Set(DELIVERY_HEADER, eventID)
+	if cp.secret != "" {
+		req.Header.Set(SIGNATURE_HEADER, Sign(cp.secret, body))
+	}
+
+	resp, err := cp.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("receiver responded with %s", resp.Status)
+	default:
+		return false, fmt.Errorf("receiver responded with %s", resp.Status)
+	}
+}
+
+// Sign returns the value of the signature header for the given body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+//=========================================================================================
+
+func (cp *Webhook) publish(eventType string, baseEvent *controlPlane.BaseEvent, status string, event any) {
+	envelope := Envelope{
+		SchemaVersion:   EVENT_SCHEMA_VERSION,
+		EventID:         uuid.New().String(),
+		EventType:       eventType,
+		MigrationPhase:  baseEvent.EventType,
+		Status:          status,
+		MigrationUUID:   baseEvent.MigrationUUID,
+		Timestamp:       time.Now().UTC(),
+		VoyagerVersion:  utils.YB_VOYAGER_VERSION,
+		VoyagerInstance: cp.voyagerInfo,
+		Payload:         event,
+	}
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		log.Warnf("marshal webhook event %s: %v", eventType, err)
+		return
+	}
+	err = cp.spoolEvent(body)
+	if err != nil {
+		log.Warnf("could not publish webhook event %s: %v", eventType, err)
+		return
+	}
+	cp.notify()
+}
+
+// spoolEvent writes the event to a new file in the spool dir. File names sort in the order of publishing.
+func (cp *Webhook) spoolEvent(body []byte) error {
+	cp.Mutex.Lock()
+	seq := max(time.Now().UnixNano(), cp.lastSpoolSeq+1)
+	cp.lastSpoolSeq = seq
+	cp.Mutex.Unlock()
+
+	fileName := fmt.Sprintf("%020d.json", seq)
+	tmpFilePath := filepath.Join(cp.spoolDir, fileName+".tmp")
+	err := os.WriteFile(tmpFilePath, body, 0644)
+	if err != nil {
+		return fmt.Errorf("write event to %q: %w", tmpFilePath, err)
+	}
+	// rename to make the event visible to the publisher only after it is completely written
+	err = os.Rename(tmpFilePath, filepath.Join(cp.spoolDir, fileName))
+	if err != nil {
+		return fmt.Errorf("rename %q: %w", tmpFilePath, err)
+	}
+	return nil
+}
+
+// tryLockSpool takes an exclusive lock on the spool dir without waiting. Returns false if another process holds it.
+func (cp *Webhook) tryLockSpool() (func(), bool, error) {
+	lockFilePath := filepath.Join(cp.spoolDir, SPOOL_LOCK_FILE_NAME)
+	lockFile, err := os.OpenFile(lockFilePath, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, false, fmt.Errorf("open %q: %w", lockFilePath, err)
+	}
+	err = syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if err != nil {
+		lockFile.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("flock %q: %w", lockFilePath, err)
+	}
+	unlock := func() {
+		// closing the file releases the lock
+		err := lockFile.Close()
+		if err != nil {
+			log.Warnf("unlock %q: %v", lockFilePath, err)
+		}
+	}
+	return unlock, true, nil
+}
+
+func (cp *Webhook) listSpooledEvents() ([]string, error) {
+	entries, err := os.ReadDir(cp.spoolDir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		files = append(files, filepath.Join(cp.spoolDir, entry.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func (cp *Webhook) removeSpooledEvent(file string) {
+	err := os.Remove(file)
+	if err != nil && !os.IsNotExist(err) {
+		log.Warnf("remove delivered webhook event %q: %v", file, err)
+	}
+}
+
+// shouldSendRowCountUpdate throttles the row count updates of a table to one per ROW_COUNT_UPDATE_MIN_PERIOD.
+// The final(COMPLETED) update of a table is always sent.
+func (cp *Webhook) shouldSendRowCountUpdate(ev *controlPlane.BaseUpdateRowCountEvent) bool {
+	key := ev.EventType + ":" + strings.Join(ev.SchemaNames, "|") + "." + ev.TableName
+	if ev.Status == "COMPLETED" {
+		return true
+	}
+	lastUpdateTime, ok := cp.lastRowCountUpdate[key]
+	if ok && lastUpdateTime.Add(ROW_COUNT_UPDATE_MIN_PERIOD).After(time.Now()) {
+		return false
+	}
+	cp.lastRowCountUpdate[key] = time.Now()
+	return true
+}
+
+//=========================================================================================
+
+func (cp *Webhook) MigrationAssessmentStarted(ev *controlPlane.MigrationAssessmentStartedEvent) {
+	cp.publish("MigrationAssessmentStarted", &ev.BaseEvent, STATUS_IN_PROGRESS, ev)
+}
+
+func (cp *Webhook) MigrationAssessmentCompleted(ev *controlPlane.MigrationAssessmentCompletedEvent) {
+	cp.publish("MigrationAssessmentCompleted", &ev.BaseEvent, STATUS_COMPLETED, ev)
+}
+
+func (cp *Webhook) ExportSchemaStarted(ev *controlPlane.ExportSchemaStartedEvent) {
+	cp.publish("ExportSchemaStarted", &ev.BaseEvent, STATUS_IN_PROGRESS, ev)
+}
+
+func (cp *Webhook) ExportSchemaCompleted(ev *controlPlane.ExportSchemaCompletedEvent) {
+	cp.publish("ExportSchemaCompleted", &ev.BaseEvent, STATUS_COMPLETED, ev)
+}
+
+func (cp *Webhook) SchemaAnalysisStarted(ev *controlPlane.SchemaAnalysisStartedEvent) {
+	cp.publish("SchemaAnalysisStarted", &ev.BaseEvent, STATUS_IN_PROGRESS, ev)
+}
+
+func (cp *Webhook) SchemaAnalysisIterationCompleted(ev *controlPlane.SchemaAnalysisIterationCompletedEvent) {
+	cp.publish("SchemaAnalysisIterationCompleted", &ev.BaseEvent, STATUS_COMPLETED, ev)
+}
+
+func (cp *Webhook) SnapshotExportStarted(ev *controlPlane.SnapshotExportStartedEvent) {
+	cp.publish("SnapshotExportStarted", &ev.BaseEvent, STATUS_IN_PROGRESS, ev)
+}
+
+func (cp *Webhook) UpdateExportedRowCount(events []*controlPlane.UpdateExportedRowCountEvent) {
+	cp.Mutex.Lock()
+	var toSend []*controlPlane.UpdateExportedRowCountEvent
+	for _, ev := range events {
+		if cp.shouldSendRowCountUpdate(&ev.BaseUpdateRowCountEvent) {
+			toSend = append(toSend, ev)
+		}
+	}
+	cp.Mutex.Unlock()
+	if len(toSend) > 0 {
+		cp.publish("UpdateExportedRowCount", &toSend[0].BaseEvent, STATUS_IN_PROGRESS, toSend)
+	}
+}
+
+func (cp *Webhook) SnapshotExportCompleted(ev *controlPlane.SnapshotExportCompletedEvent) {
+	cp.publish("SnapshotExportCompleted", &ev.BaseEvent, STATUS_COMPLETED, ev)
+}
+
+func (cp *Webhook) ImportSchemaStarted(ev *controlPlane.ImportSchemaStartedEvent) {
+	cp.publish("ImportSchemaStarted", &ev.BaseEvent, STATUS_IN_PROGRESS, ev)
+}
+
+func (cp *Webhook) ImportSchemaCompleted(ev *controlPlane.ImportSchemaCompletedEvent) {
+	cp.publish("ImportSchemaCompleted", &ev.BaseEvent, STATUS_COMPLETED, ev)
+}
+
+func (cp *Webhook) SnapshotImportStarted(ev *controlPlane.SnapshotImportStartedEvent) {
+	cp.publish("SnapshotImportStarted", &ev.BaseEvent, STATUS_IN_PROGRESS, ev)
+}
+
+func (cp *Webhook) UpdateImportedRowCount(events []*controlPlane.UpdateImportedRowCountEvent) {
+	cp.Mutex.Lock()
+	var toSend []*controlPlane.UpdateImportedRowCountEvent
+	for _, ev := range events {
+		if cp.shouldSendRowCountUpdate(&ev.BaseUpdateRowCountEvent) {
+			toSend = append(toSend, ev)
+		}
+	}
+	cp.Mutex.Unlock()
+	if len(toSend) > 0 {
+		cp.publish("UpdateImportedRowCount", &toSend[0].BaseEvent, STATUS_IN_PROGRESS, toSend)
+	}
+}
+
+func (cp *Webhook) SnapshotImportCompleted(ev *controlPlane.SnapshotImportCompletedEvent) {
+	cp.publish("SnapshotImportCompleted", &ev.BaseEvent, STATUS_COMPLETED, ev)
+}
+
+func (cp *Webhook) LiveMigrationPhaseStarted(ev *controlPlane.LiveMigrationPhaseStartedEvent) {
+	cp.publish("LiveMigrationPhaseStarted", &ev.BaseEvent, STATUS_IN_PROGRESS, ev)
+}
+
+func (cp *Webhook) UpdateStreamingStats(ev *controlPlane.UpdateStreamingStatsEvent) {
+	cp.publish("UpdateStreamingStats", &ev.BaseEvent, STATUS_IN_PROGRESS, ev)
+}
+
+func (cp *Webhook) CutoverInitiated(ev *controlPlane.CutoverInitiatedEvent) {
+	cp.publish("CutoverInitiated", &ev.BaseEvent, STATUS_IN_PROGRESS, ev)
+}
+
+func (cp *Webhook) CutoverProcessed(ev *controlPlane.CutoverProcessedEvent) {
+	cp.publish("CutoverProcessed", &ev.BaseEvent, STATUS_IN_PROGRESS, ev)
+}
+
+func (cp *Webhook) CutoverCompleted(ev *controlPlane.CutoverCompletedEvent) {
+	cp.publish("CutoverCompleted", &ev.BaseEvent, STATUS_COMPLETED, ev)
+}
+
+func (cp *Webhook) MigrationEnded(ev *controlPlane.MigrationEndedEvent) {
+	cp.publish("MigrationEnded", &ev.BaseEvent, STATUS_COMPLETED, ev)
+}
+
+func (cp *Webhook) CommandFailed(ev *controlPlane.CommandFailedEvent) {
+	cp.publish("CommandFailed", &ev.BaseEvent, STATUS_ERROR, ev)
+}
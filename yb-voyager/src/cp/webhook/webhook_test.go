@@ -0,0 +1,212 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	controlPlane "github.com/yugabyte/yb-voyager/yb-voyager/src/cp"
+)
+
+type receiver struct {
+	sync.Mutex
+	statusCodes []int // responses for the requests in order, 200 after these are used up
+	requests    []*http.Request
+	bodies      [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.Lock()
+	defer r.Unlock()
+	body, _ := io.ReadAll(req.Body)
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	statusCode := http.StatusOK
+	if len(r.statusCodes) > 0 {
+		statusCode, r.statusCodes = r.statusCodes[0], r.statusCodes[1:]
+	}
+	w.WriteHeader(statusCode)
+}
+
+func (r *receiver) envelopes(t *testing.T) []Envelope {
+	r.Lock()
+	defer r.Unlock()
+	var result []Envelope
+	for _, body := range r.bodies {
+		var envelope Envelope
+		assert.NoError(t, json.Unmarshal(body, &envelope))
+		result = append(result, envelope)
+	}
+	return result
+}
+
+func setupFastRetries(t *testing.T) {
+	initialBackoff, redeliveryInterval := INITIAL_RETRY_BACKOFF, REDELIVERY_INTERVAL
+	INITIAL_RETRY_BACKOFF, REDELIVERY_INTERVAL = time.Millisecond, 10*time.Millisecond
+	t.Cleanup(func() {
+		INITIAL_RETRY_BACKOFF, REDELIVERY_INTERVAL = initialBackoff, redeliveryInterval
+	})
+}
+
+func TestEventsAreSignedAndDeliveredInOrder(t *testing.T) {
+	setupFastRetries(t)
+	// first attempt of the first event fails with a retryable error
+	recv := &receiver{statusCodes: []int{http.StatusServiceUnavailable}}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	exportDir := t.TempDir()
+	cp := New(exportDir, server.URL, "s3cr3t")
+	assert.NoError(t, cp.Init())
+
+	migrationUUID := uuid.New()
+	cp.ExportSchemaStarted(&controlPlane.ExportSchemaStartedEvent{
+		BaseEvent: controlPlane.BaseEvent{EventType: "EXPORT SCHEMA", MigrationUUID: migrationUUID}})
+	cp.ExportSchemaCompleted(&controlPlane.ExportSchemaCompletedEvent{
+		BaseEvent: controlPlane.BaseEvent{EventType: "EXPORT SCHEMA", MigrationUUID: migrationUUID}})
+	// Finalize() attempts each event only once
+	assert.Eventually(t, func() bool { return len(recv.envelopes(t)) == 3 }, 5*time.Second, 10*time.Millisecond)
+	cp.Finalize()
+
+	envelopes := recv.envelopes(t)
+	assert.Len(t, envelopes, 3)
+	// retried with the same delivery id
+	assert.Equal(t, envelopes[0].EventID, envelopes[1].EventID)
+	assert.Equal(t, "ExportSchemaStarted", envelopes[1].EventType)
+	assert.Equal(t, STATUS_IN_PROGRESS, envelopes[1].Status)
+	assert.Equal(t, "ExportSchemaCompleted", envelopes[2].EventType)
+	assert.Equal(t, STATUS_COMPLETED, envelopes[2].Status)
+	assert.Equal(t, EVENT_SCHEMA_VERSION, envelopes[2].SchemaVersion)
+	assert.Equal(t, "EXPORT SCHEMA", envelopes[2].MigrationPhase)
+	assert.Equal(t, migrationUUID, envelopes[2].MigrationUUID)
+
+	for i, req := range recv.requests {
+		assert.Equal(t, Sign("s3cr3t", recv.bodies[i]), req.Header.Get(SIGNATURE_HEADER))
+		assert.Equal(t, envelopes[i].EventType, req.Header.Get(EVENT_HEADER))
+		assert.Equal(t, envelopes[i].EventID, req.Header.Get(DELIVERY_HEADER))
+	}
+
+	files, err := cp.listSpooledEvents()
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestEventsAreBufferedWhileReceiverIsDown(t *testing.T) {
+	setupFastRetries(t)
+	recv := &receiver{}
+	server := httptest.NewServer(recv)
+	serverURL := server.URL
+	server.Close()
+
+	exportDir := t.TempDir()
+	cp := New(exportDir, serverURL, "")
+	assert.NoError(t, cp.Init())
+	cp.MigrationEnded(&controlPlane.MigrationEndedEvent{BaseEvent: controlPlane.BaseEvent{EventType: "END MIGRATION"}})
+	cp.Finalize()
+
+	files, err := cp.listSpooledEvents()
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	// next command delivers the buffered events
+	server = httptest.NewServer(recv)
+	defer server.Close()
+	cp = New(exportDir, server.URL, "")
+	assert.NoError(t, cp.Init())
+	cp.Finalize()
+
+	envelopes := recv.envelopes(t)
+	assert.Len(t, envelopes, 1)
+	assert.Equal(t, "MigrationEnded", envelopes[0].EventType)
+	assert.Empty(t, recv.requests[0].Header.Get(SIGNATURE_HEADER))
+	files, err = cp.listSpooledEvents()
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestRejectedEventsAreDiscarded(t *testing.T) {
+	setupFastRetries(t)
+	recv := &receiver{statusCodes: []int{http.StatusBadRequest}}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	cp := New(t.TempDir(), server.URL, "")
+	assert.NoError(t, cp.Init())
+	cp.ImportSchemaStarted(&controlPlane.ImportSchemaStartedEvent{})
+	cp.ImportSchemaCompleted(&controlPlane.ImportSchemaCompletedEvent{})
+	cp.Finalize()
+
+	envelopes := recv.envelopes(t)
+	// rejected event is not retried and doesn't block the following events
+	assert.Len(t, envelopes, 2)
+	assert.Equal(t, "ImportSchemaStarted", envelopes[0].EventType)
+	assert.Equal(t, "ImportSchemaCompleted", envelopes[1].EventType)
+}
+
+func TestRowCountUpdatesAreThrottled(t *testing.T) {
+	cp := New(t.TempDir(), "http://localhost", "")
+	cp.lastRowCountUpdate = make(map[string]time.Time)
+	newEvent := func(tableName string, status string) *controlPlane.BaseUpdateRowCountEvent {
+		return &controlPlane.BaseUpdateRowCountEvent{
+			BaseEvent: controlPlane.BaseEvent{EventType: "IMPORT DATA", SchemaNames: []string{"public"}},
+			TableName: tableName,
+			Status:    status,
+		}
+	}
+	assert.True(t, cp.shouldSendRowCountUpdate(newEvent("orders", "IN PROGRESS")))
+	assert.False(t, cp.shouldSendRowCountUpdate(newEvent("orders", "IN PROGRESS")))
+	assert.True(t, cp.shouldSendRowCountUpdate(newEvent("customers", "IN PROGRESS")))
+	assert.True(t, cp.shouldSendRowCountUpdate(newEvent("orders", "COMPLETED")))
+}
+
+func TestEventsAreDeliveredByOneCommandAtATime(t *testing.T) {
+	setupFastRetries(t)
+	recv := &receiver{}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	exportDir := t.TempDir()
+	cp := New(exportDir, server.URL, "")
+	assert.NoError(t, cp.Init())
+	// another command on the same export dir is delivering the events
+	other := New(exportDir, server.URL, "")
+	unlock, locked, err := other.tryLockSpool()
+	assert.NoError(t, err)
+	assert.True(t, locked)
+
+	cp.ImportSchemaStarted(&controlPlane.ImportSchemaStartedEvent{})
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, recv.envelopes(t))
+	files, err := cp.listSpooledEvents()
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	unlock()
+	assert.Eventually(t, func() bool { return len(recv.envelopes(t)) == 1 }, 5*time.Second, 10*time.Millisecond)
+	cp.Finalize()
+	assert.Equal(t, "ImportSchemaStarted", recv.envelopes(t)[0].EventType)
+}
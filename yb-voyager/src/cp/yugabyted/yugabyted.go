@@ -39,20 +39,23 @@ import (
 type YugabyteD struct {
 	sync.Mutex
 	migrationDirectory       string
+	password                 string // overrides the password in YUGABYTED_DB_CONN_STRING if set
 	voyagerInfo              *controlPlane.VoyagerInstance
 	waitGroup                sync.WaitGroup
 	eventChan                chan (MigrationEvent)
 	rowCountUpdateEventChan  chan ([]VisualizerTableMetrics)
+	streamingStatsEventChan  chan (VisualizerStreamingStats)
 	connPool                 *pgxpool.Pool
 	lastRowCountUpdate       map[string]time.Time
 	latestInvocationSequence int
 }
 
-func New(exportDir string) *YugabyteD {
+func New(exportDir string, password string) *YugabyteD {
 	vi := prepareVoyagerInstance(exportDir)
 	return &YugabyteD{
 		voyagerInfo:        vi,
 		migrationDirectory: exportDir,
+		password:           password,
 	}
 }
 
@@ -92,6 +95,7 @@ func getAvailableDiskSpace(dirPath string) (uint64, error) {
 func (cp *YugabyteD) Init() error {
 	cp.eventChan = make(chan MigrationEvent, 100)
 	cp.rowCountUpdateEventChan = make(chan []VisualizerTableMetrics, 200)
+	cp.streamingStatsEventChan = make(chan VisualizerStreamingStats, 100)
 
 	err := cp.connect()
 	if err != nil {
@@ -108,6 +112,7 @@ func (cp *YugabyteD) Init() error {
 
 	go cp.eventPublisher()
 	go cp.rowCountUpdateEventPublisher()
+	go cp.streamingStatsEventPublisher()
 
 	return nil
 }
@@ -142,6 +147,18 @@ func (cp *YugabyteD) rowCountUpdateEventPublisher() {
 	}
 }
 
+func (cp *YugabyteD) streamingStatsEventPublisher() {
+	defer cp.panicHandler()
+	for {
+		event := <-cp.streamingStatsEventChan
+		err := cp.sendVisualizerStreamingStats(event)
+		if err != nil {
+			log.Warnf("Couldn't send metadata for visualization. %s", err)
+		}
+		cp.waitGroup.Done()
+	}
+}
+
 func (cp *YugabyteD) createAndSendEvent(event *controlPlane.BaseEvent, status string, payload string) {
 
 	timestamp := time.Now().Format("2006-01-02 15:04:05")
@@ -337,9 +354,61 @@ func (cp *YugabyteD) UpdateImportedRowCount(
 	}
 }
 
+func (cp *YugabyteD) LiveMigrationPhaseStarted(ev *controlPlane.LiveMigrationPhaseStartedEvent) {
+	cp.createAndSendEvent(&ev.BaseEvent, "IN PROGRESS", marshalPayload(map[string]string{"Role": ev.Role}))
+}
+
+func (cp *YugabyteD) UpdateStreamingStats(ev *controlPlane.UpdateStreamingStatsEvent) {
+	streamingStats := VisualizerStreamingStats{
+		MigrationUUID:       ev.MigrationUUID,
+		MigrationPhase:      MIGRATION_PHASE_MAP[ev.EventType],
+		Role:                ev.Role,
+		TotalEvents:         ev.TotalEvents,
+		EventsInCurrentRun:  ev.EventsInCurrentRun,
+		IngestionRate:       ev.IngestionRate,
+		RemainingEvents:     ev.RemainingEvents,
+		LagSeconds:          ev.LagSeconds,
+		InvocationTimestamp: time.Now().Format("2006-01-02 15:04:05"),
+	}
+
+	select {
+	case cp.streamingStatsEventChan <- streamingStats:
+		cp.waitGroup.Add(1)
+	default:
+		log.Warnf("Could not publish streaming stats event %v", streamingStats)
+	}
+}
+
+func (cp *YugabyteD) CutoverInitiated(ev *controlPlane.CutoverInitiatedEvent) {
+	cp.createAndSendEvent(&ev.BaseEvent, "INITIATED", marshalPayload(ev))
+}
+
+func (cp *YugabyteD) CutoverProcessed(ev *controlPlane.CutoverProcessedEvent) {
+	cp.createAndSendEvent(&ev.BaseEvent, "IN PROGRESS", marshalPayload(ev))
+}
+
+func (cp *YugabyteD) CutoverCompleted(ev *controlPlane.CutoverCompletedEvent) {
+	cp.createAndSendEvent(&ev.BaseEvent, "COMPLETED", marshalPayload(ev))
+}
+
 func (cp *YugabyteD) MigrationEnded(migrationEndedEvent *controlPlane.MigrationEndedEvent) {
 }
 
+func (cp *YugabyteD) CommandFailed(ev *controlPlane.CommandFailedEvent) {
+	payload := marshalPayload(map[string]string{"Command": ev.Command, "ErrorMsg": ev.ErrorMsg})
+	cp.createAndSendEvent(&ev.BaseEvent, "ERROR", payload)
+}
+
+// marshalPayload returns the payload for the metadata table, empty if the payload can't be marshalled.
+func marshalPayload(payload any) string {
+	jsonBytes, err := json.Marshal(payload)
+	if err != nil {
+		log.Warnf("failed to marshal payload for visualization: %v", err)
+		return ""
+	}
+	return string(jsonBytes)
+}
+
 func (cp *YugabyteD) panicHandler() {
 	if r := recover(); r != nil {
 		// Handle the panic for eventPublishers
@@ -378,8 +447,15 @@ func (cp *YugabyteD) connect() error {
 		return nil
 	}
 	connectionUri := os.Getenv("YUGABYTED_DB_CONN_STRING")
+	poolConfig, err := pgxpool.ParseConfig(connectionUri)
+	if err != nil {
+		return fmt.Errorf("parse YUGABYTED_DB_CONN_STRING: %w", err)
+	}
+	if cp.password != "" {
+		poolConfig.ConnConfig.Password = cp.password
+	}
 
-	connPool, err := pgxpool.Connect(context.Background(), connectionUri)
+	connPool, err := pgxpool.ConnectConfig(context.Background(), poolConfig)
 	if err != nil {
 		return fmt.Errorf("error while connecting to yugabyted db. error: %w", err)
 	}
@@ -400,6 +476,7 @@ func (cp *YugabyteD) disconnect() {
 const VISUALIZER_METADATA_SCHEMA = "ybvoyager_visualizer"
 const VISUALIZER_METADATA_TABLE = "ybvoyager_visualizer_metadata"
 const VISUALIZER_METRICS_TABLE = "ybvoyager_visualizer_table_metrics"
+const VISUALIZER_STREAMING_STATS_TABLE = "ybvoyager_visualizer_streaming_stats"
 
 // Set-up YBD database for visualisation metadata
 func (cp *YugabyteD) setupDatabase() error {
@@ -418,6 +495,11 @@ func (cp *YugabyteD) setupDatabase() error {
 		return err
 	}
 
+	err = cp.createYugabytedStreamingStatsTable()
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -489,6 +571,26 @@ func (cp *YugabyteD) createYugabytedTableMetricsTable() error {
 	return cp.executeCmdOnTarget(cmd)
 }
 
+const YUGABYTED_STREAMING_STATS_TABLE_NAME = VISUALIZER_METADATA_SCHEMA + "." + VISUALIZER_STREAMING_STATS_TABLE
+
+// Create streaming stats table, one row per exporter/importer role of a migration
+func (cp *YugabyteD) createYugabytedStreamingStatsTable() error {
+	cmd := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			migration_uuid UUID,
+			migration_phase INT,
+			role VARCHAR(50),
+			total_events BIGINT,
+			events_in_current_run BIGINT,
+			ingestion_rate BIGINT,
+			remaining_events BIGINT,
+			lag_seconds BIGINT,
+			invocation_timestamp TIMESTAMPTZ,
+			PRIMARY KEY (migration_uuid, role)
+			);`, YUGABYTED_STREAMING_STATS_TABLE_NAME)
+
+	return cp.executeCmdOnTarget(cmd)
+}
+
 // Get the latest invocation sequence for a given migration_uuid and migration phase
 func (cp *YugabyteD) getInvocationSequence(mUUID uuid.UUID, phase int) (int, error) {
 
@@ -617,6 +719,44 @@ func (cp *YugabyteD) sendVisualizerTableMetrics(
 	return cp.executeCmdOnTarget(cmd)
 }
 
+// Send streaming stats
+func (cp *YugabyteD) sendVisualizerStreamingStats(stats VisualizerStreamingStats) error {
+	cmd := fmt.Sprintf("INSERT INTO %s ("+
+		"migration_uuid, "+
+		"migration_phase, "+
+		"role, "+
+		"total_events, "+
+		"events_in_current_run, "+
+		"ingestion_rate, "+
+		"remaining_events, "+
+		"lag_seconds, "+
+		"invocation_timestamp"+
+		") VALUES ('%s', %d, '%s', %d, %d, %d, %d, %d, '%s')",
+		YUGABYTED_STREAMING_STATS_TABLE_NAME,
+		stats.MigrationUUID,
+		stats.MigrationPhase,
+		stats.Role,
+		stats.TotalEvents,
+		stats.EventsInCurrentRun,
+		stats.IngestionRate,
+		stats.RemainingEvents,
+		stats.LagSeconds,
+		stats.InvocationTimestamp)
+
+	cmd += " ON CONFLICT (migration_uuid, role) " +
+		"DO UPDATE " +
+		"SET " +
+		"migration_phase = EXCLUDED.migration_phase," +
+		"total_events = EXCLUDED.total_events," +
+		"events_in_current_run = EXCLUDED.events_in_current_run," +
+		"ingestion_rate = EXCLUDED.ingestion_rate," +
+		"remaining_events = EXCLUDED.remaining_events," +
+		"lag_seconds = EXCLUDED.lag_seconds," +
+		"invocation_timestamp = EXCLUDED.invocation_timestamp;"
+
+	return cp.executeCmdOnTarget(cmd)
+}
+
 func (cp *YugabyteD) executeInsertQuery(cmd string,
 	migrationEvent MigrationEvent) error {
 
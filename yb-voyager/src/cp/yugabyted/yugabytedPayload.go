@@ -15,7 +15,11 @@ limitations under the License.
 */
 package yugabyted
 
-import "github.com/google/uuid"
+import (
+	"slices"
+
+	"github.com/google/uuid"
+)
 
 type MigrationEvent struct {
 	MigrationUUID       uuid.UUID `json:"migration_uuid"`
@@ -35,18 +39,42 @@ type MigrationEvent struct {
 }
 
 var MIGRATION_PHASE_MAP = map[string]int{
-	"ASSESS MIGRATION": 1,
-	"EXPORT SCHEMA":    2,
-	"ANALYZE SCHEMA":   3,
-	"EXPORT DATA":      4,
-	"IMPORT SCHEMA":    5,
-	"IMPORT DATA":      6,
+	"ASSESS MIGRATION":              1,
+	"EXPORT SCHEMA":                 2,
+	"ANALYZE SCHEMA":                3,
+	"EXPORT DATA":                   4,
+	"IMPORT SCHEMA":                 5,
+	"IMPORT DATA":                   6,
+	"EXPORT DATA FROM TARGET":       7,
+	"IMPORT DATA TO SOURCE REPLICA": 8,
+	"IMPORT DATA TO SOURCE":         9,
+	"CUTOVER":                       10,
+	"END MIGRATION":                 11,
 }
 
+// phases in which the DB details in the event are of the DB being exported from
+var EXPORT_PHASES = []string{"ASSESS MIGRATION", "EXPORT SCHEMA", "ANALYZE SCHEMA", "EXPORT DATA", "EXPORT DATA FROM TARGET"}
+
+// phases in which the DB details in the event are of the DB being imported into
+var IMPORT_PHASES = []string{"IMPORT SCHEMA", "IMPORT DATA", "IMPORT DATA TO SOURCE REPLICA", "IMPORT DATA TO SOURCE"}
+
 func isExportPhase(eventType string) bool {
-	return MIGRATION_PHASE_MAP[eventType] <= 4
+	return slices.Contains(EXPORT_PHASES, eventType)
 }
 
 func isImportPhase(eventType string) bool {
-	return MIGRATION_PHASE_MAP[eventType] > 4
+	return slices.Contains(IMPORT_PHASES, eventType)
+}
+
+// Streaming stats of an exporter/importer role during live migration.
+type VisualizerStreamingStats struct {
+	MigrationUUID       uuid.UUID `json:"migration_uuid"`
+	MigrationPhase      int       `json:"migration_phase"`
+	Role                string    `json:"role"`
+	TotalEvents         int64     `json:"total_events"`
+	EventsInCurrentRun  int64     `json:"events_in_current_run"`
+	IngestionRate       int64     `json:"ingestion_rate"`
+	RemainingEvents     int64     `json:"remaining_events"`
+	LagSeconds          int64     `json:"lag_seconds"`
+	InvocationTimestamp string    `json:"invocation_timestamp"`
 }
@@ -73,7 +73,7 @@ func TestYugabyteDTableSchema(t *testing.T) {
 		assert.NoError(t, err, "Failed to remove temporary export directory")
 	}()
 
-	controlPlane := New(exportDir)
+	controlPlane := New(exportDir, "")
 	controlPlane.eventChan = make(chan MigrationEvent, 100)
 	controlPlane.rowCountUpdateEventChan = make(chan []VisualizerTableMetrics, 200)
 
@@ -111,6 +111,17 @@ func TestYugabyteDTableSchema(t *testing.T) {
 			"count_total_rows":     {Type: "integer", IsNullable: "YES", Default: sql.NullString{Valid: false}, IsPrimary: false},
 			"invocation_timestamp": {Type: "timestamp with time zone", IsNullable: "YES", Default: sql.NullString{Valid: false}, IsPrimary: false},
 		},
+		YUGABYTED_STREAMING_STATS_TABLE_NAME: {
+			"migration_uuid":        {Type: "uuid", IsNullable: "NO", Default: sql.NullString{Valid: false}, IsPrimary: true},
+			"migration_phase":       {Type: "integer", IsNullable: "YES", Default: sql.NullString{Valid: false}, IsPrimary: false},
+			"role":                  {Type: "character varying", IsNullable: "NO", Default: sql.NullString{Valid: false}, IsPrimary: true},
+			"total_events":          {Type: "bigint", IsNullable: "YES", Default: sql.NullString{Valid: false}, IsPrimary: false},
+			"events_in_current_run": {Type: "bigint", IsNullable: "YES", Default: sql.NullString{Valid: false}, IsPrimary: false},
+			"ingestion_rate":        {Type: "bigint", IsNullable: "YES", Default: sql.NullString{Valid: false}, IsPrimary: false},
+			"remaining_events":      {Type: "bigint", IsNullable: "YES", Default: sql.NullString{Valid: false}, IsPrimary: false},
+			"lag_seconds":           {Type: "bigint", IsNullable: "YES", Default: sql.NullString{Valid: false}, IsPrimary: false},
+			"invocation_timestamp":  {Type: "timestamp with time zone", IsNullable: "YES", Default: sql.NullString{Valid: false}, IsPrimary: false},
+		},
 	}
 
 	// Validate the schema and tables
@@ -177,6 +188,22 @@ func TestYugabyteDStructs(t *testing.T) {
 		testutils.CompareStructs(t, reflect.TypeOf(VisualizerTableMetrics{}), reflect.TypeOf(expectedVisualizerTableMetrics), "VisualizerTableMetrics")
 	})
 
+	expectedVisualizerStreamingStats := struct {
+		MigrationUUID       uuid.UUID `json:"migration_uuid"`
+		MigrationPhase      int       `json:"migration_phase"`
+		Role                string    `json:"role"`
+		TotalEvents         int64     `json:"total_events"`
+		EventsInCurrentRun  int64     `json:"events_in_current_run"`
+		IngestionRate       int64     `json:"ingestion_rate"`
+		RemainingEvents     int64     `json:"remaining_events"`
+		LagSeconds          int64     `json:"lag_seconds"`
+		InvocationTimestamp string    `json:"invocation_timestamp"`
+	}{}
+
+	t.Run("Validate VisualizerStreamingStats Struct Definition", func(t *testing.T) {
+		testutils.CompareStructs(t, reflect.TypeOf(VisualizerStreamingStats{}), reflect.TypeOf(expectedVisualizerStreamingStats), "VisualizerStreamingStats")
+	})
+
 	expectedYugabyteD := struct {
 		sync.Mutex
 		migrationDirectory       string
@@ -184,6 +211,7 @@ func TestYugabyteDStructs(t *testing.T) {
 		waitGroup                sync.WaitGroup
 		eventChan                chan (MigrationEvent)
 		rowCountUpdateEventChan  chan ([]VisualizerTableMetrics)
+		streamingStatsEventChan  chan (VisualizerStreamingStats)
 		connPool                 *pgxpool.Pool
 		lastRowCountUpdate       map[string]time.Time
 		latestInvocationSequence int
@@ -0,0 +1,136 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package metadb
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	SCHEMA_STMT_PENDING = "PENDING"
+	SCHEMA_STMT_DONE    = "DONE"
+	SCHEMA_STMT_FAILED  = "FAILED"
+)
+
+// ImportSchemaStmt is the state of a single DDL statement executed by `import schema`.
+type ImportSchemaStmt struct {
+	FileName   string
+	StmtHash   string
+	ObjectType string
+	ObjectName string
+	Status     string
+	ErrorMsg   string
+	// DROP statement for the object created by this DDL. Empty if the DDL didn't create any object
+	// (for example ALTER statements) or if the object was already present on the target.
+	DropStmt  string
+	UpdatedAt int64
+}
+
+// GetSchemaStmtHash returns the hash used to identify a DDL statement across the runs of import schema.
+// Whitespaces are collapsed so that formatting-only differences don't change the hash.
+func GetSchemaStmtHash(stmt string) string {
+	normalizedStmt := strings.Join(strings.Fields(stmt), " ")
+	hash := sha256.Sum256([]byte(normalizedStmt))
+	return hex.EncodeToString(hash[:])
+}
+
+func (m *MetaDB) UpsertImportSchemaStmt(stmt *ImportSchemaStmt) error {
+	stmt.UpdatedAt = time.Now().UnixNano()
+	query := fmt.Sprintf(`INSERT INTO %s (file_name, stmt_hash, object_type, object_name, status, error_msg, drop_stmt, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(file_name, stmt_hash) DO UPDATE SET
+			object_type = COALESCE(NULLIF(excluded.object_type, ''), object_type),
+			object_name = COALESCE(NULLIF(excluded.object_name, ''), object_name),
+			status = excluded.status,
+			error_msg = excluded.error_msg,
+			drop_stmt = excluded.drop_stmt,
+			updated_at = excluded.updated_at;`, IMPORT_SCHEMA_STMTS_TABLE_NAME)
+	_, err := m.db.Exec(query, stmt.FileName, stmt.StmtHash, stmt.ObjectType, stmt.ObjectName,
+		stmt.Status, stmt.ErrorMsg, stmt.DropStmt, stmt.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("error while running query on meta db - %s :%w", query, err)
+	}
+	log.Infof("import schema stmt %s of file %q marked as %s", stmt.StmtHash, stmt.FileName, stmt.Status)
+	return nil
+}
+
+// GetImportSchemaStmtStatus returns the status of the statement, or an empty string if it was never executed.
+func (m *MetaDB) GetImportSchemaStmtStatus(fileName string, stmtHash string) (string, error) {
+	query := fmt.Sprintf(`SELECT status FROM %s WHERE file_name = ? AND stmt_hash = ?;`, IMPORT_SCHEMA_STMTS_TABLE_NAME)
+	var status string
+	err := m.db.QueryRow(query, fileName, stmtHash).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("error while running query on meta db - %s :%w", query, err)
+	}
+	return status, nil
+}
+
+// GetImportSchemaStmtsCreatingObjects returns the statements which created an object on the target,
+// latest first, which is the order in which the objects can be dropped.
+func (m *MetaDB) GetImportSchemaStmtsCreatingObjects() ([]*ImportSchemaStmt, error) {
+	query := fmt.Sprintf(`SELECT file_name, stmt_hash, object_type, object_name, status, error_msg, drop_stmt, updated_at
+		FROM %s WHERE status = '%s' AND drop_stmt != '' ORDER BY updated_at DESC;`, IMPORT_SCHEMA_STMTS_TABLE_NAME, SCHEMA_STMT_DONE)
+	rows, err := m.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("run query on meta db -%s :%v", query, err)
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			log.Errorf("failed to close rows while fetching import schema stmts from query %s : %v", query, err)
+		}
+	}()
+	var stmts []*ImportSchemaStmt
+	for rows.Next() {
+		stmt := &ImportSchemaStmt{}
+		err := rows.Scan(&stmt.FileName, &stmt.StmtHash, &stmt.ObjectType, &stmt.ObjectName,
+			&stmt.Status, &stmt.ErrorMsg, &stmt.DropStmt, &stmt.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan rows while fetching import schema stmts from query %s : %v", query, err)
+		}
+		stmts = append(stmts, stmt)
+	}
+	return stmts, rows.Err()
+}
+
+func (m *MetaDB) DeleteImportSchemaStmt(fileName string, stmtHash string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE file_name = ? AND stmt_hash = ?;`, IMPORT_SCHEMA_STMTS_TABLE_NAME)
+	_, err := m.db.Exec(query, fileName, stmtHash)
+	if err != nil {
+		return fmt.Errorf("error while running query on meta db -%s :%w", query, err)
+	}
+	return nil
+}
+
+func (m *MetaDB) ResetImportSchemaStmts() error {
+	query := fmt.Sprintf(`DELETE FROM %s;`, IMPORT_SCHEMA_STMTS_TABLE_NAME)
+	_, err := m.db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("error while running query on meta db -%s :%w", query, err)
+	}
+	log.Infof("Executed query on meta db - %s", query)
+	return nil
+}
@@ -0,0 +1,103 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package metadb
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportSchemaStmts(t *testing.T) {
+	exportDir := t.TempDir()
+	require.NoError(t, CreateAndInitMetaDBIfRequired(exportDir))
+	m, err := NewMetaDB(exportDir)
+	require.NoError(t, err)
+
+	createTable := GetSchemaStmtHash("CREATE TABLE public.orders (id int);")
+	assert.Equal(t, createTable, GetSchemaStmtHash("CREATE TABLE public.orders\n\t(id int);"))
+	createView := GetSchemaStmtHash("CREATE VIEW public.v AS SELECT * FROM public.orders;")
+	alterTable := GetSchemaStmtHash("ALTER TABLE public.orders ADD COLUMN amount int;")
+
+	status, err := m.GetImportSchemaStmtStatus("table.sql", createTable)
+	require.NoError(t, err)
+	assert.Equal(t, "", status)
+
+	stmts := []*ImportSchemaStmt{
+		{FileName: "table.sql", StmtHash: createTable, ObjectType: "TABLE", ObjectName: "public.orders", Status: SCHEMA_STMT_DONE, DropStmt: "DROP TABLE IF EXISTS public.orders"},
+		{FileName: "table.sql", StmtHash: alterTable, ObjectType: "TABLE", ObjectName: "public.orders", Status: SCHEMA_STMT_DONE},
+		{FileName: "view.sql", StmtHash: createView, ObjectType: "VIEW", ObjectName: "public.v", Status: SCHEMA_STMT_FAILED, ErrorMsg: "syntax error"},
+	}
+	for _, stmt := range stmts {
+		require.NoError(t, m.UpsertImportSchemaStmt(stmt))
+	}
+	status, err = m.GetImportSchemaStmtStatus("view.sql", createView)
+	require.NoError(t, err)
+	assert.Equal(t, SCHEMA_STMT_FAILED, status)
+
+	// retry of the failed statement succeeds, the object type and name are retained if not passed
+	require.NoError(t, m.UpsertImportSchemaStmt(&ImportSchemaStmt{FileName: "view.sql", StmtHash: createView,
+		Status: SCHEMA_STMT_DONE, DropStmt: "DROP VIEW IF EXISTS public.v"}))
+	status, err = m.GetImportSchemaStmtStatus("view.sql", createView)
+	require.NoError(t, err)
+	assert.Equal(t, SCHEMA_STMT_DONE, status)
+
+	// only the statements which created objects, latest first
+	created, err := m.GetImportSchemaStmtsCreatingObjects()
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+	assert.Equal(t, "public.v", created[0].ObjectName)
+	assert.Equal(t, "VIEW", created[0].ObjectType)
+	assert.Equal(t, "", created[0].ErrorMsg)
+	assert.Equal(t, "DROP TABLE IF EXISTS public.orders", created[1].DropStmt)
+
+	require.NoError(t, m.DeleteImportSchemaStmt("view.sql", createView))
+	created, err = m.GetImportSchemaStmtsCreatingObjects()
+	require.NoError(t, err)
+	assert.Len(t, created, 1)
+
+	require.NoError(t, m.ResetImportSchemaStmts())
+	for _, stmt := range stmts {
+		status, err = m.GetImportSchemaStmtStatus(stmt.FileName, stmt.StmtHash)
+		require.NoError(t, err)
+		assert.Equal(t, "", status)
+	}
+}
+
+func TestImportSchemaStmtsTableCreatedInExistingMetaDB(t *testing.T) {
+	// meta db created by a version without the import schema stmts table
+	exportDir := t.TempDir()
+	require.NoError(t, CreateAndInitMetaDBIfRequired(exportDir))
+	db, err := sql.Open("sqlite3", GetMetaDBPath(exportDir))
+	require.NoError(t, err)
+	_, err = db.Exec(fmt.Sprintf("DROP TABLE %s", IMPORT_SCHEMA_STMTS_TABLE_NAME))
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	require.NoError(t, CreateAndInitMetaDBIfRequired(exportDir))
+	m, err := NewMetaDB(exportDir)
+	require.NoError(t, err)
+	require.NoError(t, m.UpsertImportSchemaStmt(&ImportSchemaStmt{FileName: "table.sql", StmtHash: "h1", Status: SCHEMA_STMT_DONE}))
+	status, err := m.GetImportSchemaStmtStatus("table.sql", "h1")
+	require.NoError(t, err)
+	assert.Equal(t, SCHEMA_STMT_DONE, status)
+}
@@ -39,6 +39,7 @@ var (
 	EXPORTED_EVENTS_STATS_TABLE_NAME           = "exported_events_stats"
 	EXPORTED_EVENTS_STATS_PER_TABLE_TABLE_NAME = "exported_events_stats_per_table"
 	JSON_OBJECTS_TABLE_NAME                    = "json_objects"
+	IMPORT_SCHEMA_STMTS_TABLE_NAME             = "import_schema_stmts"
 	TARGET_DB_IDENTITY_COLUMNS_KEY             = "target_db_identity_columns_key"
 	FF_DB_IDENTITY_COLUMNS_KEY                 = "ff_db_identity_columns_key"
 	SOURCE_INDEXES_INFO_KEY                    = "source_indexes_info_key"
@@ -55,8 +56,8 @@ func GetMetaDBPath(exportDir string) string {
 func CreateAndInitMetaDBIfRequired(exportDir string) error {
 	metaDBPath := GetMetaDBPath(exportDir)
 	if utils.FileOrFolderExists(metaDBPath) {
-		// already created and initiated
-		return nil
+		// already created and initiated, only the tables added in the later versions need to be created
+		return upgradeMetaDB(metaDBPath)
 	}
 	err := createMetaDBFile(metaDBPath)
 	if err != nil {
@@ -125,6 +126,7 @@ func initMetaDB(path string) error {
 		fmt.Sprintf(`CREATE TABLE %s (
 			key TEXT PRIMARY KEY,
 			json_text TEXT);`, JSON_OBJECTS_TABLE_NAME),
+		importSchemaStmtsTableDDL,
 	}
 	for _, cmd := range cmds {
 		_, err = conn.Exec(cmd)
@@ -136,6 +138,32 @@ func initMetaDB(path string) error {
 	return nil
 }
 
+// tables added after the meta db of an export dir might have been created by an older version of voyager
+var importSchemaStmtsTableDDL = fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			file_name TEXT,
+			stmt_hash TEXT,
+			object_type TEXT,
+			object_name TEXT,
+			status TEXT,
+			error_msg TEXT,
+			drop_stmt TEXT,
+			updated_at INTEGER,
+			PRIMARY KEY(file_name, stmt_hash) );`, IMPORT_SCHEMA_STMTS_TABLE_NAME)
+
+// upgradeMetaDB creates the tables which are missing in an existing meta db e.g. of an export dir created by an older version
+func upgradeMetaDB(path string) error {
+	conn, err := sql.Open("sqlite3", fmt.Sprintf("%s%s", path, SQLITE_OPTIONS))
+	if err != nil {
+		return fmt.Errorf("error while opening meta db :%w", err)
+	}
+	defer conn.Close()
+	_, err = conn.Exec(importSchemaStmtsTableDDL)
+	if err != nil {
+		return fmt.Errorf("error while upgrading meta db with query-%s :%w", importSchemaStmtsTableDDL, err)
+	}
+	return nil
+}
+
 func TruncateTablesInMetaDb(exportDir string, tableNames []string) error {
 	conn, err := sql.Open("sqlite3", fmt.Sprintf("%s%s", GetMetaDBPath(exportDir), SQLITE_OPTIONS))
 	defer func() {
@@ -172,6 +200,15 @@ func NewMetaDB(exportDir string) (*MetaDB, error) {
 	return &MetaDB{db: db}, nil
 }
 
+// NewReadOnlyMetaDB opens the meta db for the commands which only inspect the migration, while other commands may be running on it.
+func NewReadOnlyMetaDB(exportDir string) (*MetaDB, error) {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?mode=ro&_timeout=30000", GetMetaDBPath(exportDir)))
+	if err != nil {
+		return nil, fmt.Errorf("error while opening meta db in read-only mode :%w", err)
+	}
+	return &MetaDB{db: db}, nil
+}
+
 func (m *MetaDB) MarkEventQueueSegmentAsProcessed(segmentNum int64, importerRole string) error {
 	query := fmt.Sprintf(`UPDATE %s SET imported_by_%s = 1 WHERE segment_no = %d;`, QUEUE_SEGMENT_META_TABLE_NAME, importerRole, segmentNum)
 
@@ -392,6 +429,167 @@ func (m *MetaDB) GetMinSegmentExportedByAndNotImportedBy(importerRole string, ex
 	return segmentNum.Int64, nil
 }
 
+func (m *MetaDB) GetNumSegmentsExportedByAndNotImportedBy(importerRole string, exporterRole string) (int64, error) {
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE imported_by_%s = 0`, QUEUE_SEGMENT_META_TABLE_NAME, importerRole)
+	if exporterRole != "" {
+		query = fmt.Sprintf("%s AND exporter_role = '%s'", query, exporterRole)
+	}
+	query = fmt.Sprintf("%s;", query)
+
+	var numSegments int64
+	err := m.db.QueryRow(query).Scan(&numSegments)
+	if err != nil {
+		return -1, fmt.Errorf("run query on meta db - %s : %w", query, err)
+	}
+	return numSegments, nil
+}
+
+type QueueSegmentsStats struct {
+	ImportedEvents  int64
+	PendingSegments int64
+	PendingEvents   int64
+}
+
+// GetQueueSegmentsStats returns the events imported and yet to be imported by the importer from the segments written by the exporter
+func (m *MetaDB) GetQueueSegmentsStats(importerRole string, exporterRole string) (*QueueSegmentsStats, error) {
+	query := fmt.Sprintf(`SELECT COALESCE(SUM(CASE WHEN imported_by_%[2]s = 1 THEN total_events ELSE 0 END), 0),
+		COUNT(CASE WHEN imported_by_%[2]s = 0 THEN 1 END),
+		COALESCE(SUM(CASE WHEN imported_by_%[2]s = 0 THEN total_events ELSE 0 END), 0)
+		FROM %[1]s WHERE exporter_role = '%[3]s';`, QUEUE_SEGMENT_META_TABLE_NAME, importerRole, exporterRole)
+	var stats QueueSegmentsStats
+	err := m.db.QueryRow(query).Scan(&stats.ImportedEvents, &stats.PendingSegments, &stats.PendingEvents)
+	if err != nil {
+		return nil, fmt.Errorf("run query on meta db - %s : %w", query, err)
+	}
+	return &stats, nil
+}
+
+// GetTotalExportedEventsPerExporterRole returns the total events exported by each of the exporter roles across all the runs
+func (m *MetaDB) GetTotalExportedEventsPerExporterRole() (map[string]int64, error) {
+	query := fmt.Sprintf(`SELECT exporter_role, COALESCE(SUM(num_total), 0) FROM %s GROUP BY exporter_role`, EXPORTED_EVENTS_STATS_TABLE_NAME)
+	rows, err := m.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("run query on meta db - %s : %w", query, err)
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			log.Errorf("failed to close rows of query %s : %v", query, err)
+		}
+	}()
+	result := make(map[string]int64)
+	for rows.Next() {
+		var exporterRole string
+		var totalCount int64
+		err := rows.Scan(&exporterRole, &totalCount)
+		if err != nil {
+			return nil, fmt.Errorf("scan rows of query %s : %w", query, err)
+		}
+		result[exporterRole] = totalCount
+	}
+	return result, rows.Err()
+}
+
+type TableExportedEventsStats struct {
+	ExporterRole string
+	SchemaName   string
+	TableName    string
+	tgtdb.EventCounter
+}
+
+// GetTableNames returns the names of all the tables in the meta db
+func (m *MetaDB) GetTableNames() ([]string, error) {
+	query := `SELECT name FROM sqlite_master WHERE type = 'table' ORDER BY name`
+	rows, err := m.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("run query on meta db - %s : %w", query, err)
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			log.Errorf("failed to close rows of query %s : %v", query, err)
+		}
+	}()
+	var result []string
+	for rows.Next() {
+		var tableName string
+		err := rows.Scan(&tableName)
+		if err != nil {
+			return nil, fmt.Errorf("scan rows of query %s : %w", query, err)
+		}
+		result = append(result, tableName)
+	}
+	return result, rows.Err()
+}
+
+// GetTableRows returns all the rows of the meta db table as column name -> value maps, with the TEXT/BLOB values as strings
+func (m *MetaDB) GetTableRows(tableName string) ([]map[string]any, error) {
+	query := fmt.Sprintf(`SELECT * FROM %q`, tableName)
+	rows, err := m.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("run query on meta db - %s : %w", query, err)
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			log.Errorf("failed to close rows of query %s : %v", query, err)
+		}
+	}()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("get columns of query %s : %w", query, err)
+	}
+	var result []map[string]any
+	for rows.Next() {
+		values := make([]any, len(columns))
+		valuePtrs := make([]any, len(columns))
+		for i := range values {
+			valuePtrs[i] = &values[i]
+		}
+		err := rows.Scan(valuePtrs...)
+		if err != nil {
+			return nil, fmt.Errorf("scan rows of query %s : %w", query, err)
+		}
+		row := make(map[string]any, len(columns))
+		for i, column := range columns {
+			if b, ok := values[i].([]byte); ok {
+				values[i] = string(b)
+			}
+			row[column] = values[i]
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// GetExportedEventsStatsPerTable returns the exported events stats of all the tables as stored by the exporters, without
+// resolving the table names in the name registry
+func (m *MetaDB) GetExportedEventsStatsPerTable() ([]*TableExportedEventsStats, error) {
+	query := fmt.Sprintf(`SELECT exporter_role, schema_name, table_name, COALESCE(num_total, 0), COALESCE(num_inserts, 0),
+		COALESCE(num_updates, 0), COALESCE(num_deletes, 0) FROM %s WHERE table_name != 'null'`, EXPORTED_EVENTS_STATS_PER_TABLE_TABLE_NAME)
+	rows, err := m.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("run query on meta db - %s : %w", query, err)
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			log.Errorf("failed to close rows of query %s : %v", query, err)
+		}
+	}()
+	var result []*TableExportedEventsStats
+	for rows.Next() {
+		stats := &TableExportedEventsStats{}
+		err := rows.Scan(&stats.ExporterRole, &stats.SchemaName, &stats.TableName, &stats.TotalEvents,
+			&stats.NumInserts, &stats.NumUpdates, &stats.NumDeletes)
+		if err != nil {
+			return nil, fmt.Errorf("scan rows of query %s : %w", query, err)
+		}
+		result = append(result, stats)
+	}
+	return result, rows.Err()
+}
+
 func (m *MetaDB) GetExportedEventsStatsForTable(schemaName string, tableName string) (*tgtdb.EventCounter, error) {
 	var totalCount int64
 	var inserts int64
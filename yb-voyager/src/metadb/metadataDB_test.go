@@ -24,6 +24,8 @@ import (
 	"testing"
 
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+
 	testutils "github.com/yugabyte/yb-voyager/yb-voyager/test/utils"
 )
 
@@ -67,6 +69,16 @@ func TestInitMetaDB(t *testing.T) {
 			"key":       {Type: "TEXT", PrimaryKey: 1},
 			"json_text": {Type: "TEXT"},
 		},
+		IMPORT_SCHEMA_STMTS_TABLE_NAME: {
+			"file_name":   {Type: "TEXT", PrimaryKey: 1},
+			"stmt_hash":   {Type: "TEXT", PrimaryKey: 2},
+			"object_type": {Type: "TEXT"},
+			"object_name": {Type: "TEXT"},
+			"status":      {Type: "TEXT"},
+			"error_msg":   {Type: "TEXT"},
+			"drop_stmt":   {Type: "TEXT"},
+			"updated_at":  {Type: "INTEGER"},
+		},
 	}
 
 	// Create a temporary SQLite database file for testing
@@ -116,3 +128,61 @@ func TestInitMetaDB(t *testing.T) {
 		})
 	}
 }
+
+func TestQueueSegmentsAndExportedEventsStats(t *testing.T) {
+	exportDir := t.TempDir()
+	assert.NoError(t, CreateAndInitMetaDBIfRequired(exportDir))
+	m, err := NewMetaDB(exportDir)
+	assert.NoError(t, err)
+
+	stmts := []string{
+		`INSERT INTO queue_segment_meta (segment_no, total_events, exporter_role, imported_by_target_db_importer) VALUES (0, 100, 'source_db_exporter', 1)`,
+		`INSERT INTO queue_segment_meta (segment_no, total_events, exporter_role) VALUES (1, 40, 'source_db_exporter')`,
+		`INSERT INTO queue_segment_meta (segment_no, total_events, exporter_role) VALUES (2, 5, 'target_db_exporter_ff')`,
+		`INSERT INTO exported_events_stats VALUES ('run1', 'source_db_exporter', 1, 100, 80, 15, 5)`,
+		`INSERT INTO exported_events_stats VALUES ('run2', 'source_db_exporter', 2, 40, 40, 0, 0)`,
+		`INSERT INTO exported_events_stats VALUES ('run3', 'target_db_exporter_ff', 3, 5, 5, 0, 0)`,
+		`INSERT INTO exported_events_stats_per_table VALUES ('source_db_exporter', 'public', 'orders', 140, 120, 15, 5)`,
+		`INSERT INTO exported_events_stats_per_table VALUES ('source_db_exporter', '', 'null', 0, 0, 0, 0)`,
+	}
+	for _, stmt := range stmts {
+		_, err := m.db.Exec(stmt)
+		assert.NoError(t, err)
+	}
+
+	stats, err := m.GetQueueSegmentsStats("target_db_importer", "source_db_exporter")
+	assert.NoError(t, err)
+	assert.Equal(t, &QueueSegmentsStats{ImportedEvents: 100, PendingSegments: 1, PendingEvents: 40}, stats)
+	stats, err = m.GetQueueSegmentsStats("source_replica_db_importer", "target_db_exporter_ff")
+	assert.NoError(t, err)
+	assert.Equal(t, &QueueSegmentsStats{ImportedEvents: 0, PendingSegments: 1, PendingEvents: 5}, stats)
+
+	totals, err := m.GetTotalExportedEventsPerExporterRole()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"source_db_exporter": 140, "target_db_exporter_ff": 5}, totals)
+
+	tableStats, err := m.GetExportedEventsStatsPerTable()
+	assert.NoError(t, err)
+	assert.Len(t, tableStats, 1)
+	assert.Equal(t, "orders", tableStats[0].TableName)
+	assert.Equal(t, int64(15), tableStats[0].NumUpdates)
+}
+
+func TestGetTableRows(t *testing.T) {
+	exportDir := t.TempDir()
+	assert.NoError(t, CreateAndInitMetaDBIfRequired(exportDir))
+	m, err := NewMetaDB(exportDir)
+	assert.NoError(t, err)
+
+	tableNames, err := m.GetTableNames()
+	assert.NoError(t, err)
+	assert.Subset(t, tableNames, []string{QUEUE_SEGMENT_META_TABLE_NAME, JSON_OBJECTS_TABLE_NAME, EXPORTED_EVENTS_STATS_TABLE_NAME})
+
+	_, err = m.db.Exec(`INSERT INTO exported_events_stats_per_table VALUES ('source_db_exporter', 'public', 'orders', 140, 120, 15, 5)`)
+	assert.NoError(t, err)
+	rows, err := m.GetTableRows(EXPORTED_EVENTS_STATS_PER_TABLE_TABLE_NAME)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, "orders", rows[0]["table_name"])
+	assert.EqualValues(t, 140, rows[0]["num_total"])
+}
@@ -2,6 +2,7 @@ package metadb
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -53,6 +54,18 @@ type MigrationStatusRecord struct {
 	IsExportTableListSet             bool              `json:"IsExportTableListSet"`
 	MigrationAssessmentDone          bool              `json:"MigrationAssessmentDone"`
 	AssessmentRecommendationsApplied bool              `json:"AssessmentRecommendationsApplied"`
+
+	// keyed by the migration phase e.g. "EXPORT DATA", "IMPORT DATA TO SOURCE REPLICA", "CUTOVER TO TARGET"
+	PhaseTimestamps map[string]*PhaseTimestamps `json:"PhaseTimestamps"`
+}
+
+// PhaseTimestamps are zero for the events which haven't happened yet.
+// SnapshotCompletedAt and StreamingStartedAt are applicable only to the export/import data phases.
+type PhaseTimestamps struct {
+	StartedAt           time.Time `json:"StartedAt"`
+	SnapshotCompletedAt time.Time `json:"SnapshotCompletedAt"`
+	StreamingStartedAt  time.Time `json:"StreamingStartedAt"`
+	CompletedAt         time.Time `json:"CompletedAt"`
 }
 
 const MIGRATION_STATUS_KEY = "migration_status"
@@ -61,6 +74,18 @@ func (m *MetaDB) UpdateMigrationStatusRecord(updateFn func(*MigrationStatusRecor
 	return UpdateJsonObjectInMetaDB(m, MIGRATION_STATUS_KEY, updateFn)
 }
 
+func (m *MetaDB) UpdatePhaseTimestamps(phase string, updateFn func(*PhaseTimestamps)) error {
+	return m.UpdateMigrationStatusRecord(func(record *MigrationStatusRecord) {
+		if record.PhaseTimestamps == nil {
+			record.PhaseTimestamps = make(map[string]*PhaseTimestamps)
+		}
+		if record.PhaseTimestamps[phase] == nil {
+			record.PhaseTimestamps[phase] = &PhaseTimestamps{}
+		}
+		updateFn(record.PhaseTimestamps[phase])
+	})
+}
+
 func (m *MetaDB) GetMigrationStatusRecord() (*MigrationStatusRecord, error) {
 	record := new(MigrationStatusRecord)
 	found, err := m.GetJsonObject(nil, MIGRATION_STATUS_KEY, record)
@@ -0,0 +1,77 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package metrics
+
+/*
+Metrics exposed by the long running commands(export data, import data, import data file, etc.) on the
+address passed in the --metrics-listen-address flag.
+
+The metric names and labels are part of the user facing interface(alerts/dashboards are written on them),
+so existing metrics should not be renamed or have their labels changed; add new ones instead.
+*/
+
+var DefaultRegistry = NewRegistry()
+
+// upper bounds(in seconds) of the COPY latency buckets
+var copyDurationBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300}
+
+var (
+	// gauge since it is set to the row counts tracked by the exporters(debezium or the data file line counts) rather than incremented
+	ExportedRows = NewGauge("yb_voyager_export_rows",
+		"Number of rows exported from the source database, per table.", "table")
+
+	ImportedRows = NewCounter("yb_voyager_import_rows_total",
+		"Number of rows imported into the target database by the current run, per table.", "table")
+
+	ImportBatchesInFlight = NewGauge("yb_voyager_import_batches_in_flight",
+		"Number of batches submitted for import which are not yet imported.")
+
+	ImportCopyDuration = NewHistogram("yb_voyager_import_copy_duration_seconds",
+		"Time taken by each attempt of importing a batch(COPY) into the target database.", copyDurationBuckets)
+
+	ImportConnectionPoolSize = NewGaugeFunc("yb_voyager_import_connection_pool_size",
+		"Current number of connections in the target database connection pool (changes with adaptive parallelism).")
+
+	ImportConnectionPoolMaxSize = NewGaugeFunc("yb_voyager_import_connection_pool_max_size",
+		"Maximum number of connections allowed in the target database connection pool.")
+
+	ImportedEvents = NewCounter("yb_voyager_import_events_total",
+		"Number of change events imported by the current run, per operation(insert, update, delete). Use rate() for the ingestion rate.", "operation")
+
+	ImportRemainingEvents = NewGauge("yb_voyager_import_remaining_events",
+		"Number of change events exported but not yet imported.")
+
+	ImportSegmentBacklog = NewGauge("yb_voyager_import_segment_backlog",
+		"Number of event queue segments exported but not yet imported.")
+)
+
+func init() {
+	DefaultRegistry.MustRegister(
+		ExportedRows,
+		ImportedRows,
+		ImportBatchesInFlight,
+		ImportCopyDuration,
+		ImportConnectionPoolSize,
+		ImportConnectionPoolMaxSize,
+		ImportedEvents,
+		ImportRemainingEvents,
+		ImportSegmentBacklog,
+	)
+}
+
+func StartServer(addr string) error {
+	return DefaultRegistry.StartServer(addr)
+}
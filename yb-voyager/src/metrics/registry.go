@@ -0,0 +1,357 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	COUNTER   = "counter"
+	GAUGE     = "gauge"
+	HISTOGRAM = "histogram"
+
+	// version 0.0.4 of the prometheus text exposition format
+	CONTENT_TYPE = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// Collector is implemented by all the metric types that can be registered in a Registry.
+type Collector interface {
+	Name() string
+	// writeTo writes the HELP, TYPE and sample lines of the metric in the prometheus text format.
+	writeTo(w io.Writer)
+}
+
+type Registry struct {
+	mu         sync.Mutex
+	collectors map[string]Collector
+}
+
+func NewRegistry() *Registry {
+	return &Registry{collectors: make(map[string]Collector)}
+}
+
+func (r *Registry) MustRegister(collectors ...Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range collectors {
+		if _, ok := r.collectors[c.Name()]; ok {
+			panic(fmt.Sprintf("metric %q is already registered", c.Name()))
+		}
+		r.collectors[c.Name()] = c
+	}
+}
+
+// Write writes all the registered metrics, sorted by name, in the prometheus text format.
+func (r *Registry) Write(w io.Writer) {
+	r.mu.Lock()
+	collectors := make([]Collector, 0, len(r.collectors))
+	for _, c := range r.collectors {
+		collectors = append(collectors, c)
+	}
+	r.mu.Unlock()
+
+	sort.Slice(collectors, func(i, j int) bool {
+		return collectors[i].Name() < collectors[j].Name()
+	})
+	for _, c := range collectors {
+		c.writeTo(w)
+	}
+}
+
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var buf bytes.Buffer
+		r.Write(&buf)
+		w.Header().Set("Content-Type", CONTENT_TYPE)
+		_, err := w.Write(buf.Bytes())
+		if err != nil {
+			log.Warnf("writing metrics response: %v", err)
+		}
+	})
+}
+
+// StartServer starts listening on the given address and serves the metrics of the registry on /metrics.
+// Binding to the address is done synchronously so that an invalid/busy address is reported to the caller.
+func (r *Registry) StartServer(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen on %q: %w", addr, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", r.Handler())
+	go func() {
+		err := http.Serve(listener, mux)
+		if err != nil {
+			log.Errorf("metrics server on %q stopped: %v", addr, err)
+		}
+	}()
+	log.Infof("serving metrics on http://%s/metrics", listener.Addr())
+	return nil
+}
+
+//=========================================================================================
+
+type desc struct {
+	name       string
+	help       string
+	metricType string
+	labelNames []string
+}
+
+func (d *desc) Name() string {
+	return d.name
+}
+
+func (d *desc) writeHeader(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.name, escapeHelp(d.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.name, d.metricType)
+}
+
+// labeledValues stores one value per combination of label values.
+type labeledValues struct {
+	desc
+	mu     sync.Mutex
+	values map[string]*labeledValue
+}
+
+type labeledValue struct {
+	labelValues []string
+	value       float64
+}
+
+func newLabeledValues(name, help, metricType string, labelNames []string) labeledValues {
+	return labeledValues{
+		desc:   desc{name: name, help: help, metricType: metricType, labelNames: labelNames},
+		values: make(map[string]*labeledValue),
+	}
+}
+
+func (l *labeledValues) update(labelValues []string, fn func(v float64) float64) {
+	if len(labelValues) != len(l.labelNames) {
+		panic(fmt.Sprintf("metric %q expects %d label values, got %d", l.name, len(l.labelNames), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lv, ok := l.values[key]
+	if !ok {
+		lv = &labeledValue{labelValues: append([]string{}, labelValues...)}
+		l.values[key] = lv
+	}
+	lv.value = fn(lv.value)
+}
+
+func (l *labeledValues) get(labelValues ...string) float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lv, ok := l.values[strings.Join(labelValues, "\xff")]
+	if !ok {
+		return 0
+	}
+	return lv.value
+}
+
+func (l *labeledValues) writeTo(w io.Writer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.writeHeader(w)
+	if len(l.labelNames) == 0 && len(l.values) == 0 {
+		// metrics without labels are always reported, even before the first update
+		fmt.Fprintf(w, "%s %s\n", l.name, formatValue(0))
+		return
+	}
+	keys := make([]string, 0, len(l.values))
+	for key := range l.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lv := l.values[key]
+		fmt.Fprintf(w, "%s%s %s\n", l.name, formatLabels(l.labelNames, lv.labelValues), formatValue(lv.value))
+	}
+}
+
+//=========================================================================================
+
+// Counter is a monotonically increasing value, optionally partitioned by labels.
+type Counter struct {
+	labeledValues
+}
+
+func NewCounter(name, help string, labelNames ...string) *Counter {
+	return &Counter{labeledValues: newLabeledValues(name, help, COUNTER, labelNames)}
+}
+
+func (c *Counter) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		panic(fmt.Sprintf("counter %q cannot decrease", c.name))
+	}
+	c.update(labelValues, func(v float64) float64 { return v + delta })
+}
+
+func (c *Counter) Get(labelValues ...string) float64 {
+	return c.get(labelValues...)
+}
+
+// Gauge is a value that can go up and down, optionally partitioned by labels.
+type Gauge struct {
+	labeledValues
+}
+
+func NewGauge(name, help string, labelNames ...string) *Gauge {
+	return &Gauge{labeledValues: newLabeledValues(name, help, GAUGE, labelNames)}
+}
+
+func (g *Gauge) Set(value float64, labelValues ...string) {
+	g.update(labelValues, func(float64) float64 { return value })
+}
+
+func (g *Gauge) Add(delta float64, labelValues ...string) {
+	g.update(labelValues, func(v float64) float64 { return v + delta })
+}
+
+func (g *Gauge) Inc(labelValues ...string) {
+	g.Add(1, labelValues...)
+}
+
+func (g *Gauge) Dec(labelValues ...string) {
+	g.Add(-1, labelValues...)
+}
+
+func (g *Gauge) Get(labelValues ...string) float64 {
+	return g.get(labelValues...)
+}
+
+// GaugeFunc is a gauge whose value is computed at the time of scraping.
+// It is not reported until the function is set.
+type GaugeFunc struct {
+	desc
+	mu sync.Mutex
+	fn func() float64
+}
+
+func NewGaugeFunc(name, help string) *GaugeFunc {
+	return &GaugeFunc{desc: desc{name: name, help: help, metricType: GAUGE}}
+}
+
+func (g *GaugeFunc) SetFunc(fn func() float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.fn = fn
+}
+
+func (g *GaugeFunc) writeTo(w io.Writer) {
+	g.mu.Lock()
+	fn := g.fn
+	g.mu.Unlock()
+	if fn == nil {
+		return
+	}
+	g.writeHeader(w)
+	fmt.Fprintf(w, "%s %s\n", g.name, formatValue(fn()))
+}
+
+// Histogram counts the observations in cumulative buckets defined by their upper bounds.
+type Histogram struct {
+	desc
+	mu           sync.Mutex
+	upperBounds  []float64
+	bucketCounts []uint64
+	count        uint64
+	sum          float64
+}
+
+func NewHistogram(name, help string, upperBounds []float64) *Histogram {
+	bounds := append([]float64{}, upperBounds...)
+	sort.Float64s(bounds)
+	return &Histogram{
+		desc:         desc{name: name, help: help, metricType: HISTOGRAM},
+		upperBounds:  bounds,
+		bucketCounts: make([]uint64, len(bounds)),
+	}
+}
+
+func (h *Histogram) Observe(value float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i, bound := range h.upperBounds {
+		if value <= bound {
+			h.bucketCounts[i]++
+		}
+	}
+	h.count++
+	h.sum += value
+}
+
+func (h *Histogram) writeTo(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.writeHeader(w)
+	for i, bound := range h.upperBounds {
+		fmt.Fprintf(w, "%s_bucket{le=\"%s\"} %d\n", h.name, formatValue(bound), h.bucketCounts[i])
+	}
+	fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n", h.name, h.count)
+	fmt.Fprintf(w, "%s_sum %s\n", h.name, formatValue(h.sum))
+	fmt.Fprintf(w, "%s_count %d\n", h.name, h.count)
+}
+
+//=========================================================================================
+
+func formatLabels(labelNames []string, labelValues []string) string {
+	if len(labelNames) == 0 {
+		return ""
+	}
+	pairs := make([]string, len(labelNames))
+	for i, name := range labelNames {
+		pairs[i] = fmt.Sprintf("%s=\"%s\"", name, escapeLabelValue(labelValues[i]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelValueEscaper.Replace(s)
+}
@@ -0,0 +1,99 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package metrics
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryTextFormat(t *testing.T) {
+	registry := NewRegistry()
+	rows := NewCounter("test_rows_total", "Rows per table.", "table")
+	inFlight := NewGauge("test_in_flight", "In flight.")
+	poolSize := NewGaugeFunc("test_pool_size", "Pool size.")
+	notSet := NewGaugeFunc("test_not_set", "Not set.")
+	latency := NewHistogram("test_duration_seconds", "Latency.", []float64{1, 0.5})
+	registry.MustRegister(rows, inFlight, poolSize, notSet, latency)
+
+	rows.Add(10, "public.orders")
+	rows.Add(5, `public."Weird""Name"`)
+	rows.Add(10, "public.orders")
+	inFlight.Inc()
+	inFlight.Inc()
+	inFlight.Dec()
+	poolSize.SetFunc(func() float64 { return 4 })
+	latency.Observe(0.25)
+	latency.Observe(0.75)
+	latency.Observe(3)
+
+	var buf bytes.Buffer
+	registry.Write(&buf)
+	expected := `# HELP test_duration_seconds Latency.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{le="0.5"} 1
+test_duration_seconds_bucket{le="1"} 2
+test_duration_seconds_bucket{le="+Inf"} 3
+test_duration_seconds_sum 4
+test_duration_seconds_count 3
+# HELP test_in_flight In flight.
+# TYPE test_in_flight gauge
+test_in_flight 1
+# HELP test_pool_size Pool size.
+# TYPE test_pool_size gauge
+test_pool_size 4
+# HELP test_rows_total Rows per table.
+# TYPE test_rows_total counter
+test_rows_total{table="public.\"Weird\"\"Name\""} 5
+test_rows_total{table="public.orders"} 20
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRegistryHandler(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister(NewGauge("test_gauge", "Gauge."))
+	assert.Panics(t, func() { registry.MustRegister(NewCounter("test_gauge", "Duplicate.")) })
+	assert.Panics(t, func() { NewCounter("test_counter", "Counter.").Add(-1) })
+
+	recorder := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(recorder.Result().Body)
+	assert.NoError(t, err)
+	assert.Equal(t, CONTENT_TYPE, recorder.Header().Get("Content-Type"))
+	// metrics without labels are reported before their first update
+	assert.Equal(t, "# HELP test_gauge Gauge.\n# TYPE test_gauge gauge\ntest_gauge 0\n", string(body))
+}
+
+func TestDefaultRegistryMetricNames(t *testing.T) {
+	var buf bytes.Buffer
+	DefaultRegistry.Write(&buf)
+	// the names are used in alerts and dashboards, changing them is a breaking change
+	for _, name := range []string{
+		"yb_voyager_import_batches_in_flight",
+		"yb_voyager_import_copy_duration_seconds",
+		"yb_voyager_import_remaining_events",
+		"yb_voyager_import_segment_backlog",
+	} {
+		assert.Contains(t, buf.String(), "# TYPE "+name+" ")
+	}
+}
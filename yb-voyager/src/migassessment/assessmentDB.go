@@ -39,6 +39,10 @@ const (
 	TABLE_COLUMNS_DATA_TYPES = "table_columns_data_types"
 	TABLE_INDEX_STATS        = "table_index_stats"
 	DB_QUERIES_SUMMARY       = "db_queries_summary"
+	INDEX_LEADING_COLUMNS    = "index_leading_columns"
+	INDEX_DEFINITIONS        = "index_definitions"
+	COLUMN_DATA_PROFILE      = "column_data_profile"
+	TABLE_ROW_WIDTHS         = "table_row_widths"
 
 	PARTITIONED_TABLE_OBJECT_TYPE = "partitioned table"
 	PARTITIONED_INDEX_OBJECT_TYPE = "partitioned index"
@@ -83,6 +87,7 @@ func InitAssessmentDB() error {
 			seq_reads		INTEGER,
 			row_writes		INTEGER,
 			measurement_type TEXT,
+			measurement_time INTEGER,
 			PRIMARY KEY (schema_name, object_name, measurement_type));`, TABLE_INDEX_IOPS),
 		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
 			schema_name		TEXT,
@@ -118,6 +123,45 @@ func InitAssessmentDB() error {
 			column_name		TEXT,
 			data_type		TEXT,
 			PRIMARY KEY (schema_name, table_name, column_name));`, TABLE_COLUMNS_DATA_TYPES),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			schema_name		TEXT,
+			table_name		TEXT,
+			index_name		TEXT,
+			is_primary_key	BOOLEAN,
+			column_name		TEXT,
+			is_identity		BOOLEAN,
+			owned_sequence	TEXT,
+			column_default	TEXT,
+			PRIMARY KEY (schema_name, index_name));`, INDEX_LEADING_COLUMNS),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			schema_name		TEXT,
+			index_name		TEXT,
+			table_name		TEXT,
+			index_method	TEXT,
+			is_primary_key	BOOLEAN,
+			is_unique		BOOLEAN,
+			is_constraint	BOOLEAN,
+			key_columns		TEXT,
+			include_columns	TEXT,
+			predicate		TEXT,
+			PRIMARY KEY (schema_name, index_name));`, INDEX_DEFINITIONS),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			schema_name		TEXT,
+			table_name		TEXT,
+			column_name		TEXT,
+			null_frac		REAL,
+			distinct_count	INTEGER,
+			avg_width		REAL,
+			max_width		INTEGER,
+			top_value_freq	REAL,
+			PRIMARY KEY (schema_name, table_name, column_name));`, COLUMN_DATA_PROFILE),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			schema_name		TEXT,
+			table_name		TEXT,
+			sampled_rows	INTEGER,
+			avg_row_width	REAL,
+			max_row_width	INTEGER,
+			PRIMARY KEY (schema_name, table_name));`, TABLE_ROW_WIDTHS),
 		// derived from the above metric tables
 		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
 			schema_name         TEXT,
@@ -135,7 +179,10 @@ func InitAssessmentDB() error {
 			PRIMARY KEY(schema_name, object_name));`, TABLE_INDEX_STATS),
 		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
 			queryid			BIGINT,
-			query		TEXT);`, DB_QUERIES_SUMMARY),
+			query		TEXT,
+			calls			INTEGER,
+			total_exec_time	REAL,
+			mean_exec_time	REAL);`, DB_QUERIES_SUMMARY),
 	}
 
 	for _, cmd := range cmds {
@@ -250,39 +297,6 @@ const (
 	LEFT JOIN %s tcc ON itm.index_schema = tcc.schema_name AND itm.index_name = tcc.object_name
 	LEFT JOIN %s otm ON itm.index_schema = otm.schema_name AND itm.index_name = otm.object_name
 	WHERE otm.object_type NOT IN ('%s', '%s');`
-
-	CreateTempTable = `CREATE TEMP TABLE read_write_rates AS
-	SELECT
-		initial.schema_name,
-		initial.object_name,
-		initial.object_type,
-		(final.seq_reads - initial.seq_reads) / 120 AS seq_reads_per_second,
-		(final.row_writes - initial.row_writes) / 120 AS row_writes_per_second
-	FROM
-		%s AS initial
-	JOIN
-		%s AS final ON initial.schema_name = final.schema_name
-								  AND initial.object_name = final.object_name
-								  AND final.measurement_type = 'final'
-	WHERE
-		initial.measurement_type = 'initial';`
-
-	UpdateStatsWithRates = `UPDATE table_index_stats
-	SET
-		reads_per_second = (SELECT seq_reads_per_second
-							FROM read_write_rates
-							WHERE read_write_rates.schema_name = table_index_stats.schema_name
-							  AND read_write_rates.object_name = table_index_stats.object_name),
-		writes_per_second = (SELECT row_writes_per_second
-							 FROM read_write_rates
-							 WHERE read_write_rates.schema_name = table_index_stats.schema_name
-							   AND read_write_rates.object_name = table_index_stats.object_name)
-	WHERE EXISTS (
-		SELECT 1
-		FROM read_write_rates
-		WHERE read_write_rates.schema_name = table_index_stats.schema_name
-		  AND read_write_rates.object_name = table_index_stats.object_name
-	);`
 )
 
 // populate table_index_stats table using the data from other tables
@@ -294,17 +308,6 @@ func (adb *AssessmentDB) PopulateMigrationAssessmentStats() error {
 			TABLE_COLUMNS_COUNT, OBJECT_TYPE_MAPPING, PARTITIONED_TABLE_OBJECT_TYPE, PARTITIONED_INDEX_OBJECT_TYPE),
 	}
 
-	switch SourceDBType {
-	case "postgresql":
-		statements = append(statements,
-			fmt.Sprintf(CreateTempTable, TABLE_INDEX_IOPS, TABLE_INDEX_IOPS),
-			UpdateStatsWithRates)
-	case "oracle":
-		// already accounted
-	default:
-		panic("invalid source db type")
-	}
-
 	for _, stmt := range statements {
 		log.Infof("executing query for populating migration assessment stats- %s", stmt)
 		if _, err := adb.db.Exec(stmt); err != nil {
@@ -312,6 +315,17 @@ func (adb *AssessmentDB) PopulateMigrationAssessmentStats() error {
 		}
 	}
 
+	switch SourceDBType {
+	case "postgresql", "mysql":
+		err := adb.updateStatsWithPeakRates()
+		if err != nil {
+			return fmt.Errorf("error updating the reads/writes per second: %w", err)
+		}
+	case "oracle":
+		// already accounted
+	default:
+		panic("invalid source db type")
+	}
 	return nil
 }
 
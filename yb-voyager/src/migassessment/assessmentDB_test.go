@@ -36,6 +36,7 @@ func TestInitAssessmentDB(t *testing.T) {
 			"seq_reads":        {Type: "INTEGER"},
 			"row_writes":       {Type: "INTEGER"},
 			"measurement_type": {Type: "TEXT", PrimaryKey: 3},
+			"measurement_time": {Type: "INTEGER"},
 		},
 		TABLE_INDEX_SIZES: {
 			"schema_name":   {Type: "TEXT", PrimaryKey: 1},
@@ -71,6 +72,45 @@ func TestInitAssessmentDB(t *testing.T) {
 			"column_name": {Type: "TEXT", PrimaryKey: 3},
 			"data_type":   {Type: "TEXT"},
 		},
+		INDEX_LEADING_COLUMNS: {
+			"schema_name":    {Type: "TEXT", PrimaryKey: 1},
+			"table_name":     {Type: "TEXT"},
+			"index_name":     {Type: "TEXT", PrimaryKey: 2},
+			"is_primary_key": {Type: "BOOLEAN"},
+			"column_name":    {Type: "TEXT"},
+			"is_identity":    {Type: "BOOLEAN"},
+			"owned_sequence": {Type: "TEXT"},
+			"column_default": {Type: "TEXT"},
+		},
+		INDEX_DEFINITIONS: {
+			"schema_name":     {Type: "TEXT", PrimaryKey: 1},
+			"index_name":      {Type: "TEXT", PrimaryKey: 2},
+			"table_name":      {Type: "TEXT"},
+			"index_method":    {Type: "TEXT"},
+			"is_primary_key":  {Type: "BOOLEAN"},
+			"is_unique":       {Type: "BOOLEAN"},
+			"is_constraint":   {Type: "BOOLEAN"},
+			"key_columns":     {Type: "TEXT"},
+			"include_columns": {Type: "TEXT"},
+			"predicate":       {Type: "TEXT"},
+		},
+		COLUMN_DATA_PROFILE: {
+			"schema_name":    {Type: "TEXT", PrimaryKey: 1},
+			"table_name":     {Type: "TEXT", PrimaryKey: 2},
+			"column_name":    {Type: "TEXT", PrimaryKey: 3},
+			"null_frac":      {Type: "REAL"},
+			"distinct_count": {Type: "INTEGER"},
+			"avg_width":      {Type: "REAL"},
+			"max_width":      {Type: "INTEGER"},
+			"top_value_freq": {Type: "REAL"},
+		},
+		TABLE_ROW_WIDTHS: {
+			"schema_name":   {Type: "TEXT", PrimaryKey: 1},
+			"table_name":    {Type: "TEXT", PrimaryKey: 2},
+			"sampled_rows":  {Type: "INTEGER"},
+			"avg_row_width": {Type: "REAL"},
+			"max_row_width": {Type: "INTEGER"},
+		},
 		TABLE_INDEX_STATS: {
 			"schema_name":       {Type: "TEXT", PrimaryKey: 1},
 			"object_name":       {Type: "TEXT", PrimaryKey: 2},
@@ -86,8 +126,11 @@ func TestInitAssessmentDB(t *testing.T) {
 			"size_in_bytes":     {Type: "INTEGER"},
 		},
 		DB_QUERIES_SUMMARY: {
-			"queryid": {Type: "BIGINT"},
-			"query":   {Type: "TEXT"},
+			"queryid":         {Type: "BIGINT"},
+			"query":           {Type: "TEXT"},
+			"calls":           {Type: "INTEGER"},
+			"total_exec_time": {Type: "REAL"},
+			"mean_exec_time":  {Type: "REAL"},
 		},
 	}
 
@@ -37,11 +37,15 @@ type SizingRecommendation struct {
 	OptimalInsertConnectionsPerNode int64
 	EstimatedTimeInMinForImport     float64
 	ParallelVoyagerJobs             float64
+	ReplicationFactor               int
+	ExperimentDataset               string // experiment data the recommendation is based on
+	InstanceType                    string // instance type(s) of the recommended shape, if present in the experiment data
 }
 
 type SizingAssessmentReport struct {
 	SizingRecommendation SizingRecommendation
 	FailureReasoning     string
+	YearlySizing         []YearlySizingRecommendation `json:"YearlySizing,omitempty"`
 }
 
 func checkInternetAccess() (ok bool) {
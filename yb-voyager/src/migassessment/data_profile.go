@@ -0,0 +1,348 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package migassessment
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
+)
+
+/*
+Data profiling(optional, sampling based) records per column the null ratio, distinct count estimate, average/max width
+of the values and the frequency of the most common value(key skew) for the columns of the primary keys/indexes.
+
+DataProfileAssessment flags:
+  - oversized rows: rows larger than the max batch size(MAX_BATCH_SIZE_BYTES) fail the import, and wide rows make the
+    import batches smaller than the batch size in number of rows
+  - low cardinality leading index columns: all the rows of a hash sharded index go to as many tablets as the distinct
+    values of the leading column
+  - skewed shard keys: the tablet holding the most common value of the leading column gets a disproportionate share of
+    the data and the operations
+*/
+
+const (
+	// same as the defaults of the MaxBatchSizeInBytes and batch size in number of rows for YugabyteDB target
+	DEFAULT_MAX_BATCH_SIZE_BYTES      = 200 * 1024 * 1024
+	DEFAULT_IMPORT_BATCH_SIZE_IN_ROWS = 20000
+
+	// leading index columns with at most these many distinct values in a table having at least
+	// MIN_ROW_COUNT_FOR_DISTRIBUTION_CHECK rows are reported as low cardinality
+	LOW_CARDINALITY_DISTINCT_COUNT_THRESHOLD = 10
+	// fraction of the rows having the most common value of the leading index column above which it is reported as skewed
+	SKEWED_SHARD_KEY_TOP_VALUE_FREQ_THRESHOLD = 0.2
+	MIN_ROW_COUNT_FOR_DISTRIBUTION_CHECK      = 10000
+)
+
+type DataProfile struct {
+	Columns                    []ColumnDataProfile  `json:"Columns"`
+	OversizedRows              []OversizedRowTable  `json:"OversizedRows,omitempty"`
+	LowCardinalityIndexColumns []IndexColumnProfile `json:"LowCardinalityIndexColumns,omitempty"`
+	SkewedShardKeys            []IndexColumnProfile `json:"SkewedShardKeys,omitempty"`
+}
+
+type ColumnDataProfile struct {
+	SchemaName    string   `json:"SchemaName"`
+	TableName     string   `json:"TableName"`
+	ColumnName    string   `json:"ColumnName"`
+	NullRatio     *float64 `json:"NullRatio"`     // nil if the table is not analyzed on the source
+	DistinctCount *int64   `json:"DistinctCount"` // estimate
+	AvgWidth      *float64 `json:"AvgWidth"`      // in bytes, of the text representation
+	MaxWidth      *int64   `json:"MaxWidth"`
+	KeySkew       *float64 `json:"KeySkew"` // fraction of the rows having the most common value, only for primary key/index columns
+}
+
+type OversizedRowTable struct {
+	SchemaName          string  `json:"SchemaName"`
+	TableName           string  `json:"TableName"`
+	AvgRowWidth         float64 `json:"AvgRowWidth"`
+	MaxRowWidth         int64   `json:"MaxRowWidth"`
+	MaxBatchSizeInBytes int64   `json:"MaxBatchSizeInBytes"`
+	ExceedsMaxBatchSize bool    `json:"ExceedsMaxBatchSize"`
+	RowsPerBatch        int64   `json:"RowsPerBatch"`
+	Reasoning           string  `json:"Reasoning"`
+}
+
+type IndexColumnProfile struct {
+	SchemaName    string  `json:"SchemaName"`
+	TableName     string  `json:"TableName"`
+	IndexName     string  `json:"IndexName"`
+	IsPrimaryKey  bool    `json:"IsPrimaryKey"`
+	ColumnName    string  `json:"ColumnName"`
+	RowCount      int64   `json:"RowCount"`
+	DistinctCount int64   `json:"DistinctCount"`
+	KeySkew       float64 `json:"KeySkew"`
+	Reasoning     string  `json:"Reasoning"`
+}
+
+type tableRowWidth struct {
+	schemaName  string
+	tableName   string
+	avgRowWidth float64
+	maxRowWidth int64
+}
+
+type indexLeadingColumnProfile struct {
+	schemaName    string
+	tableName     string
+	indexName     string
+	isPrimaryKey  bool
+	columnName    string
+	rowCount      sql.NullFloat64
+	distinctCount sql.NullInt64
+	topValueFreq  sql.NullFloat64
+}
+
+func DataProfileAssessment(adb *AssessmentDB) (*DataProfile, error) {
+	if SourceDBType != "postgresql" {
+		return nil, nil
+	}
+
+	columns, err := adb.fetchColumnDataProfiles()
+	if err != nil {
+		return nil, fmt.Errorf("fetching column data profiles: %w", err)
+	}
+	if len(columns) == 0 {
+		log.Infof("data profile not present in the assessment metadata")
+		return nil, nil
+	}
+
+	rowWidths, err := adb.fetchTableRowWidths()
+	if err != nil {
+		return nil, fmt.Errorf("fetching table row widths: %w", err)
+	}
+
+	leadingColumns, err := adb.fetchIndexLeadingColumnProfiles()
+	if err != nil {
+		return nil, fmt.Errorf("fetching data profile of index leading columns: %w", err)
+	}
+
+	maxBatchSizeInBytes := utils.GetEnvAsInt64("MAX_BATCH_SIZE_BYTES", DEFAULT_MAX_BATCH_SIZE_BYTES)
+	profile := &DataProfile{
+		Columns:       columns,
+		OversizedRows: findOversizedRows(rowWidths, maxBatchSizeInBytes),
+	}
+	profile.LowCardinalityIndexColumns, profile.SkewedShardKeys = findLowCardinalityAndSkewedKeys(leadingColumns)
+	log.Infof("data profile: oversized rows in %d tables, %d low cardinality leading index columns, %d skewed shard keys",
+		len(profile.OversizedRows), len(profile.LowCardinalityIndexColumns), len(profile.SkewedShardKeys))
+	return profile, nil
+}
+
+func findOversizedRows(rowWidths []tableRowWidth, maxBatchSizeInBytes int64) []OversizedRowTable {
+	var result []OversizedRowTable
+	for _, t := range rowWidths {
+		oversized := OversizedRowTable{
+			SchemaName:          t.schemaName,
+			TableName:           t.tableName,
+			AvgRowWidth:         t.avgRowWidth,
+			MaxRowWidth:         t.maxRowWidth,
+			MaxBatchSizeInBytes: maxBatchSizeInBytes,
+		}
+		if t.avgRowWidth > 0 {
+			oversized.RowsPerBatch = int64(float64(maxBatchSizeInBytes) / t.avgRowWidth)
+		}
+		switch {
+		case t.maxRowWidth > maxBatchSizeInBytes:
+			oversized.ExceedsMaxBatchSize = true
+			oversized.Reasoning = fmt.Sprintf("Rows of up to %d bytes are larger than the max batch size(%d bytes), import data will fail for the table. "+
+				"Increase the max batch size using the env var MAX_BATCH_SIZE_BYTES(up to the rpc_max_message_size of the target YugabyteDB) "+
+				"or move the large values out of the table.", t.maxRowWidth, maxBatchSizeInBytes)
+		case oversized.RowsPerBatch > 0 && oversized.RowsPerBatch < DEFAULT_IMPORT_BATCH_SIZE_IN_ROWS:
+			oversized.Reasoning = fmt.Sprintf("With an average row width of %.0f bytes, import batches will be cut at about %d rows by the max batch size(%d bytes) "+
+				"instead of %d rows. Consider a smaller --batch-size and more --parallel-jobs for the table.",
+				t.avgRowWidth, oversized.RowsPerBatch, maxBatchSizeInBytes, DEFAULT_IMPORT_BATCH_SIZE_IN_ROWS)
+		default:
+			continue
+		}
+		result = append(result, oversized)
+	}
+	return result
+}
+
+func findLowCardinalityAndSkewedKeys(leadingColumns []indexLeadingColumnProfile) ([]IndexColumnProfile, []IndexColumnProfile) {
+	var lowCardinality, skewed []IndexColumnProfile
+	for _, col := range leadingColumns {
+		if !col.rowCount.Valid || col.rowCount.Float64 < MIN_ROW_COUNT_FOR_DISTRIBUTION_CHECK {
+			continue
+		}
+		rowCount := int64(col.rowCount.Float64)
+		profile := IndexColumnProfile{
+			SchemaName:    col.schemaName,
+			TableName:     col.tableName,
+			IndexName:     col.indexName,
+			IsPrimaryKey:  col.isPrimaryKey,
+			ColumnName:    col.columnName,
+			RowCount:      rowCount,
+			DistinctCount: col.distinctCount.Int64,
+			KeySkew:       col.topValueFreq.Float64,
+		}
+		keyKind := lo.Ternary(col.isPrimaryKey, "primary key", "index")
+		switch {
+		case col.distinctCount.Valid && col.distinctCount.Int64 > 0 && col.distinctCount.Int64 <= LOW_CARDINALITY_DISTINCT_COUNT_THRESHOLD:
+			profile.Reasoning = fmt.Sprintf("Leading column %q of the %s has only about %d distinct values for %d rows. "+
+				"Hash sharding on it distributes the rows to at most %d tablets; include more columns in the HASH part of the key, "+
+				"e.g. ((%s, <other column>) HASH), or drop the index if it is not selective enough.",
+				col.columnName, keyKind, col.distinctCount.Int64, rowCount, col.distinctCount.Int64, col.columnName)
+			lowCardinality = append(lowCardinality, profile)
+		case col.topValueFreq.Valid && col.topValueFreq.Float64 >= SKEWED_SHARD_KEY_TOP_VALUE_FREQ_THRESHOLD:
+			profile.Reasoning = fmt.Sprintf("The most common value of the leading column %q of the %s is in %.0f%% of the rows. "+
+				"The tablet holding it will be a hotspot for the data and the operations; "+
+				"include more columns in the HASH part of the key to spread the rows.",
+				col.columnName, keyKind, col.topValueFreq.Float64*100)
+			skewed = append(skewed, profile)
+		}
+	}
+	return lowCardinality, skewed
+}
+
+/*
+loadProfiledDataSizes sets the ProfiledDataSize(in GB) of the tables as the average row width found by data profiling
+times the row count, which is the size of the exported data used for estimating the import time.
+Not set for the tables which are not profiled.
+*/
+func loadProfiledDataSizes(filePath string, tables []SourceDBMetadata) error {
+	metaDB, err := utils.ConnectToSqliteDatabase(filePath)
+	if err != nil {
+		return fmt.Errorf("cannot connect to source metadata database: %w", err)
+	}
+	defer func() {
+		if closeErr := metaDB.Close(); closeErr != nil {
+			log.Warnf("failed to close connection to sourceDB metadata")
+		}
+	}()
+
+	rowWidths, err := (&AssessmentDB{db: metaDB}).fetchTableRowWidths()
+	if err != nil {
+		return err
+	}
+	avgRowWidths := make(map[string]float64)
+	for _, t := range rowWidths {
+		avgRowWidths[t.schemaName+"."+t.tableName] = t.avgRowWidth
+	}
+	for i, table := range tables {
+		avgRowWidth, ok := avgRowWidths[table.SchemaName+"."+table.ObjectName]
+		if !ok || !table.RowCount.Valid {
+			continue
+		}
+		tables[i].ProfiledDataSize = sql.NullFloat64{Float64: utils.BytesToGB(avgRowWidth * table.RowCount.Float64), Valid: true}
+	}
+	return nil
+}
+
+func (adb *AssessmentDB) fetchColumnDataProfiles() ([]ColumnDataProfile, error) {
+	// the columns left NULL by the gather script(never analyzed tables, non-index columns) are '' in the metadata CSV
+	query := fmt.Sprintf(`SELECT schema_name, table_name, column_name, NULLIF(null_frac, ''), NULLIF(distinct_count, ''),
+		NULLIF(avg_width, ''), NULLIF(max_width, ''), NULLIF(top_value_freq, '')
+	FROM %s
+	ORDER BY schema_name, table_name, column_name;`, COLUMN_DATA_PROFILE)
+	rows, err := adb.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying-%s: %w", query, err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warnf("failed to close result set for query: [%s]", query)
+		}
+	}()
+
+	var result []ColumnDataProfile
+	for rows.Next() {
+		var col ColumnDataProfile
+		var nullFrac, avgWidth, topValueFreq sql.NullFloat64
+		var distinctCount, maxWidth sql.NullInt64
+		err := rows.Scan(&col.SchemaName, &col.TableName, &col.ColumnName, &nullFrac, &distinctCount, &avgWidth,
+			&maxWidth, &topValueFreq)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+		col.NullRatio = lo.Ternary(nullFrac.Valid, &nullFrac.Float64, nil)
+		col.DistinctCount = lo.Ternary(distinctCount.Valid, &distinctCount.Int64, nil)
+		col.AvgWidth = lo.Ternary(avgWidth.Valid, &avgWidth.Float64, nil)
+		col.MaxWidth = lo.Ternary(maxWidth.Valid, &maxWidth.Int64, nil)
+		col.KeySkew = lo.Ternary(topValueFreq.Valid, &topValueFreq.Float64, nil)
+		result = append(result, col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rows: %w", err)
+	}
+	return result, nil
+}
+
+func (adb *AssessmentDB) fetchTableRowWidths() ([]tableRowWidth, error) {
+	query := fmt.Sprintf(`SELECT schema_name, table_name, avg_row_width, max_row_width
+	FROM %s
+	WHERE sampled_rows > 0
+	ORDER BY schema_name, table_name;`, TABLE_ROW_WIDTHS)
+	rows, err := adb.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying-%s: %w", query, err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warnf("failed to close result set for query: [%s]", query)
+		}
+	}()
+
+	var result []tableRowWidth
+	for rows.Next() {
+		var t tableRowWidth
+		if err := rows.Scan(&t.schemaName, &t.tableName, &t.avgRowWidth, &t.maxRowWidth); err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+		result = append(result, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rows: %w", err)
+	}
+	return result, nil
+}
+
+func (adb *AssessmentDB) fetchIndexLeadingColumnProfiles() ([]indexLeadingColumnProfile, error) {
+	query := fmt.Sprintf(`SELECT ilc.schema_name, ilc.table_name, ilc.index_name, ilc.is_primary_key, ilc.column_name,
+		tis.row_count, NULLIF(cdp.distinct_count, ''), NULLIF(cdp.top_value_freq, '')
+	FROM %s ilc
+	JOIN %s cdp ON ilc.schema_name = cdp.schema_name AND ilc.table_name = cdp.table_name AND ilc.column_name = cdp.column_name
+	LEFT JOIN %s tis ON ilc.schema_name = tis.schema_name AND ilc.table_name = tis.object_name AND tis.is_index = 0
+	ORDER BY ilc.schema_name, ilc.table_name, ilc.index_name;`, INDEX_LEADING_COLUMNS, COLUMN_DATA_PROFILE, TABLE_INDEX_STATS)
+	rows, err := adb.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying-%s: %w", query, err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warnf("failed to close result set for query: [%s]", query)
+		}
+	}()
+
+	var result []indexLeadingColumnProfile
+	for rows.Next() {
+		var col indexLeadingColumnProfile
+		err := rows.Scan(&col.schemaName, &col.tableName, &col.indexName, &col.isPrimaryKey, &col.columnName,
+			&col.rowCount, &col.distinctCount, &col.topValueFreq)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+		result = append(result, col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rows: %w", err)
+	}
+	return result, nil
+}
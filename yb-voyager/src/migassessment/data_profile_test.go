@@ -0,0 +1,77 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package migassessment
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindOversizedRows(t *testing.T) {
+	maxBatchSize := int64(200 * 1024 * 1024)
+	result := findOversizedRows([]tableRowWidth{
+		{schemaName: "public", tableName: "small_rows", avgRowWidth: 100, maxRowWidth: 1000},
+		// 200MB / 20000 rows = ~10KB per row
+		{schemaName: "public", tableName: "wide_rows", avgRowWidth: 20 * 1024, maxRowWidth: 1024 * 1024},
+		{schemaName: "public", tableName: "huge_rows", avgRowWidth: 1024, maxRowWidth: 300 * 1024 * 1024},
+	}, maxBatchSize)
+
+	assert.Len(t, result, 2)
+	assert.Equal(t, "wide_rows", result[0].TableName)
+	assert.False(t, result[0].ExceedsMaxBatchSize)
+	assert.Equal(t, int64(10240), result[0].RowsPerBatch)
+	assert.Equal(t, "huge_rows", result[1].TableName)
+	assert.True(t, result[1].ExceedsMaxBatchSize)
+}
+
+func TestFindLowCardinalityAndSkewedKeys(t *testing.T) {
+	newColumn := func(indexName string, rowCount float64, distinctCount int64, topValueFreq float64) indexLeadingColumnProfile {
+		return indexLeadingColumnProfile{
+			schemaName:    "public",
+			tableName:     "orders",
+			indexName:     indexName,
+			columnName:    "col",
+			rowCount:      sql.NullFloat64{Float64: rowCount, Valid: true},
+			distinctCount: sql.NullInt64{Int64: distinctCount, Valid: true},
+			topValueFreq:  sql.NullFloat64{Float64: topValueFreq, Valid: true},
+		}
+	}
+	// unique column i.e. no skew
+	pkey := newColumn("orders_pkey", 1000000, 1000000, 0)
+	pkey.isPrimaryKey = true
+	status := newColumn("orders_status_idx", 1000000, 4, 0.6)
+	customer := newColumn("orders_customer_idx", 1000000, 50000, 0.3)
+	region := newColumn("orders_region_idx", 1000000, 200, 0.1)
+	// small table
+	smallTable := newColumn("orders_small_idx", 100, 2, 0.5)
+	// not analyzed
+	noStats := newColumn("orders_nostats_idx", 1000000, 0, 0)
+	noStats.distinctCount.Valid, noStats.topValueFreq.Valid = false, false
+
+	lowCardinality, skewed := findLowCardinalityAndSkewedKeys([]indexLeadingColumnProfile{pkey, status, customer, region, smallTable, noStats})
+
+	assert.Len(t, lowCardinality, 1)
+	assert.Equal(t, "orders_status_idx", lowCardinality[0].IndexName)
+	assert.Equal(t, int64(4), lowCardinality[0].DistinctCount)
+	// low cardinality columns are not reported again as skewed
+	assert.Len(t, skewed, 1)
+	assert.Equal(t, "orders_customer_idx", skewed[0].IndexName)
+	assert.Equal(t, 0.3, skewed[0].KeySkew)
+}
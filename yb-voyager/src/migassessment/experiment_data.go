@@ -0,0 +1,177 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package migassessment
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
+)
+
+/*
+The sizing recommendation is based on the experiment data(sqlite DB) of the instance shapes benchmarked by Yugabyte.
+Users can provide their own experiment data(--sizing-experiment-data) with the same tables for their instance families.
+
+An optional INSTANCE_TYPES_TABLE in the experiment data maps the (num_cores, mem_per_core) shapes to the
+names of the instance types(for eg. m6i.2xlarge) which are then reported along with the recommendation.
+*/
+
+const (
+	INSTANCE_TYPES_TABLE = "instance_types"
+
+	BUILTIN_EXPERIMENT_DATASET = EXPERIMENT_DATA_FILENAME + " (built-in)"
+	REMOTE_EXPERIMENT_DATASET  = EXPERIMENT_DATA_FILENAME + " (downloaded from " + GITHUB_RAW_LINK + ")"
+)
+
+// path of the user-provided experiment data, built-in experiment data is used if empty
+var ExperimentDataFilePath string
+
+// tables and the columns in them required from the experiment data for the sizing assessment
+var experimentDataSchema = map[string][]string{
+	COLOCATED_LIMITS_TABLE: {"max_colocated_db_size_gb", "num_cores", "mem_per_core", "max_num_tables", "min_num_tables"},
+	COLOCATED_SIZING_TABLE: {"dimension", "selects_per_core", "inserts_per_core", "select_conn_per_node",
+		"insert_conn_per_node", "num_cores", "memory_per_core"},
+	SHARDED_SIZING_TABLE: {"dimension", "selects_per_core", "inserts_per_core", "select_conn_per_node",
+		"insert_conn_per_node", "num_tables", "num_cores", "memory_per_core"},
+	COLOCATED_LOAD_TIME_TABLE: {"csv_size_gb", "num_cores", "mem_per_core", "migration_time_secs", "parallel_threads", "row_count"},
+	SHARDED_LOAD_TIME_TABLE:   {"csv_size_gb", "num_cores", "mem_per_core", "migration_time_secs", "parallel_threads", "row_count"},
+	LOAD_TIME_INDEX_IMPACT_TABLE: {"num_cores", "mem_per_core", "number_of_indexes", "multiplication_factor_sharded",
+		"multiplication_factor_colocated"},
+	LOAD_TIME_COLUMNS_IMPACT_TABLE: {"num_cores", "mem_per_core", "number_of_columns", "multiplication_factor_sharded",
+		"multiplication_factor_colocated"},
+}
+
+var instanceTypesTableColumns = []string{"instance_type", "num_cores", "mem_per_core"}
+
+// ValidateExperimentDataFile checks that the given file is a sqlite database with the schema needed for the sizing
+func ValidateExperimentDataFile(filePath string) error {
+	experimentDB, err := utils.ConnectToSqliteDatabase(filePath)
+	if err != nil {
+		return fmt.Errorf("connect to experiment data: %w", err)
+	}
+	defer experimentDB.Close()
+	return validateExperimentDataSchema(experimentDB)
+}
+
+// validateExperimentDataSchema checks that all the tables and columns used for the sizing are present in the experiment data
+func validateExperimentDataSchema(experimentDB *sql.DB) error {
+	tableNames := lo.Keys(experimentDataSchema)
+	sort.Strings(tableNames)
+
+	var problems []string
+	for _, tableName := range tableNames {
+		columns, err := getExperimentDataTableColumns(experimentDB, tableName)
+		if err != nil {
+			return err
+		}
+		if len(columns) == 0 {
+			problems = append(problems, fmt.Sprintf("table %q is missing", tableName))
+			continue
+		}
+		missingColumns, _ := lo.Difference(experimentDataSchema[tableName], columns)
+		if len(missingColumns) > 0 {
+			problems = append(problems, fmt.Sprintf("table %q is missing columns [%s]", tableName, strings.Join(missingColumns, ", ")))
+		}
+	}
+
+	columns, err := getExperimentDataTableColumns(experimentDB, INSTANCE_TYPES_TABLE)
+	if err != nil {
+		return err
+	}
+	if len(columns) > 0 {
+		missingColumns, _ := lo.Difference(instanceTypesTableColumns, columns)
+		if len(missingColumns) > 0 {
+			problems = append(problems, fmt.Sprintf("table %q is missing columns [%s]", INSTANCE_TYPES_TABLE, strings.Join(missingColumns, ", ")))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid experiment data: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// returns the lower cased column names of the table, empty if the table does not exist
+func getExperimentDataTableColumns(experimentDB *sql.DB, tableName string) ([]string, error) {
+	query := fmt.Sprintf("SELECT name FROM pragma_table_info('%s')", tableName)
+	rows, err := experimentDB.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying-%s: %w", query, err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warnf("failed to close result set for query: [%s]", query)
+		}
+	}()
+
+	var columns []string
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+		columns = append(columns, strings.ToLower(column))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rows: %w", err)
+	}
+	return columns, nil
+}
+
+/*
+getInstanceType returns the name(s) of the instance types of the given shape from the instance types catalog
+in the experiment data. Returns empty string if the catalog is not present or has no matching instance type.
+*/
+func getInstanceType(experimentDB *sql.DB, vCPUPerInstance int, memPerCore int) (string, error) {
+	columns, err := getExperimentDataTableColumns(experimentDB, INSTANCE_TYPES_TABLE)
+	if err != nil {
+		return "", err
+	}
+	if len(columns) == 0 {
+		return "", nil
+	}
+
+	query := fmt.Sprintf(`SELECT instance_type FROM %s WHERE num_cores = ? AND mem_per_core = ? ORDER BY instance_type`,
+		INSTANCE_TYPES_TABLE)
+	rows, err := experimentDB.Query(query, vCPUPerInstance, memPerCore)
+	if err != nil {
+		return "", fmt.Errorf("error querying-%s: %w", query, err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warnf("failed to close result set for query: [%s]", query)
+		}
+	}()
+
+	var instanceTypes []string
+	for rows.Next() {
+		var instanceType string
+		if err := rows.Scan(&instanceType); err != nil {
+			return "", fmt.Errorf("error scanning row: %w", err)
+		}
+		instanceTypes = append(instanceTypes, instanceType)
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("error reading rows: %w", err)
+	}
+	return strings.Join(instanceTypes, ", "), nil
+}
@@ -0,0 +1,110 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migassessment
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/utils"
+)
+
+func createTestExperimentDB(t *testing.T) (*sql.DB, string) {
+	filePath := filepath.Join(t.TempDir(), "experiment.db")
+	err := os.WriteFile(filePath, experimentData20240, 0644)
+	assert.NoError(t, err)
+	experimentDB, err := utils.ConnectToSqliteDatabase(filePath)
+	assert.NoError(t, err)
+	t.Cleanup(func() { experimentDB.Close() })
+	return experimentDB, filePath
+}
+
+func TestValidateExperimentDataSchema_BuiltinData(t *testing.T) {
+	experimentDB, _ := createTestExperimentDB(t)
+	assert.NoError(t, validateExperimentDataSchema(experimentDB))
+}
+
+func TestValidateExperimentDataSchema_MissingTableAndColumns(t *testing.T) {
+	experimentDB, _ := createTestExperimentDB(t)
+	_, err := experimentDB.Exec("DROP TABLE " + LOAD_TIME_COLUMNS_IMPACT_TABLE)
+	assert.NoError(t, err)
+	_, err = experimentDB.Exec("ALTER TABLE " + SHARDED_LOAD_TIME_TABLE + " DROP COLUMN row_count")
+	assert.NoError(t, err)
+	_, err = experimentDB.Exec("CREATE TABLE " + INSTANCE_TYPES_TABLE + " (instance_type TEXT, num_cores INT)")
+	assert.NoError(t, err)
+
+	err = validateExperimentDataSchema(experimentDB)
+	assert.ErrorContains(t, err, `table "load_time_columns_impact" is missing`)
+	assert.ErrorContains(t, err, `table "sharded_load_time" is missing columns [row_count]`)
+	assert.ErrorContains(t, err, `table "instance_types" is missing columns [mem_per_core]`)
+}
+
+func TestValidateExperimentDataFile(t *testing.T) {
+	experimentDB, filePath := createTestExperimentDB(t)
+	assert.NoError(t, ValidateExperimentDataFile(filePath))
+
+	_, err := experimentDB.Exec("DROP TABLE " + COLOCATED_LOAD_TIME_TABLE)
+	assert.NoError(t, err)
+	assert.ErrorContains(t, ValidateExperimentDataFile(filePath), `table "colocated_load_time" is missing`)
+
+	notSqliteFile := filepath.Join(t.TempDir(), "experiment.csv")
+	assert.NoError(t, os.WriteFile(notSqliteFile, []byte("num_cores,mem_per_core\n8,4\n"), 0644))
+	assert.Error(t, ValidateExperimentDataFile(notSqliteFile))
+}
+
+func TestGetInstanceType(t *testing.T) {
+	experimentDB, _ := createTestExperimentDB(t)
+
+	// no instance types catalog in the built-in data
+	instanceType, err := getInstanceType(experimentDB, 8, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, "", instanceType)
+
+	_, err = experimentDB.Exec("CREATE TABLE " + INSTANCE_TYPES_TABLE + ` (instance_type TEXT, num_cores INT, mem_per_core INT);
+		INSERT INTO instance_types VALUES ('m6i.2xlarge', 8, 4), ('c6i.4xlarge', 16, 2), ('m7i.2xlarge', 8, 4);`)
+	assert.NoError(t, err)
+	assert.NoError(t, validateExperimentDataSchema(experimentDB))
+
+	instanceType, err = getInstanceType(experimentDB, 8, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, "m6i.2xlarge, m7i.2xlarge", instanceType)
+
+	instanceType, err = getInstanceType(experimentDB, 16, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, "", instanceType)
+}
+
+func TestGetExperimentFile_UserProvidedData(t *testing.T) {
+	_, filePath := createTestExperimentDB(t)
+	ExperimentDataFilePath = filePath
+	defer func() { ExperimentDataFilePath = "" }()
+
+	path, dataset, err := getExperimentFile()
+	assert.NoError(t, err)
+	assert.Equal(t, filePath, path)
+	assert.Equal(t, filePath, dataset)
+
+	ExperimentDataFilePath = filepath.Join(t.TempDir(), "missing.db")
+	_, _, err = getExperimentFile()
+	assert.ErrorContains(t, err, "does not exist")
+}
@@ -0,0 +1,280 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package migassessment
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/query/queryparser"
+)
+
+/*
+When pg_stat_statements is not available on the source, the queries executed on it can be collected from the
+PostgreSQL server logs instead, with log_statement=all(or mod) or log_min_duration_statement=0 configured.
+Both the csvlog(.csv files) and the stderr log formats are supported. The log messages considered are like:
+
+	statement: SELECT * FROM t WHERE id = 1
+	duration: 0.421 ms  statement: SELECT * FROM t WHERE id = 1
+	duration: 0.421 ms  execute <unnamed>: SELECT * FROM t WHERE id = $1
+
+The queries are normalized and grouped by their fingerprint into DB_QUERIES_SUMMARY
+with the number of calls and the execution times, similar to pg_stat_statements.
+
+The same executions are present in both pg_stat_statements and the logs, so the log files are used only if
+DB_QUERIES_SUMMARY is not populated from pg_stat_statements, otherwise the queries would be counted twice.
+*/
+
+const (
+	CSVLOG_ERROR_SEVERITY_INDEX = 11
+	CSVLOG_MESSAGE_INDEX        = 13
+	STDERR_LOG_MESSAGE_PREFIX   = "LOG:  "
+)
+
+var logStatementMessageRegex = regexp.MustCompile(`^(?:duration: ([0-9.]+) ms\s+)?(?:statement|execute [^:]+): ((?s).*)$`)
+
+type loggedStatement struct {
+	query      string
+	durationMs sql.NullFloat64
+}
+
+type QuerySummary struct {
+	QueryId       int64
+	Query         string
+	Calls         int64
+	TotalExecTime sql.NullFloat64 // in milliseconds, valid only if the durations are logged
+	MeanExecTime  sql.NullFloat64
+}
+
+// PopulateQueriesFromPostgresLogs parses the given log files and inserts the summary of the queries into DB_QUERIES_SUMMARY.
+// Returns false if the queries are already collected from pg_stat_statements and hence the log files are skipped.
+func PopulateQueriesFromPostgresLogs(adb *AssessmentDB, logFilePaths []string) (int, bool, error) {
+	numQueries, err := adb.countQuerySummaries()
+	if err != nil {
+		return 0, false, err
+	}
+	if numQueries > 0 {
+		log.Infof("skipping the log files %v as %d queries are already collected from pg_stat_statements", logFilePaths, numQueries)
+		return 0, false, nil
+	}
+
+	var statements []loggedStatement
+	for _, logFilePath := range logFilePaths {
+		fileStatements, err := parsePostgresLogFile(logFilePath)
+		if err != nil {
+			return 0, false, fmt.Errorf("parsing log file %q: %w", logFilePath, err)
+		}
+		log.Infof("found %d statements in log file %q", len(fileStatements), logFilePath)
+		statements = append(statements, fileStatements...)
+	}
+
+	summaries := summarizeLoggedStatements(statements)
+	err = adb.insertQuerySummaries(summaries)
+	if err != nil {
+		return 0, false, err
+	}
+	return len(summaries), true, nil
+}
+
+func parsePostgresLogFile(logFilePath string) ([]loggedStatement, error) {
+	file, err := os.Open(logFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("opening file: %w", err)
+	}
+	defer file.Close()
+
+	var messages []string
+	if strings.EqualFold(filepath.Ext(logFilePath), ".csv") {
+		messages, err = readCSVLogMessages(file)
+	} else {
+		messages, err = readStderrLogMessages(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var statements []loggedStatement
+	for _, message := range messages {
+		statement, ok := parseLogStatementMessage(message)
+		if ok {
+			statements = append(statements, statement)
+		}
+	}
+	return statements, nil
+}
+
+func readCSVLogMessages(reader io.Reader) ([]string, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1 // number of columns differs across the PostgreSQL versions
+	var messages []string
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading csv log: %w", err)
+		}
+		if len(record) <= CSVLOG_MESSAGE_INDEX || record[CSVLOG_ERROR_SEVERITY_INDEX] != "LOG" {
+			continue
+		}
+		messages = append(messages, record[CSVLOG_MESSAGE_INDEX])
+	}
+	return messages, nil
+}
+
+/*
+In the stderr log format, every log line starts with the log_line_prefix followed by the severity,
+and the multi-line statements are continued in the subsequent lines starting with a tab.
+*/
+func readStderrLogMessages(reader io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	var messages []string
+	var current *strings.Builder
+	flush := func() {
+		if current != nil {
+			messages = append(messages, current.String())
+			current = nil
+		}
+	}
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "\t") {
+			if current != nil {
+				current.WriteString("\n")
+				current.WriteString(strings.TrimPrefix(line, "\t"))
+			}
+			continue
+		}
+		flush()
+		idx := strings.Index(line, STDERR_LOG_MESSAGE_PREFIX)
+		if idx == -1 {
+			continue
+		}
+		current = &strings.Builder{}
+		current.WriteString(line[idx+len(STDERR_LOG_MESSAGE_PREFIX):])
+	}
+	flush()
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading stderr log: %w", err)
+	}
+	return messages, nil
+}
+
+func parseLogStatementMessage(message string) (loggedStatement, bool) {
+	matches := logStatementMessageRegex.FindStringSubmatch(message)
+	if matches == nil {
+		return loggedStatement{}, false
+	}
+	statement := loggedStatement{query: strings.TrimSpace(matches[2])}
+	if statement.query == "" {
+		return loggedStatement{}, false
+	}
+	if matches[1] != "" {
+		duration, err := strconv.ParseFloat(matches[1], 64)
+		if err == nil {
+			statement.durationMs = sql.NullFloat64{Float64: duration, Valid: true}
+		}
+	}
+	return statement, true
+}
+
+func summarizeLoggedStatements(statements []loggedStatement) []QuerySummary {
+	var result []*QuerySummary
+	summaryByFingerprint := make(map[uint64]*QuerySummary)
+	durationCounts := make(map[uint64]int64)
+	for _, statement := range statements {
+		normalizedQuery, fingerprint, err := queryparser.NormalizeQuery(statement.query)
+		if err != nil {
+			log.Warnf("skipping the logged statement [%s]: %v", statement.query, err)
+			continue
+		}
+		summary, ok := summaryByFingerprint[fingerprint]
+		if !ok {
+			summary = &QuerySummary{QueryId: int64(fingerprint), Query: normalizedQuery}
+			summaryByFingerprint[fingerprint] = summary
+			result = append(result, summary)
+		}
+		summary.Calls++
+		if statement.durationMs.Valid {
+			summary.TotalExecTime.Float64 += statement.durationMs.Float64
+			summary.TotalExecTime.Valid = true
+			durationCounts[fingerprint]++
+		}
+	}
+
+	summaries := make([]QuerySummary, 0, len(result))
+	for _, summary := range result {
+		if summary.TotalExecTime.Valid {
+			fingerprint := uint64(summary.QueryId)
+			summary.MeanExecTime = sql.NullFloat64{Float64: summary.TotalExecTime.Float64 / float64(durationCounts[fingerprint]), Valid: true}
+		}
+		summaries = append(summaries, *summary)
+	}
+	return summaries
+}
+
+func (adb *AssessmentDB) countQuerySummaries() (int, error) {
+	var count int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s`, DB_QUERIES_SUMMARY)
+	err := adb.db.QueryRow(query).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error querying-%s: %w", query, err)
+	}
+	return count, nil
+}
+
+func (adb *AssessmentDB) insertQuerySummaries(summaries []QuerySummary) error {
+	if len(summaries) == 0 {
+		return nil
+	}
+	tx, err := adb.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting transaction for inserting into %s: %w", DB_QUERIES_SUMMARY, err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(fmt.Sprintf(`INSERT INTO %s (queryid, query, calls, total_exec_time, mean_exec_time) VALUES (?, ?, ?, ?, ?)`,
+		DB_QUERIES_SUMMARY))
+	if err != nil {
+		return fmt.Errorf("error preparing statement for inserting into %s: %w", DB_QUERIES_SUMMARY, err)
+	}
+	defer stmt.Close()
+
+	for _, summary := range summaries {
+		_, err = stmt.Exec(summary.QueryId, summary.Query, summary.Calls, summary.TotalExecTime, summary.MeanExecTime)
+		if err != nil {
+			return fmt.Errorf("error inserting query summary into %s: %w", DB_QUERIES_SUMMARY, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing transaction for inserting into %s: %w", DB_QUERIES_SUMMARY, err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package migassessment
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testStderrLog = `2025-01-10 10:00:00.123 UTC [101] LOG:  statement: SELECT * FROM orders WHERE id = 10
+2025-01-10 10:00:01.123 UTC [101] LOG:  duration: 1.500 ms  statement: SELECT * FROM orders WHERE id = 20
+2025-01-10 10:00:02.123 UTC [102] LOG:  duration: 2.500 ms  execute <unnamed>: SELECT *
+	FROM orders
+	WHERE id = $1
+2025-01-10 10:00:02.124 UTC [102] DETAIL:  parameters: $1 = '30'
+2025-01-10 10:00:03.123 UTC [103] LOG:  checkpoint starting: time
+2025-01-10 10:00:04.123 UTC [103] ERROR:  relation "foo" does not exist
+2025-01-10 10:00:04.123 UTC [103] STATEMENT:  SELECT * FROM foo
+2025-01-10 10:00:05.123 UTC [104] LOG:  statement: SELECT pg_advisory_lock(100)
+`
+
+const testCSVLog = `2025-01-10 10:00:00.123 UTC,"app","db",101,"[local]",abc.1,1,"SELECT",2025-01-10 09:00:00 UTC,3/1,0,LOG,00000,"duration: 3.000 ms  statement: SELECT *
+FROM orders WHERE id = 40",,,,,,,,,"psql","client backend",,0
+2025-01-10 10:00:01.123 UTC,"app","db",101,"[local]",abc.1,2,"idle",2025-01-10 09:00:00 UTC,3/1,0,LOG,00000,"connection authorized: user=app",,,,,,,,,"psql","client backend",,0
+`
+
+func writeTestLogFile(t *testing.T, name string, contents string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+	return path
+}
+
+func TestParsePostgresLogFile(t *testing.T) {
+	statements, err := parsePostgresLogFile(writeTestLogFile(t, "postgresql.log", testStderrLog))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(statements))
+	assert.Equal(t, "SELECT * FROM orders WHERE id = 10", statements[0].query)
+	assert.False(t, statements[0].durationMs.Valid)
+	assert.Equal(t, 1.5, statements[1].durationMs.Float64)
+	assert.Equal(t, "SELECT *\nFROM orders\nWHERE id = $1", statements[2].query)
+	assert.Equal(t, "SELECT pg_advisory_lock(100)", statements[3].query)
+
+	statements, err = parsePostgresLogFile(writeTestLogFile(t, "postgresql.csv", testCSVLog))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(statements))
+	assert.Equal(t, "SELECT *\nFROM orders WHERE id = 40", statements[0].query)
+	assert.Equal(t, 3.0, statements[0].durationMs.Float64)
+}
+
+func TestSummarizeLoggedStatements(t *testing.T) {
+	var statements []loggedStatement
+	for _, logFile := range []string{writeTestLogFile(t, "postgresql.log", testStderrLog), writeTestLogFile(t, "postgresql.csv", testCSVLog)} {
+		fileStatements, err := parsePostgresLogFile(logFile)
+		assert.NoError(t, err)
+		statements = append(statements, fileStatements...)
+	}
+	statements = append(statements, loggedStatement{query: "SELECT FROM WHERE"})
+
+	summaries := summarizeLoggedStatements(statements)
+	assert.Equal(t, 2, len(summaries))
+
+	// all the queries on orders differ only in the constants
+	assert.Equal(t, "SELECT * FROM orders WHERE id = $1", summaries[0].Query)
+	assert.Equal(t, int64(4), summaries[0].Calls)
+	assert.Equal(t, 7.0, summaries[0].TotalExecTime.Float64)
+	assert.InDelta(t, 7.0/3, summaries[0].MeanExecTime.Float64, 0.001)
+
+	assert.True(t, strings.HasPrefix(summaries[1].Query, "SELECT pg_advisory_lock($1)"))
+	assert.Equal(t, int64(1), summaries[1].Calls)
+	assert.False(t, summaries[1].TotalExecTime.Valid)
+	assert.NotEqual(t, summaries[0].QueryId, summaries[1].QueryId)
+}
+
+func TestPopulateQueriesFromPostgresLogs(t *testing.T) {
+	dbFilePath := filepath.Join(t.TempDir(), "assessment.db")
+	GetSourceMetadataDBFilePath = func() string {
+		return dbFilePath
+	}
+	assert.NoError(t, InitAssessmentDB())
+	adb, err := NewAssessmentDB("postgresql")
+	assert.NoError(t, err)
+	logFilePath := writeTestLogFile(t, "postgresql.log", testStderrLog)
+
+	numQueries, used, err := PopulateQueriesFromPostgresLogs(adb, []string{logFilePath})
+	assert.NoError(t, err)
+	assert.True(t, used)
+	assert.Equal(t, 2, numQueries)
+
+	// the queries are present now(like from pg_stat_statements), so the logs are not counted again
+	numQueries, used, err = PopulateQueriesFromPostgresLogs(adb, []string{logFilePath})
+	assert.NoError(t, err)
+	assert.False(t, used)
+	assert.Equal(t, 0, numQueries)
+	count, err := adb.countQuerySummaries()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,351 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package migassessment
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/query/queryparser"
+)
+
+/*
+Queries which are supported on YugabyteDB can still be slow due to the distributed nature of the tables.
+QueryPerformanceRiskAssessment scores the top queries(by the total execution time, or calls if not available)
+from pg_stat_statements against the following patterns:
+  - full scan of a large sharded table: none of the filter columns is the leading column of an index on the table
+  - range scan on a hash key: range predicates/ORDER BY on the leading column of a hash sharded primary key/index
+  - join without an index: join columns which are not the leading column of an index on any of the joined tables
+  - row locking on a hot row: SELECT ... FOR UPDATE/SHARE executed very frequently
+
+Columns are resolved to the tables via their qualifiers(table names or aliases). The unqualified columns in a query
+on multiple tables are ambiguous, since the columns of the tables are not known, and hence not used for the patterns.
+*/
+
+const (
+	SEQ_SCAN_ON_LARGE_TABLE = "SEQ_SCAN_ON_LARGE_TABLE"
+	RANGE_SCAN_ON_HASH_KEY  = "RANGE_SCAN_ON_HASH_KEY"
+	JOIN_WITHOUT_INDEX      = "JOIN_WITHOUT_INDEX"
+	ROW_LOCKING_HOT_ROW     = "ROW_LOCKING_HOT_ROW"
+
+	MAX_QUERIES_FOR_PERFORMANCE_RISK = 50
+	LARGE_TABLE_ROW_COUNT_THRESHOLD  = 1000000
+	HOT_ROW_LOCKING_CALLS_THRESHOLD  = 10000
+)
+
+var queryPerformanceRiskScores = map[string]int{
+	SEQ_SCAN_ON_LARGE_TABLE: 40,
+	RANGE_SCAN_ON_HASH_KEY:  30,
+	JOIN_WITHOUT_INDEX:      20,
+	ROW_LOCKING_HOT_ROW:     20,
+}
+
+type QueryPerformanceRisk struct {
+	QueryId         int64       `json:"QueryId"`
+	Query           string      `json:"Query"`
+	Calls           int64       `json:"Calls"`
+	TotalExecTimeMs float64     `json:"TotalExecTimeMs"`
+	Score           int         `json:"Score"`
+	Risks           []QueryRisk `json:"Risks"`
+}
+
+type QueryRisk struct {
+	Pattern    string `json:"Pattern"` // SEQ_SCAN_ON_LARGE_TABLE, RANGE_SCAN_ON_HASH_KEY, JOIN_WITHOUT_INDEX or ROW_LOCKING_HOT_ROW
+	TableName  string `json:"TableName,omitempty"`
+	Reasoning  string `json:"Reasoning"`
+	Suggestion string `json:"Suggestion"`
+}
+
+type executedQuery struct {
+	queryId       int64
+	query         string
+	calls         sql.NullInt64
+	totalExecTime sql.NullFloat64
+}
+
+type riskAssessmentTable struct {
+	schemaName  string
+	tableName   string
+	rowCount    int64
+	isColocated bool
+	indexes     []*indexDefinition
+}
+
+func (t *riskAssessmentTable) qualifiedName() string {
+	return t.schemaName + "." + t.tableName
+}
+
+// returns the index with the given column as the leading key column, if any
+func (t *riskAssessmentTable) getIndexWithLeadingColumn(column string) *indexDefinition {
+	for _, index := range t.indexes {
+		if len(index.keyColumns) > 0 && strings.EqualFold(index.keyColumns[0], column) {
+			return index
+		}
+	}
+	return nil
+}
+
+func QueryPerformanceRiskAssessment(adb *AssessmentDB, shardingRecommendations []ShardingKeyRecommendation) ([]QueryPerformanceRisk, error) {
+	if SourceDBType != "postgresql" {
+		return nil, nil
+	}
+
+	queries, err := adb.fetchTopExecutedQueries(MAX_QUERIES_FOR_PERFORMANCE_RISK)
+	if err != nil {
+		return nil, fmt.Errorf("fetching top queries: %w", err)
+	}
+	if len(queries) == 0 {
+		log.Infof("queries info not present in the assessment metadata for query performance risk assessment")
+		return nil, nil
+	}
+
+	tables, err := adb.fetchRiskAssessmentTables()
+	if err != nil {
+		return nil, fmt.Errorf("fetching tables: %w", err)
+	}
+
+	rangeShardedIndexes := lo.FilterMap(shardingRecommendations, func(r ShardingKeyRecommendation, _ int) (string, bool) {
+		return r.SchemaName + "." + r.IndexName, r.IsRangeSharding()
+	})
+
+	var result []QueryPerformanceRisk
+	for _, query := range queries {
+		shape, err := queryparser.GetQueryShape(query.query)
+		if err != nil {
+			log.Debugf("skipping query for performance risk assessment - [%s]: %v", query.query, err)
+			continue
+		}
+		risks := getQueryRisks(query, shape, tables, rangeShardedIndexes)
+		if len(risks) == 0 {
+			continue
+		}
+		queryRisk := QueryPerformanceRisk{
+			QueryId:         query.queryId,
+			Query:           query.query,
+			Calls:           query.calls.Int64,
+			TotalExecTimeMs: query.totalExecTime.Float64,
+			Risks:           risks,
+		}
+		for _, risk := range risks {
+			queryRisk.Score += queryPerformanceRiskScores[risk.Pattern]
+		}
+		log.Infof("query performance risk score %d for query %d: %v", queryRisk.Score, query.queryId,
+			lo.Map(risks, func(r QueryRisk, _ int) string { return r.Pattern }))
+		result = append(result, queryRisk)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+	return result, nil
+}
+
+func getQueryRisks(query executedQuery, shape *queryparser.QueryShape, tables []*riskAssessmentTable, rangeShardedIndexes []string) []QueryRisk {
+	referencedTables := lo.Filter(tables, func(t *riskAssessmentTable, _ int) bool {
+		return shape.ReferencesRelation(t.schemaName, t.tableName)
+	})
+	getTable := func(tableRef queryparser.TableRef) *riskAssessmentTable {
+		table, _ := lo.Find(referencedTables, func(t *riskAssessmentTable) bool {
+			return tableRef.Matches(t.schemaName, t.tableName)
+		})
+		return table
+	}
+	// a filter on an unresolved column can be on any of the tables, so the tables can't be reported for full scan
+	hasUnresolvedFilterColumns := lo.SomeBy(lo.Entries(shape.ColumnRefs), func(e lo.Entry[queryparser.ColumnRef, *queryparser.ColumnUsage]) bool {
+		_, ok := shape.ResolveColumn(e.Key)
+		return !ok && (e.Value.EqualityFilter || e.Value.RangeFilter)
+	})
+
+	var risks []QueryRisk
+	for _, table := range referencedTables {
+		columns := shape.RelationColumns(table.schemaName, table.tableName)
+		filterColumns := lo.Filter(lo.Keys(columns), func(column string, _ int) bool {
+			return columns[column].EqualityFilter || columns[column].RangeFilter
+		})
+		slices.Sort(filterColumns)
+		sortedColumns := lo.Keys(columns)
+		slices.Sort(sortedColumns)
+
+		if !table.isColocated && table.rowCount >= LARGE_TABLE_ROW_COUNT_THRESHOLD {
+			indexedFilterColumns := lo.Filter(filterColumns, func(column string, _ int) bool {
+				return table.getIndexWithLeadingColumn(column) != nil
+			})
+			if len(indexedFilterColumns) == 0 && !hasUnresolvedFilterColumns {
+				risks = append(risks, getSeqScanRisk(table, filterColumns))
+			}
+		}
+
+		for _, column := range sortedColumns {
+			usage := columns[column]
+			if !(usage.RangeFilter || usage.OrderByAsc || usage.OrderByDesc) || usage.EqualityFilter {
+				continue
+			}
+			index := table.getIndexWithLeadingColumn(column)
+			if index == nil || slices.Contains(rangeShardedIndexes, table.schemaName+"."+index.indexName) {
+				continue
+			}
+			risks = append(risks, QueryRisk{
+				Pattern:   RANGE_SCAN_ON_HASH_KEY,
+				TableName: table.qualifiedName(),
+				Reasoning: fmt.Sprintf("Range predicate or ORDER BY on column %q which is the leading column of the index %q. "+
+					"It is hash sharded by default on YugabyteDB, so the query has to scan all the tablets.", column, index.indexName),
+				Suggestion: fmt.Sprintf("Use range sharding (%s ASC/DESC) for the index %q, or add a range sharded index on it.", column, index.indexName),
+			})
+		}
+	}
+
+	for _, pair := range shape.JoinColumns {
+		var tableRefs [2]queryparser.TableRef
+		var joinedTables [2]*riskAssessmentTable
+		for i, column := range pair {
+			tableRef, ok := shape.ResolveColumn(column)
+			if ok {
+				tableRefs[i], joinedTables[i] = tableRef, getTable(tableRef)
+			}
+		}
+		// a self join is on two references(aliases) of the same table, while a comparison of two columns of the same reference is not a join
+		if joinedTables[0] == nil || joinedTables[1] == nil || tableRefs[0] == tableRefs[1] {
+			log.Debugf("skipping join on %v for performance risk assessment as the columns are not resolved to two tables", pair)
+			continue
+		}
+		// the join can use an index on either side
+		if joinedTables[0].getIndexWithLeadingColumn(pair[0].Name) != nil || joinedTables[1].getIndexWithLeadingColumn(pair[1].Name) != nil {
+			continue
+		}
+		suggestion := fmt.Sprintf("Create an index with %q as the leading column on %s or with %q on %s.",
+			pair[0].Name, joinedTables[0].qualifiedName(), pair[1].Name, joinedTables[1].qualifiedName())
+		if !joinedTables[0].isColocated || !joinedTables[1].isColocated {
+			suggestion += " If the joined tables are small, colocating them avoids the cross-node joins."
+		}
+		risks = append(risks, QueryRisk{
+			Pattern: JOIN_WITHOUT_INDEX,
+			Reasoning: fmt.Sprintf("Join on %s.%s = %s.%s where neither column is the leading column of an index on its table. "+
+				"Joins without an index fetch all the rows of the table across the nodes.",
+				joinedTables[0].qualifiedName(), pair[0].Name, joinedTables[1].qualifiedName(), pair[1].Name),
+			Suggestion: suggestion,
+		})
+	}
+
+	if shape.HasRowLocking && query.calls.Int64 >= HOT_ROW_LOCKING_CALLS_THRESHOLD {
+		risks = append(risks, QueryRisk{
+			Pattern: ROW_LOCKING_HOT_ROW,
+			Reasoning: fmt.Sprintf("Row locking(SELECT ... FOR UPDATE/SHARE) executed %d times. Frequently locked rows cause "+
+				"transaction conflicts and retries on YugabyteDB.", query.calls.Int64),
+			Suggestion: "Avoid locking the same rows from the concurrent transactions, for example by using SKIP LOCKED, " +
+				"optimistic concurrency(a version column) or by splitting the hot row(like a counter) into multiple rows.",
+		})
+	}
+	return risks
+}
+
+func getSeqScanRisk(table *riskAssessmentTable, filterColumns []string) QueryRisk {
+	risk := QueryRisk{
+		Pattern:   SEQ_SCAN_ON_LARGE_TABLE,
+		TableName: table.qualifiedName(),
+		Reasoning: fmt.Sprintf("None of the filter columns is the leading column of an index on the table with %d rows, "+
+			"which is hash sharded across the nodes. The query has to scan all the tablets of the table.", table.rowCount),
+	}
+	if len(filterColumns) == 0 {
+		risk.Reasoning = fmt.Sprintf("No filter on the table with %d rows, which is hash sharded across the nodes. "+
+			"The query has to scan all the tablets of the table.", table.rowCount)
+		risk.Suggestion = "Add a selective filter on an indexed column or paginate the query."
+	} else {
+		risk.Suggestion = fmt.Sprintf("Create an index on the filter column(s) %s.", strings.Join(filterColumns, ", "))
+	}
+	return risk
+}
+
+func (adb *AssessmentDB) fetchTopExecutedQueries(limit int) ([]executedQuery, error) {
+	query := fmt.Sprintf(`SELECT queryid, query, calls, total_exec_time FROM %s
+	ORDER BY total_exec_time DESC NULLS LAST, calls DESC NULLS LAST
+	LIMIT %d;`, DB_QUERIES_SUMMARY, limit)
+	rows, err := adb.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying-%s: %w", query, err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warnf("failed to close result set for query: [%s]", query)
+		}
+	}()
+
+	var result []executedQuery
+	for rows.Next() {
+		var q executedQuery
+		var queryId sql.NullInt64
+		err := rows.Scan(&queryId, &q.query, &q.calls, &q.totalExecTime)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+		q.queryId = queryId.Int64
+		result = append(result, q)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rows: %w", err)
+	}
+	return result, nil
+}
+
+func (adb *AssessmentDB) fetchRiskAssessmentTables() ([]*riskAssessmentTable, error) {
+	query := fmt.Sprintf(`SELECT schema_name, object_name, row_count FROM %s WHERE is_index = 0;`, TABLE_INDEX_STATS)
+	rows, err := adb.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying-%s: %w", query, err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warnf("failed to close result set for query: [%s]", query)
+		}
+	}()
+
+	var colocatedTables []string
+	if SizingReport != nil {
+		colocatedTables = SizingReport.SizingRecommendation.ColocatedTables
+	}
+	tablesByName := make(map[string]*riskAssessmentTable)
+	var result []*riskAssessmentTable
+	for rows.Next() {
+		var table riskAssessmentTable
+		var rowCount sql.NullInt64
+		if err := rows.Scan(&table.schemaName, &table.tableName, &rowCount); err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+		table.rowCount = rowCount.Int64
+		table.isColocated = slices.Contains(colocatedTables, table.qualifiedName())
+		tablesByName[table.qualifiedName()] = &table
+		result = append(result, &table)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rows: %w", err)
+	}
+
+	indexes, err := adb.fetchIndexDefinitions()
+	if err != nil {
+		return nil, fmt.Errorf("fetching index definitions: %w", err)
+	}
+	for _, index := range indexes {
+		if table, ok := tablesByName[index.schemaName+"."+index.tableName]; ok {
+			table.indexes = append(table.indexes, index)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,82 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package migassessment
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/query/queryparser"
+)
+
+func TestQueryRisks(t *testing.T) {
+	tables := []*riskAssessmentTable{
+		{schemaName: "public", tableName: "orders", rowCount: 5000000, indexes: []*indexDefinition{
+			{indexName: "orders_pkey", keyColumns: []string{"id"}, isPrimaryKey: true},
+			{indexName: "orders_created_at_idx", keyColumns: []string{"created_at"}},
+		}},
+		{schemaName: "public", tableName: "customers", rowCount: 100, isColocated: true, indexes: []*indexDefinition{
+			{indexName: "customers_pkey", keyColumns: []string{"id"}, isPrimaryKey: true},
+		}},
+		{schemaName: "public", tableName: "items", rowCount: 100},
+		{schemaName: "public", tableName: "tags", rowCount: 100},
+	}
+	rangeShardedIndexes := []string{"public.orders_created_at_idx"}
+
+	tests := []struct {
+		query            string
+		calls            int64
+		expectedPatterns []string
+	}{
+		{`SELECT * FROM orders WHERE id = $1`, 10, nil},
+		{`SELECT * FROM orders WHERE status = $1`, 10, []string{SEQ_SCAN_ON_LARGE_TABLE}},
+		{`SELECT * FROM orders WHERE id > $1 ORDER BY id`, 10, []string{RANGE_SCAN_ON_HASH_KEY}},
+		// range sharding recommended for the index
+		{`SELECT * FROM orders WHERE created_at > $1`, 10, nil},
+		// either side of the join is indexed
+		{`SELECT * FROM orders o JOIN customers c ON c.id = o.customer_id WHERE o.id = $1`, 10, nil},
+		{`SELECT * FROM items i JOIN tags t ON t.item_name = i.name`, 10, []string{JOIN_WITHOUT_INDEX}},
+		{`SELECT * FROM customers WHERE id = $1 FOR UPDATE`, 50000, []string{ROW_LOCKING_HOT_ROW}},
+		{`SELECT * FROM customers WHERE id = $1 FOR UPDATE`, 10, nil},
+		// columns resolved via the aliases: the filter is on the indexed id of customers, not of orders
+		{`SELECT * FROM orders o JOIN customers c ON c.id = o.customer_id WHERE c.id = $1`, 10, []string{SEQ_SCAN_ON_LARGE_TABLE}},
+		{`SELECT * FROM orders JOIN customers ON customers.id = orders.customer_id WHERE orders.created_at > $1`, 10, nil},
+		// the unqualified columns are ambiguous in a multi table query
+		{`SELECT * FROM orders o JOIN customers c ON c.id = o.customer_id WHERE id > $1 ORDER BY id`, 10, nil},
+		{`SELECT * FROM items JOIN tags ON item_name = name`, 10, nil},
+		// self join
+		{`SELECT * FROM items i1 JOIN items i2 ON i1.name = i2.parent_name`, 10, []string{JOIN_WITHOUT_INDEX}},
+		// comparison of the columns of the same table is not a join
+		{`SELECT * FROM items WHERE name = parent_name`, 10, nil},
+	}
+	for _, tc := range tests {
+		shape, err := queryparser.GetQueryShape(tc.query)
+		assert.NoError(t, err)
+		query := executedQuery{query: tc.query, calls: sql.NullInt64{Int64: tc.calls, Valid: true}}
+		risks := getQueryRisks(query, shape, tables, rangeShardedIndexes)
+		patterns := lo.Map(risks, func(r QueryRisk, _ int) string { return r.Pattern })
+		assert.ElementsMatch(t, tc.expectedPatterns, patterns, "query: %s", tc.query)
+		for _, risk := range risks {
+			assert.NotEmpty(t, risk.Reasoning)
+			assert.NotEmpty(t, risk.Suggestion)
+		}
+	}
+}
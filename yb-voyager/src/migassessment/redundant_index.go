@@ -0,0 +1,261 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package migassessment
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+)
+
+/*
+Every index on a table adds to the import time and the write amplification on YugabyteDB.
+RedundantIndexAssessment finds the indexes which can be dropped:
+  - duplicate: same key columns, included columns and predicate as another index on the table
+  - prefix: key columns are a prefix of the key columns of another btree index on the table
+  - unused: zero index scans in the source statistics
+
+Indexes backing the constraints(primary key, unique, exclusion, referenced by foreign keys) are never reported,
+and the unique indexes are reported only if another unique index covers them.
+*/
+
+const (
+	DUPLICATE_INDEX = "DUPLICATE_INDEX"
+	PREFIX_INDEX    = "PREFIX_INDEX"
+	UNUSED_INDEX    = "UNUSED_INDEX"
+)
+
+type RedundantIndex struct {
+	SchemaName                      string   `json:"SchemaName"`
+	IndexName                       string   `json:"IndexName"`
+	TableName                       string   `json:"TableName"`
+	Reason                          string   `json:"Reason"` // DUPLICATE_INDEX, PREFIX_INDEX or UNUSED_INDEX
+	CoveringIndexName               string   `json:"CoveringIndexName,omitempty"`
+	KeyColumns                      []string `json:"KeyColumns"`
+	SizeInBytes                     int64    `json:"SizeInBytes"`
+	EstimatedImportTimeSavingsInMin float64  `json:"EstimatedImportTimeSavingsInMin"`
+}
+
+type indexDefinition struct {
+	schemaName     string
+	indexName      string
+	tableName      string
+	indexMethod    string
+	isPrimaryKey   bool
+	isUnique       bool
+	isConstraint   bool
+	keyColumns     []string
+	includeColumns []string
+	predicate      string
+	scans          sql.NullInt64
+	sizeInBytes    int64
+}
+
+// indexes which are required on the target irrespective of the other indexes
+func (d *indexDefinition) isRequired() bool {
+	return d.isPrimaryKey || d.isConstraint
+}
+
+func RedundantIndexAssessment(adb *AssessmentDB) ([]RedundantIndex, error) {
+	if SourceDBType != "postgresql" {
+		return nil, nil
+	}
+
+	indexes, err := adb.fetchIndexDefinitions()
+	if err != nil {
+		return nil, fmt.Errorf("fetching index definitions: %w", err)
+	}
+	if len(indexes) == 0 {
+		log.Infof("index definitions not present in the assessment metadata for redundant index assessment")
+		return nil, nil
+	}
+
+	redundantIndexes := findRedundantIndexes(indexes)
+	for i := range redundantIndexes {
+		savings, ok := EstimateImportTimeSavingsOfDroppingIndex(redundantIndexes[i].SchemaName, redundantIndexes[i].IndexName)
+		if ok {
+			redundantIndexes[i].EstimatedImportTimeSavingsInMin = savings
+		}
+		log.Infof("redundant index %s.%s: %s", redundantIndexes[i].SchemaName, redundantIndexes[i].IndexName, redundantIndexes[i].Reason)
+	}
+	return redundantIndexes, nil
+}
+
+func findRedundantIndexes(indexes []*indexDefinition) []RedundantIndex {
+	// group the indexes by table to compare the indexes of a table with each other
+	indexesByTable := make(map[string][]*indexDefinition)
+	var tables []string
+	for _, index := range indexes {
+		key := index.schemaName + "." + index.tableName
+		if _, ok := indexesByTable[key]; !ok {
+			tables = append(tables, key)
+		}
+		indexesByTable[key] = append(indexesByTable[key], index)
+	}
+	sort.Strings(tables)
+
+	var result []RedundantIndex
+	for _, table := range tables {
+		tableIndexes := indexesByTable[table]
+		redundancyReasons := resolveRedundancyReasons(tableIndexes)
+
+		sort.Slice(tableIndexes, func(i, j int) bool {
+			return tableIndexes[i].indexName < tableIndexes[j].indexName
+		})
+		for _, index := range tableIndexes {
+			reason, ok := redundancyReasons[index]
+			if !ok {
+				continue
+			}
+			result = append(result, RedundantIndex{
+				SchemaName:        index.schemaName,
+				IndexName:         index.indexName,
+				TableName:         index.tableName,
+				Reason:            reason[0],
+				CoveringIndexName: reason[1],
+				KeyColumns:        index.keyColumns,
+				SizeInBytes:       index.sizeInBytes,
+			})
+		}
+	}
+	return result
+}
+
+/*
+resolveRedundancyReasons returns the [reason, covering index] of the redundant indexes of a table.
+
+An index is reported as covered only by an index which is retained, otherwise both the indexes of a chain
+(e.g. (a) prefix of (a,b) duplicate of (a,b)) can be dropped while the workload still needs one of them.
+The indexes are resolved in an order in which a covering index always comes before the indexes it covers:
+longer keys first, then the required, unique and by name. So the fate of the covering index is already known.
+*/
+func resolveRedundancyReasons(tableIndexes []*indexDefinition) map[*indexDefinition][2]string {
+	ordered := slices.Clone(tableIndexes)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if len(a.keyColumns) != len(b.keyColumns) {
+			return len(a.keyColumns) > len(b.keyColumns)
+		}
+		if a.isRequired() != b.isRequired() {
+			return a.isRequired()
+		}
+		if a.isUnique != b.isUnique {
+			return a.isUnique
+		}
+		return a.indexName < b.indexName
+	})
+
+	result := make(map[*indexDefinition][2]string)
+	for i, index := range ordered {
+		// only the indexes resolved before this one can cover it, and only if they are retained
+		retainedIndexes := lo.Filter(ordered[:i], func(other *indexDefinition, _ int) bool {
+			_, removed := result[other]
+			return !removed
+		})
+		reason, coveringIndex := getRedundancyReason(index, retainedIndexes)
+		if reason != "" {
+			result[index] = [2]string{reason, coveringIndex}
+		}
+	}
+	return result
+}
+
+func getRedundancyReason(index *indexDefinition, candidateIndexes []*indexDefinition) (string, string) {
+	if index.isRequired() {
+		return "", ""
+	}
+
+	comparableIndexes := lo.Filter(candidateIndexes, func(other *indexDefinition, _ int) bool {
+		return other != index && other.indexMethod == index.indexMethod && other.predicate == index.predicate
+	})
+
+	for _, other := range comparableIndexes {
+		if !slices.Equal(other.keyColumns, index.keyColumns) || !slices.Equal(other.includeColumns, index.includeColumns) {
+			continue
+		}
+		// out of the duplicates, keep the one required for constraints/uniqueness or else the first one by name
+		if index.isUnique && !other.isUnique {
+			continue
+		}
+		if other.isRequired() || (other.isUnique && !index.isUnique) || other.indexName < index.indexName {
+			return DUPLICATE_INDEX, other.indexName
+		}
+	}
+
+	if !index.isUnique && index.indexMethod == "btree" && len(index.includeColumns) == 0 {
+		for _, other := range comparableIndexes {
+			if len(index.keyColumns) < len(other.keyColumns) && slices.Equal(other.keyColumns[:len(index.keyColumns)], index.keyColumns) {
+				return PREFIX_INDEX, other.indexName
+			}
+		}
+	}
+
+	if !index.isUnique && index.scans.Valid && index.scans.Int64 == 0 {
+		return UNUSED_INDEX, ""
+	}
+	return "", ""
+}
+
+func (adb *AssessmentDB) fetchIndexDefinitions() ([]*indexDefinition, error) {
+	query := fmt.Sprintf(`SELECT idef.schema_name, idef.index_name, idef.table_name, idef.index_method, idef.is_primary_key,
+		idef.is_unique, idef.is_constraint, idef.key_columns, idef.include_columns, idef.predicate, tis.reads, tis.size_in_bytes
+	FROM %s idef
+	LEFT JOIN %s tis ON idef.schema_name = tis.schema_name AND idef.index_name = tis.object_name AND tis.is_index = 1;`,
+		INDEX_DEFINITIONS, TABLE_INDEX_STATS)
+	rows, err := adb.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying-%s: %w", query, err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warnf("failed to close result set for query: [%s]", query)
+		}
+	}()
+
+	var result []*indexDefinition
+	for rows.Next() {
+		var index indexDefinition
+		var keyColumns, includeColumns, predicate sql.NullString
+		var sizeInBytes sql.NullInt64
+		err := rows.Scan(&index.schemaName, &index.indexName, &index.tableName, &index.indexMethod, &index.isPrimaryKey,
+			&index.isUnique, &index.isConstraint, &keyColumns, &includeColumns, &predicate, &index.scans, &sizeInBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+		err = json.Unmarshal([]byte(keyColumns.String), &index.keyColumns)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing key columns %q of index %s: %w", keyColumns.String, index.indexName, err)
+		}
+		if includeColumns.String != "" {
+			err = json.Unmarshal([]byte(includeColumns.String), &index.includeColumns)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing include columns %q of index %s: %w", includeColumns.String, index.indexName, err)
+			}
+		}
+		index.predicate = predicate.String
+		index.sizeInBytes = sizeInBytes.Int64
+		result = append(result, &index)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rows: %w", err)
+	}
+	return result, nil
+}
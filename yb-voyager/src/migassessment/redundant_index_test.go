@@ -0,0 +1,137 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package migassessment
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindRedundantIndexes(t *testing.T) {
+	newIndex := func(name string, keyColumns ...string) *indexDefinition {
+		return &indexDefinition{
+			schemaName:  "public",
+			tableName:   "orders",
+			indexName:   name,
+			indexMethod: "btree",
+			keyColumns:  keyColumns,
+			scans:       sql.NullInt64{Int64: 10, Valid: true},
+		}
+	}
+
+	pkey := newIndex("orders_pkey", "id")
+	pkey.isPrimaryKey, pkey.isUnique, pkey.isConstraint = true, true, true
+	dupOfPkey := newIndex("orders_id_idx", "id")
+
+	customerDate := newIndex("orders_customer_date_idx", "customer_id", "order_date")
+	customer := newIndex("orders_customer_idx", "customer_id")
+	// prefix of an index but unique, hence required
+	uniqueCustomer := newIndex("orders_customer_uniq", "customer_id")
+	uniqueCustomer.isUnique = true
+
+	// duplicates, the first one by name is retained
+	status1 := newIndex("orders_status_idx1", "status")
+	status2 := newIndex("orders_status_idx2", "status")
+	// different predicate
+	partialStatus := newIndex("orders_status_partial_idx", "status")
+	partialStatus.predicate = "(status = 'open'::text)"
+
+	unused := newIndex("orders_note_idx", "lower(note)")
+	unused.scans = sql.NullInt64{Int64: 0, Valid: true}
+	// no stats available
+	noStats := newIndex("orders_amount_idx", "amount")
+	noStats.scans = sql.NullInt64{}
+
+	// hash indexes are not reported as prefixes
+	hashCustomer := newIndex("orders_customer_hash_idx", "customer_id")
+	hashCustomer.indexMethod = "hash"
+
+	result := findRedundantIndexes([]*indexDefinition{pkey, dupOfPkey, customerDate, customer, uniqueCustomer,
+		status1, status2, partialStatus, unused, noStats, hashCustomer})
+
+	actual := make(map[string][2]string)
+	for _, r := range result {
+		actual[r.IndexName] = [2]string{r.Reason, r.CoveringIndexName}
+	}
+	assert.Equal(t, map[string][2]string{
+		"orders_id_idx":       {DUPLICATE_INDEX, "orders_pkey"},
+		"orders_customer_idx": {DUPLICATE_INDEX, "orders_customer_uniq"},
+		"orders_status_idx2":  {DUPLICATE_INDEX, "orders_status_idx1"},
+		"orders_note_idx":     {UNUSED_INDEX, ""},
+	}, actual)
+
+	// prefix index
+	result = findRedundantIndexes([]*indexDefinition{newIndex("orders_a_idx", "a"), newIndex("orders_a_b_idx", "a", "b")})
+	assert.Equal(t, 1, len(result))
+	assert.Equal(t, "orders_a_idx", result[0].IndexName)
+	assert.Equal(t, PREFIX_INDEX, result[0].Reason)
+	assert.Equal(t, "orders_a_b_idx", result[0].CoveringIndexName)
+}
+
+func TestFindRedundantIndexesChained(t *testing.T) {
+	newIndex := func(name string, scans int64, keyColumns ...string) *indexDefinition {
+		return &indexDefinition{
+			schemaName:  "public",
+			tableName:   "orders",
+			indexName:   name,
+			indexMethod: "btree",
+			keyColumns:  keyColumns,
+			scans:       sql.NullInt64{Int64: scans, Valid: true},
+		}
+	}
+	reasons := func(result []RedundantIndex) map[string][2]string {
+		actual := make(map[string][2]string)
+		for _, r := range result {
+			actual[r.IndexName] = [2]string{r.Reason, r.CoveringIndexName}
+		}
+		return actual
+	}
+
+	// (a) is a prefix of (a,b) which is a duplicate of (a,b) with a smaller name: (a) is covered by the retained one
+	result := findRedundantIndexes([]*indexDefinition{
+		newIndex("orders_a_idx", 10, "a"),
+		newIndex("orders_a_b_idx1", 10, "a", "b"),
+		newIndex("orders_a_b_idx2", 10, "a", "b"),
+	})
+	assert.Equal(t, map[string][2]string{
+		"orders_a_idx":    {PREFIX_INDEX, "orders_a_b_idx1"},
+		"orders_a_b_idx2": {DUPLICATE_INDEX, "orders_a_b_idx1"},
+	}, reasons(result))
+
+	// (a,b) is unused, so (a) is not reported as its prefix
+	result = findRedundantIndexes([]*indexDefinition{
+		newIndex("orders_a_idx", 10, "a"),
+		newIndex("orders_a_b_idx", 0, "a", "b"),
+	})
+	assert.Equal(t, map[string][2]string{
+		"orders_a_b_idx": {UNUSED_INDEX, ""},
+	}, reasons(result))
+
+	// (a) prefix of (a,b) prefix of (a,b,c): both are covered by (a,b,c)
+	result = findRedundantIndexes([]*indexDefinition{
+		newIndex("orders_a_idx", 10, "a"),
+		newIndex("orders_a_b_idx", 10, "a", "b"),
+		newIndex("orders_a_b_c_idx", 10, "a", "b", "c"),
+	})
+	assert.Equal(t, map[string][2]string{
+		"orders_a_idx":   {PREFIX_INDEX, "orders_a_b_c_idx"},
+		"orders_a_b_idx": {PREFIX_INDEX, "orders_a_b_c_idx"},
+	}, reasons(result))
+}
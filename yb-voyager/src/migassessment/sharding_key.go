@@ -0,0 +1,246 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package migassessment
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/query/queryparser"
+)
+
+/*
+Monotonically increasing keys(sequences, identity columns, timestamps) are hash sharded by default on YugabyteDB.
+Hash sharding spreads the inserts across the tablets but range queries/ORDER BY on such keys have to scan all the tablets.
+Range sharding(ASC/DESC) serves the range queries efficiently but all the new rows go to the last tablet i.e. a write hotspot.
+
+ShardingKeyAssessment flags such leading key columns of the primary keys and indexes, and recommends the sharding
+based on the write rate of the table and the range queries(from pg_stat_statements) on the column.
+*/
+
+const (
+	SHARDING_HASH = "HASH"
+	SHARDING_ASC  = "ASC"
+	SHARDING_DESC = "DESC"
+
+	MONOTONIC_SEQUENCE  = "sequence"
+	MONOTONIC_IDENTITY  = "identity"
+	MONOTONIC_TIMESTAMP = "timestamp"
+
+	// write rate of a table above which the write hotspot of range sharding outweighs the benefit for the range queries
+	HOTSPOT_WRITES_PER_SECOND_THRESHOLD = 100
+)
+
+var monotonicDataTypes = []string{"timestamp without time zone", "timestamp with time zone", "timestamp", "timestamptz", "date"}
+
+type ShardingKeyRecommendation struct {
+	SchemaName      string `json:"SchemaName"`
+	TableName       string `json:"TableName"`
+	IndexName       string `json:"IndexName"`
+	IsPrimaryKey    bool   `json:"IsPrimaryKey"`
+	ColumnName      string `json:"ColumnName"`
+	DataType        string `json:"DataType"`
+	MonotonicReason string `json:"MonotonicReason"` // sequence, identity or timestamp
+	WritesPerSecond int64  `json:"WritesPerSecond"`
+	RangeQueries    int    `json:"RangeQueries"` // number of queries with range predicates or ORDER BY on the column
+	Sharding        string `json:"Sharding"`     // HASH, ASC or DESC
+	Reasoning       string `json:"Reasoning"`
+}
+
+func (r *ShardingKeyRecommendation) IsRangeSharding() bool {
+	return r.Sharding == SHARDING_ASC || r.Sharding == SHARDING_DESC
+}
+
+type indexLeadingColumn struct {
+	schemaName      string
+	tableName       string
+	indexName       string
+	isPrimaryKey    bool
+	columnName      string
+	dataType        string
+	isIdentity      bool
+	ownedSequence   string
+	columnDefault   string
+	writesPerSecond int64
+}
+
+func ShardingKeyAssessment(adb *AssessmentDB) ([]ShardingKeyRecommendation, error) {
+	if SourceDBType != "postgresql" {
+		return nil, nil
+	}
+
+	leadingColumns, err := adb.fetchIndexLeadingColumns()
+	if err != nil {
+		return nil, fmt.Errorf("fetching leading columns of indexes: %w", err)
+	}
+	if len(leadingColumns) == 0 {
+		log.Infof("index leading columns info not present in the assessment metadata for sharding key assessment")
+		return nil, nil
+	}
+
+	queryShapes, err := adb.fetchQueryShapes()
+	if err != nil {
+		return nil, fmt.Errorf("fetching query shapes: %w", err)
+	}
+
+	var recommendations []ShardingKeyRecommendation
+	for _, col := range leadingColumns {
+		monotonicReason := getMonotonicReason(col)
+		if monotonicReason == "" {
+			continue
+		}
+		recommendation := recommendSharding(col, monotonicReason, queryShapes)
+		log.Infof("sharding key recommendation for index %s.%s on column %q: %s", col.schemaName, col.indexName,
+			col.columnName, recommendation.Sharding)
+		recommendations = append(recommendations, recommendation)
+	}
+	return recommendations, nil
+}
+
+func getMonotonicReason(col indexLeadingColumn) string {
+	switch {
+	case col.isIdentity:
+		return MONOTONIC_IDENTITY
+	case col.ownedSequence != "" || strings.HasPrefix(strings.ToLower(col.columnDefault), "nextval("):
+		return MONOTONIC_SEQUENCE
+	case slices.Contains(monotonicDataTypes, strings.ToLower(col.dataType)):
+		return MONOTONIC_TIMESTAMP
+	}
+	return ""
+}
+
+func recommendSharding(col indexLeadingColumn, monotonicReason string, queryShapes []*queryparser.QueryShape) ShardingKeyRecommendation {
+	recommendation := ShardingKeyRecommendation{
+		SchemaName:      col.schemaName,
+		TableName:       col.tableName,
+		IndexName:       col.indexName,
+		IsPrimaryKey:    col.isPrimaryKey,
+		ColumnName:      col.columnName,
+		DataType:        col.dataType,
+		MonotonicReason: monotonicReason,
+		WritesPerSecond: col.writesPerSecond,
+		Sharding:        SHARDING_HASH,
+	}
+
+	var ascQueries, descQueries int
+	for _, shape := range queryShapes {
+		if !shape.ReferencesRelation(col.schemaName, col.tableName) {
+			continue
+		}
+		usage, ok := shape.Columns[strings.ToLower(col.columnName)]
+		if !ok || !(usage.RangeFilter || usage.OrderByAsc || usage.OrderByDesc) {
+			continue
+		}
+		recommendation.RangeQueries++
+		if usage.OrderByDesc {
+			descQueries++
+		} else {
+			ascQueries++
+		}
+	}
+
+	switch {
+	case recommendation.RangeQueries == 0:
+		recommendation.Reasoning = fmt.Sprintf("No range queries or ORDER BY found on the monotonically increasing(%s) column %q. "+
+			"Hash sharding distributes the inserts across all the tablets.", monotonicReason, col.columnName)
+	case col.writesPerSecond >= HOTSPOT_WRITES_PER_SECOND_THRESHOLD:
+		recommendation.Reasoning = fmt.Sprintf("%d queries use range predicates or ORDER BY on the monotonically increasing(%s) column %q, "+
+			"but with %d writes per second range sharding would direct all the inserts to a single tablet(write hotspot). "+
+			"Hash sharding is recommended; consider adding a leading bucket column to serve the range queries.",
+			recommendation.RangeQueries, monotonicReason, col.columnName, col.writesPerSecond)
+	default:
+		recommendation.Sharding = lo.Ternary(descQueries > ascQueries, SHARDING_DESC, SHARDING_ASC)
+		recommendation.Reasoning = fmt.Sprintf("%d queries use range predicates or ORDER BY on the monotonically increasing(%s) column %q "+
+			"and the write rate(%d writes per second) is low enough for range sharding.",
+			recommendation.RangeQueries, monotonicReason, col.columnName, col.writesPerSecond)
+	}
+	return recommendation
+}
+
+func (adb *AssessmentDB) fetchIndexLeadingColumns() ([]indexLeadingColumn, error) {
+	query := fmt.Sprintf(`SELECT ilc.schema_name, ilc.table_name, ilc.index_name, ilc.is_primary_key, ilc.column_name,
+		tcdt.data_type, ilc.is_identity, ilc.owned_sequence, ilc.column_default, tis.writes_per_second
+	FROM %s ilc
+	LEFT JOIN %s tcdt ON ilc.schema_name = tcdt.schema_name AND ilc.table_name = tcdt.table_name AND ilc.column_name = tcdt.column_name
+	LEFT JOIN %s tis ON ilc.schema_name = tis.schema_name AND ilc.table_name = tis.object_name AND tis.is_index = 0
+	ORDER BY ilc.schema_name, ilc.table_name, ilc.index_name;`, INDEX_LEADING_COLUMNS, TABLE_COLUMNS_DATA_TYPES, TABLE_INDEX_STATS)
+	rows, err := adb.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying-%s: %w", query, err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warnf("failed to close result set for query: [%s]", query)
+		}
+	}()
+
+	var result []indexLeadingColumn
+	for rows.Next() {
+		var col indexLeadingColumn
+		var dataType, ownedSequence, columnDefault sql.NullString
+		var writesPerSecond sql.NullInt64
+		err := rows.Scan(&col.schemaName, &col.tableName, &col.indexName, &col.isPrimaryKey, &col.columnName,
+			&dataType, &col.isIdentity, &ownedSequence, &columnDefault, &writesPerSecond)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+		col.dataType = dataType.String
+		col.ownedSequence = ownedSequence.String
+		col.columnDefault = columnDefault.String
+		col.writesPerSecond = writesPerSecond.Int64
+		result = append(result, col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rows: %w", err)
+	}
+	return result, nil
+}
+
+func (adb *AssessmentDB) fetchQueryShapes() ([]*queryparser.QueryShape, error) {
+	query := fmt.Sprintf("SELECT DISTINCT query FROM %s", DB_QUERIES_SUMMARY)
+	rows, err := adb.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying-%s: %w", query, err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warnf("failed to close result set for query: [%s]", query)
+		}
+	}()
+
+	var shapes []*queryparser.QueryShape
+	for rows.Next() {
+		var executedQuery string
+		if err := rows.Scan(&executedQuery); err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+		shape, err := queryparser.GetQueryShape(executedQuery)
+		if err != nil {
+			log.Debugf("skipping query for sharding key assessment - [%s]: %v", executedQuery, err)
+			continue
+		}
+		shapes = append(shapes, shape)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rows: %w", err)
+	}
+	return shapes, nil
+}
@@ -0,0 +1,87 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package migassessment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/query/queryparser"
+)
+
+func TestShardingKeyRecommendation(t *testing.T) {
+	var queryShapes []*queryparser.QueryShape
+	for _, query := range []string{
+		`SELECT * FROM public.events WHERE created_at BETWEEN $1 AND $2`,
+		`SELECT * FROM events e WHERE e.created_at > $1 ORDER BY e.created_at DESC LIMIT $2`,
+		`SELECT * FROM events ORDER BY created_at DESC`,
+		`SELECT * FROM orders WHERE id = $1`,
+		`SELECT * FROM logs WHERE id >= $1 ORDER BY id`,
+	} {
+		shape, err := queryparser.GetQueryShape(query)
+		assert.NoError(t, err)
+		queryShapes = append(queryShapes, shape)
+	}
+
+	tests := []struct {
+		col              indexLeadingColumn
+		expectedReason   string
+		expectedSharding string
+		expectedQueries  int
+	}{
+		{
+			col:              indexLeadingColumn{schemaName: "public", tableName: "events", indexName: "events_created_at_idx", columnName: "created_at", dataType: "timestamp without time zone", writesPerSecond: 10},
+			expectedReason:   MONOTONIC_TIMESTAMP,
+			expectedSharding: SHARDING_DESC,
+			expectedQueries:  3,
+		},
+		{
+			// range queries but high write rate
+			col:              indexLeadingColumn{schemaName: "public", tableName: "events", indexName: "events_created_at_idx", columnName: "created_at", dataType: "timestamp with time zone", writesPerSecond: 500},
+			expectedReason:   MONOTONIC_TIMESTAMP,
+			expectedSharding: SHARDING_HASH,
+			expectedQueries:  3,
+		},
+		{
+			// only point lookups
+			col:              indexLeadingColumn{schemaName: "public", tableName: "orders", indexName: "orders_pkey", isPrimaryKey: true, columnName: "id", dataType: "bigint", ownedSequence: "public.orders_id_seq"},
+			expectedReason:   MONOTONIC_SEQUENCE,
+			expectedSharding: SHARDING_HASH,
+		},
+		{
+			col:              indexLeadingColumn{schemaName: "public", tableName: "logs", indexName: "logs_pkey", isPrimaryKey: true, columnName: "id", dataType: "integer", isIdentity: true},
+			expectedReason:   MONOTONIC_IDENTITY,
+			expectedSharding: SHARDING_ASC,
+			expectedQueries:  1,
+		},
+	}
+	for _, tc := range tests {
+		reason := getMonotonicReason(tc.col)
+		assert.Equal(t, tc.expectedReason, reason, "index: %s", tc.col.indexName)
+		recommendation := recommendSharding(tc.col, reason, queryShapes)
+		assert.Equal(t, tc.expectedSharding, recommendation.Sharding, "index: %s", tc.col.indexName)
+		assert.Equal(t, tc.expectedQueries, recommendation.RangeQueries, "index: %s", tc.col.indexName)
+		assert.NotEmpty(t, recommendation.Reasoning)
+	}
+
+	// not monotonically increasing
+	assert.Equal(t, "", getMonotonicReason(indexLeadingColumn{columnName: "email", dataType: "text"}))
+	assert.Equal(t, MONOTONIC_SEQUENCE, getMonotonicReason(indexLeadingColumn{columnName: "id", dataType: "bigint",
+		columnDefault: "nextval('public.id_seq'::regclass)"}))
+}
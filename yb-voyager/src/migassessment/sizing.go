@@ -46,6 +46,8 @@ type SourceDBMetadata struct {
 	IsIndex         bool            `db:"is_index,string"`
 	ParentTableName sql.NullString  `db:"parent_table_name"`
 	Size            sql.NullFloat64 `db:"size_in_bytes,string"`
+	// size(in GB) of the exported data of the table estimated from the data profile, if profiled
+	ProfiledDataSize sql.NullFloat64
 }
 
 type ExpDataShardedLimit struct {
@@ -155,7 +157,13 @@ func SizingAssessment() error {
 		return fmt.Errorf("failed to load source metadata: %w", err)
 	}
 
-	experimentDB, err := createConnectionToExperimentData()
+	err = loadProfiledDataSizes(GetSourceMetadataDBFilePath(), sourceTableMetadata)
+	if err != nil {
+		// data profiling is optional, the sizes of the tables on the source are used instead
+		log.Warnf("failed to load the profiled data sizes of the tables: %v", err)
+	}
+
+	experimentDB, experimentDataset, err := createConnectionToExperimentData()
 	if err != nil {
 		SizingReport.FailureReasoning = fmt.Sprintf("failed to connect to experiment data: %v", err)
 		return fmt.Errorf("failed to connect to experiment data: %w", err)
@@ -185,19 +193,13 @@ func SizingAssessment() error {
 		return fmt.Errorf("error fetching the sharded throughput: %w", err)
 	}
 
-	sizingRecommendationPerCore := createSizingRecommendationStructure(colocatedLimits)
-
-	sizingRecommendationPerCore = shardingBasedOnTableSizeAndCount(sourceTableMetadata, sourceIndexMetadata,
-		colocatedLimits, sizingRecommendationPerCore)
-
-	sizingRecommendationPerCore = shardingBasedOnOperations(sourceIndexMetadata, colocatedThroughput, sizingRecommendationPerCore)
-
-	sizingRecommendationPerCore = checkShardedTableLimit(sourceIndexMetadata, shardedLimits, sizingRecommendationPerCore)
-
-	sizingRecommendationPerCore = findNumNodesNeededBasedOnThroughputRequirement(sourceIndexMetadata, shardedThroughput, sizingRecommendationPerCore)
-
-	sizingRecommendationPerCore = findNumNodesNeededBasedOnTabletsRequired(sourceIndexMetadata, shardedLimits, sizingRecommendationPerCore)
-	finalSizingRecommendation := pickBestRecommendation(sizingRecommendationPerCore)
+	expData := &sizingExperimentData{
+		colocatedLimits:     colocatedLimits,
+		colocatedThroughput: colocatedThroughput,
+		shardedLimits:       shardedLimits,
+		shardedThroughput:   shardedThroughput,
+	}
+	finalSizingRecommendation := getSizingRecommendation(sourceTableMetadata, sourceIndexMetadata, expData)
 
 	if finalSizingRecommendation.FailureReasoning != "" {
 		SizingReport.FailureReasoning = finalSizingRecommendation.FailureReasoning
@@ -254,6 +256,13 @@ func SizingAssessment() error {
 		SizingReport.FailureReasoning = fmt.Sprintf("calculate time taken for sharded data import: %v", err)
 		return fmt.Errorf("calculate time taken for sharded data import: %w", err)
 	}
+	instanceType, err := getInstanceType(experimentDB, finalSizingRecommendation.VCPUsPerInstance,
+		finalSizingRecommendation.MemoryPerCore)
+	if err != nil {
+		SizingReport.FailureReasoning = fmt.Sprintf("error while fetching instance type from experiment data: %v", err)
+		return fmt.Errorf("error while fetching instance type from experiment data: %w", err)
+	}
+
 	reasoning := getReasoning(finalSizingRecommendation, shardedObjects, cumulativeIndexCountSharded, colocatedObjects,
 		cumulativeIndexCountColocated)
 
@@ -268,12 +277,110 @@ func SizingAssessment() error {
 		ParallelVoyagerJobs:             math.Min(float64(parallelVoyagerJobsColocated), float64(parallelVoyagerJobsSharded)),
 		ColocatedReasoning:              reasoning,
 		EstimatedTimeInMinForImport:     importTimeForColocatedObjects + importTimeForShardedObjects,
+		ReplicationFactor:               SizingParams.getReplicationFactor(),
+		ExperimentDataset:               experimentDataset,
+		InstanceType:                    instanceType,
 	}
 	SizingReport.SizingRecommendation = *sizingRecommendation
-
+	SizingReport.YearlySizing = getYearlySizingProjection(sourceTableMetadata, sourceIndexMetadata, expData)
+
+	importTimeEstimationData = &importTimeEstimation{
+		colocatedTables:     finalSizingRecommendation.ColocatedTables,
+		shardedTables:       finalSizingRecommendation.ShardedTables,
+		sourceIndexMetadata: sourceIndexMetadata,
+		colocatedLoadTimes:  colocatedLoadTimes,
+		shardedLoadTimes:    shardedLoadTimes,
+		indexImpacts:        indexImpactOnLoadTimeCommon,
+		columnsImpacts:      columnsImpactOnLoadTimeCommon,
+	}
 	return nil
 }
 
+// experiment data of the final sizing recommendation, used for estimating the import time of the tables later on
+type importTimeEstimation struct {
+	colocatedTables     []SourceDBMetadata
+	shardedTables       []SourceDBMetadata
+	sourceIndexMetadata []SourceDBMetadata
+	colocatedLoadTimes  []ExpDataLoadTime
+	shardedLoadTimes    []ExpDataLoadTime
+	indexImpacts        []ExpDataLoadTimeIndexImpact
+	columnsImpacts      []ExpDataLoadTimeColumnsImpact
+}
+
+var importTimeEstimationData *importTimeEstimation
+
+/*
+EstimateImportTimeSavingsOfDroppingIndex estimates the reduction in the import time(in minutes) of the parent table
+if the given index is not created on the target. Returns false if the sizing assessment was not done.
+*/
+func EstimateImportTimeSavingsOfDroppingIndex(indexSchema string, indexName string) (float64, bool) {
+	data := importTimeEstimationData
+	if data == nil {
+		return 0, false
+	}
+	index, found := lo.Find(data.sourceIndexMetadata, func(m SourceDBMetadata) bool {
+		return m.SchemaName == indexSchema && m.ObjectName == indexName
+	})
+	if !found || !index.ParentTableName.Valid {
+		return 0, false
+	}
+	remainingIndexes := lo.Filter(data.sourceIndexMetadata, func(m SourceDBMetadata, _ int) bool {
+		return !(m.SchemaName == indexSchema && m.ObjectName == indexName)
+	})
+
+	for _, objectType := range []string{COLOCATED, SHARDED} {
+		tables, loadTimes := data.colocatedTables, data.colocatedLoadTimes
+		if objectType == SHARDED {
+			tables, loadTimes = data.shardedTables, data.shardedLoadTimes
+		}
+		table, found := lo.Find(tables, func(t SourceDBMetadata) bool {
+			return t.SchemaName+"."+t.ObjectName == index.ParentTableName.String
+		})
+		if !found || len(loadTimes) == 0 || len(data.indexImpacts) == 0 {
+			continue
+		}
+		tableImportTimeSec := findImportTimeFromExpDataLoadTime(loadTimes, getImportDataSize(table),
+			lo.Ternary(table.RowCount.Valid, table.RowCount.Float64, 0))
+		columnsFactor := getMultiplicationFactorForImportTimeBasedOnNumColumns(table, data.columnsImpacts, objectType)
+		currentIndexesFactor := getMultiplicationFactorForImportTimeBasedOnIndexes(table, data.sourceIndexMetadata, data.indexImpacts, objectType)
+		remainingIndexesFactor := getMultiplicationFactorForImportTimeBasedOnIndexes(table, remainingIndexes, data.indexImpacts, objectType)
+		savingsInMin := (currentIndexesFactor - remainingIndexesFactor) * columnsFactor * tableImportTimeSec / 60
+		return math.Max(math.Round(savingsInMin*100)/100, 0), true
+	}
+	return 0, false
+}
+
+// experiment data required for the sizing recommendation of a given source workload
+type sizingExperimentData struct {
+	colocatedLimits     []ExpDataColocatedLimit
+	colocatedThroughput []ExpDataThroughput
+	shardedLimits       []ExpDataShardedLimit
+	shardedThroughput   []ExpDataThroughput
+}
+
+/*
+getSizingRecommendation runs all the sizing steps for the given source tables and indexes and returns the best
+recommendation out of the ones per core count.
+*/
+func getSizingRecommendation(sourceTableMetadata []SourceDBMetadata, sourceIndexMetadata []SourceDBMetadata,
+	expData *sizingExperimentData) IntermediateRecommendation {
+	sizingRecommendationPerCore := createSizingRecommendationStructure(expData.colocatedLimits)
+
+	sizingRecommendationPerCore = shardingBasedOnTableSizeAndCount(sourceTableMetadata, sourceIndexMetadata,
+		expData.colocatedLimits, sizingRecommendationPerCore)
+
+	sizingRecommendationPerCore = shardingBasedOnOperations(sourceIndexMetadata, expData.colocatedThroughput, sizingRecommendationPerCore)
+
+	sizingRecommendationPerCore = checkShardedTableLimit(sourceIndexMetadata, expData.shardedLimits, sizingRecommendationPerCore)
+
+	sizingRecommendationPerCore = findNumNodesNeededBasedOnThroughputRequirement(sourceIndexMetadata, expData.shardedThroughput, sizingRecommendationPerCore)
+
+	sizingRecommendationPerCore = findNumNodesNeededBasedOnTabletsRequired(sourceIndexMetadata, expData.shardedLimits, sizingRecommendationPerCore)
+
+	sizingRecommendationPerCore = alignNumNodesWithZones(sizingRecommendationPerCore)
+	return pickBestRecommendation(sizingRecommendationPerCore)
+}
+
 /*
 pickBestRecommendation selects the best recommendation from a map of recommendations by optimizing for the cores. Hence,
 we chose the setup where the number of cores is less.
@@ -351,9 +458,10 @@ func findNumNodesNeededBasedOnThroughputRequirement(sourceIndexMetadata []Source
 		}
 
 		// Calculate needed cores based on cumulative operations per second
+		// experiment data is with RF=3, every write is replicated to replication factor number of nodes
 		neededCores :=
 			math.Ceil(float64(cumulativeSelectOpsPerSec)/shardedThroughput.maxSupportedSelectsPerCore.Float64 +
-				float64(cumulativeInsertOpsPerSec)/shardedThroughput.maxSupportedInsertsPerCore.Float64)
+				float64(cumulativeInsertOpsPerSec)*SizingParams.getWriteAmplificationFactor()/shardedThroughput.maxSupportedInsertsPerCore.Float64)
 
 		nodesNeeded := math.Ceil(neededCores / shardedThroughput.numCores.Float64)
 		// Assumption: If there are any colocated objects - one node will be utilized as colocated tablet leader.
@@ -362,9 +470,8 @@ func findNumNodesNeededBasedOnThroughputRequirement(sourceIndexMetadata []Source
 			nodesNeeded += 1
 		}
 
-		// Assumption: minimum required replication is 3, so minimum nodes recommended would be 3.
-		// Choose max of nodes needed and 3
-		nodesNeeded = math.Max(nodesNeeded, 3)
+		// Minimum nodes recommended would be the replication factor(3 by default).
+		nodesNeeded = math.Max(nodesNeeded, float64(SizingParams.getReplicationFactor()))
 
 		// Update recommendation with the number of nodes needed
 		recommendation[int(shardedThroughput.numCores.Float64)] = IntermediateRecommendation{
@@ -402,29 +509,15 @@ func findNumNodesNeededBasedOnTabletsRequired(sourceIndexMetadata []SourceDBMeta
 	recommendation map[int]IntermediateRecommendation) map[int]IntermediateRecommendation {
 	// Iterate over each intermediate recommendation where failureReasoning is empty
 	for i, rec := range recommendation {
-		totalTabletsRequired := 0
 		if len(rec.ShardedTables) != 0 && rec.FailureReasoning == "" {
-			// Iterate over each table and its indexes to find out how many tablets are needed
-			for _, table := range rec.ShardedTables {
-				_, tabletsRequired := getThresholdAndTablets(rec.NumNodes, lo.Ternary(table.Size.Valid, table.Size.Float64, 0))
-				for _, index := range sourceIndexMetadata {
-					if index.ParentTableName.Valid && (index.ParentTableName.String == (table.SchemaName + "." + table.ObjectName)) {
-						// calculating tablets required for each of the index
-						_, tabletsRequiredForIndex := getThresholdAndTablets(rec.NumNodes, lo.Ternary(index.Size.Valid, index.Size.Float64, 0))
-						// tablets required for each table is the sum of tablets required for the table and its indexes
-						tabletsRequired += tabletsRequiredForIndex
-					}
-				}
-				// adding total tablets required across all tables
-				totalTabletsRequired += tabletsRequired
-			}
+			totalTabletsRequired := getShardedTabletsRequired(rec, sourceIndexMetadata)
 			// assuming table limits is also a tablet limit
 			// get shardedLimit of current recommendation
 			for _, record := range shardedLimits {
 				if record.numCores.Valid && int(record.numCores.Float64) == rec.VCPUsPerInstance {
-					// considering RF=3, hence total required tablets would be 3 times(1 tablet leader and 2 followers) the totalTabletsRequired
+					// total required tablets would be replication factor times(1 tablet leader and RF-1 followers) the totalTabletsRequired
 					// adding 100% buffer for the tablets required by multiplier of 2
-					nodesRequired := math.Ceil(float64(totalTabletsRequired*3*2) / float64(record.maxSupportedNumTables.Int64))
+					nodesRequired := math.Ceil(float64(totalTabletsRequired*SizingParams.getReplicationFactor()*2) / float64(record.maxSupportedNumTables.Int64))
 					// update recommendation to use the maximum of the existing recommended nodes and nodes calculated based on tablets
 					// Caveat: if new nodes required is more than the existing recommended nodes, we would need to
 					// re-evaluate tablets required. Although, in this iteration we've skipping re-evaluation.
@@ -438,6 +531,29 @@ func findNumNodesNeededBasedOnTabletsRequired(sourceIndexMetadata []SourceDBMeta
 	return recommendation
 }
 
+/*
+getShardedTabletsRequired returns the number of tablet leaders required for the sharded tables and their indexes
+of the recommendation.
+*/
+func getShardedTabletsRequired(rec IntermediateRecommendation, sourceIndexMetadata []SourceDBMetadata) int {
+	totalTabletsRequired := 0
+	// Iterate over each table and its indexes to find out how many tablets are needed
+	for _, table := range rec.ShardedTables {
+		_, tabletsRequired := getThresholdAndTablets(rec.NumNodes, lo.Ternary(table.Size.Valid, table.Size.Float64, 0))
+		for _, index := range sourceIndexMetadata {
+			if index.ParentTableName.Valid && (index.ParentTableName.String == (table.SchemaName + "." + table.ObjectName)) {
+				// calculating tablets required for each of the index
+				_, tabletsRequiredForIndex := getThresholdAndTablets(rec.NumNodes, lo.Ternary(index.Size.Valid, index.Size.Float64, 0))
+				// tablets required for each table is the sum of tablets required for the table and its indexes
+				tabletsRequired += tabletsRequiredForIndex
+			}
+		}
+		// adding total tablets required across all tables
+		totalTabletsRequired += tabletsRequired
+	}
+	return totalTabletsRequired
+}
+
 /*
 getThresholdAndTablets determines the size threshold and number of tablets needed for a given table size.
 
@@ -918,7 +1034,7 @@ func calculateTimeTakenAndParallelJobsForImport(tables []SourceDBMetadata,
 	// find the rows in experiment data about the approx row matching the size
 	for _, table := range tables {
 		// find the closest record from experiment data for the size of the table
-		tableSize := lo.Ternary(table.Size.Valid, table.Size.Float64, 0)
+		tableSize := getImportDataSize(table)
 		rowsInTable := lo.Ternary(table.RowCount.Valid, table.RowCount.Float64, 0)
 
 		// get multiplication factor for every table based on the number of indexes
@@ -936,6 +1052,14 @@ func calculateTimeTakenAndParallelJobsForImport(tables []SourceDBMetadata,
 	return math.Ceil(importTime), loadTimes[0].parallelThreads.Int64, nil
 }
 
+// size(in GB) of the data to be imported for the table, the profiled data size if available else the size on the source
+func getImportDataSize(table SourceDBMetadata) float64 {
+	if table.ProfiledDataSize.Valid {
+		return table.ProfiledDataSize.Float64
+	}
+	return lo.Ternary(table.Size.Valid, table.Size.Float64, 0)
+}
+
 /*
 getExpDataLoadTime fetches load time information from the experiment data table.
 Parameters:
@@ -1435,24 +1559,39 @@ func getListOfIndexesAlongWithObjects(tableList []SourceDBMetadata,
 	return indexesAndObject, cumulativeIndexCount
 }
 
-func createConnectionToExperimentData() (*sql.DB, error) {
-	filePath, err := getExperimentFile()
+/*
+createConnectionToExperimentData connects to the user-provided experiment data if any, otherwise to the built-in one.
+Returns the connection along with the description of the dataset to report the recommendation source.
+*/
+func createConnectionToExperimentData() (*sql.DB, string, error) {
+	filePath, dataset, err := getExperimentFile()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get experiment file: %w", err)
+		return nil, "", fmt.Errorf("failed to get experiment file: %w", err)
 	}
 	DbConnection, err := utils.ConnectToSqliteDatabase(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to experiment data database: %w", err)
+		return nil, "", fmt.Errorf("failed to connect to experiment data database: %w", err)
+	}
+	err = validateExperimentDataSchema(DbConnection)
+	if err != nil {
+		return nil, "", fmt.Errorf("experiment data %s: %w", dataset, err)
 	}
-	return DbConnection, nil
+	return DbConnection, dataset, nil
 }
 
-func getExperimentFile() (string, error) {
+func getExperimentFile() (string, string, error) {
+	if ExperimentDataFilePath != "" {
+		if !utils.FileOrFolderExists(ExperimentDataFilePath) {
+			return "", "", fmt.Errorf("experiment data file %q does not exist", ExperimentDataFilePath)
+		}
+		return ExperimentDataFilePath, ExperimentDataFilePath, nil
+	}
+
 	fetchedFromRemote := false
 	if PREFER_REMOTE_EXPERIMENT_DB && checkInternetAccess() {
 		existsOnRemote, err := checkAndDownloadFileExistsOnRemoteRepo()
 		if err != nil {
-			return "", err
+			return "", "", err
 		}
 		if existsOnRemote {
 			fetchedFromRemote = true
@@ -1461,11 +1600,11 @@ func getExperimentFile() (string, error) {
 	if !fetchedFromRemote {
 		err := os.WriteFile(getExperimentDBPath(), experimentData20240, 0644)
 		if err != nil {
-			return "", fmt.Errorf("failed to write experiment data file: %w", err)
+			return "", "", fmt.Errorf("failed to write experiment data file: %w", err)
 		}
 	}
 
-	return getExperimentDBPath(), nil
+	return getExperimentDBPath(), lo.Ternary(fetchedFromRemote, REMOTE_EXPERIMENT_DATASET, BUILTIN_EXPERIMENT_DATASET), nil
 }
 
 func checkAndDownloadFileExistsOnRemoteRepo() (bool, error) {
@@ -0,0 +1,171 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package migassessment
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+)
+
+/*
+The experiment data is with a 3 node RF=3 cluster in a single region. SizingParams adjusts the sizing for the
+target cluster topology(replication factor and the number of regions/zones) and projects the sizing for the
+expected yearly growth of the data and the throughput.
+*/
+
+const (
+	DEFAULT_REPLICATION_FACTOR    = 3
+	EXPERIMENT_REPLICATION_FACTOR = 3
+	MAX_SIZING_PROJECTION_YEARS   = 10
+)
+
+type SizingParameters struct {
+	ReplicationFactor             int
+	NumZones                      int // number of regions/zones across which the nodes are spread, 0 if not specified
+	ProjectionYears               int
+	YearlyDataGrowthPercent       float64
+	YearlyThroughputGrowthPercent float64
+}
+
+var SizingParams = &SizingParameters{
+	ReplicationFactor: DEFAULT_REPLICATION_FACTOR,
+}
+
+func (p *SizingParameters) Validate() error {
+	if p.ReplicationFactor < 1 || p.ReplicationFactor%2 == 0 {
+		return fmt.Errorf("replication factor should be an odd number greater than 0, got %d", p.ReplicationFactor)
+	}
+	if p.NumZones < 0 {
+		return fmt.Errorf("number of zones should not be negative, got %d", p.NumZones)
+	}
+	if p.ProjectionYears < 0 || p.ProjectionYears > MAX_SIZING_PROJECTION_YEARS {
+		return fmt.Errorf("sizing projection years should be between 0 and %d, got %d", MAX_SIZING_PROJECTION_YEARS, p.ProjectionYears)
+	}
+	if p.YearlyDataGrowthPercent < 0 || p.YearlyThroughputGrowthPercent < 0 {
+		return fmt.Errorf("yearly growth percentages should not be negative")
+	}
+	return nil
+}
+
+func (p *SizingParameters) getReplicationFactor() int {
+	if p == nil || p.ReplicationFactor == 0 {
+		return DEFAULT_REPLICATION_FACTOR
+	}
+	return p.ReplicationFactor
+}
+
+// writes per core supported in the experiment data are with RF=3, scale the required writes for the target RF
+func (p *SizingParameters) getWriteAmplificationFactor() float64 {
+	return float64(p.getReplicationFactor()) / EXPERIMENT_REPLICATION_FACTOR
+}
+
+/*
+alignNumNodesWithZones rounds up the number of nodes of the recommendations to a multiple of the number of zones
+so that the nodes(and the tablet replicas) are evenly spread across the regions/zones.
+*/
+func alignNumNodesWithZones(recommendation map[int]IntermediateRecommendation) map[int]IntermediateRecommendation {
+	numZones := float64(SizingParams.NumZones)
+	if numZones <= 0 {
+		return recommendation
+	}
+	for i, rec := range recommendation {
+		rec.NumNodes = math.Ceil(rec.NumNodes/numZones) * numZones
+		recommendation[i] = rec
+	}
+	return recommendation
+}
+
+type YearlySizingRecommendation struct {
+	Year                   int
+	DataSizeInGB           float64
+	ReadsPerSecond         int64
+	WritesPerSecond        int64
+	NumColocatedTables     int
+	NumShardedTables       int
+	NumTabletsWithReplicas int
+	NumNodes               float64
+	VCPUsPerInstance       int
+	MemoryPerInstance      int
+	FailureReasoning       string
+}
+
+/*
+getYearlySizingProjection recomputes the sizing recommendation for every year till the projection years
+with the data and throughput grown by the yearly growth percentages. Year 0 is the current workload.
+Returns nil if the projection is not requested.
+*/
+func getYearlySizingProjection(sourceTableMetadata []SourceDBMetadata, sourceIndexMetadata []SourceDBMetadata,
+	expData *sizingExperimentData) []YearlySizingRecommendation {
+	if SizingParams.ProjectionYears == 0 {
+		return nil
+	}
+
+	var result []YearlySizingRecommendation
+	for year := 0; year <= SizingParams.ProjectionYears; year++ {
+		dataGrowth := math.Pow(1+SizingParams.YearlyDataGrowthPercent/100, float64(year))
+		throughputGrowth := math.Pow(1+SizingParams.YearlyThroughputGrowthPercent/100, float64(year))
+		tables := growSourceDBMetadata(sourceTableMetadata, dataGrowth, throughputGrowth)
+		indexes := growSourceDBMetadata(sourceIndexMetadata, dataGrowth, throughputGrowth)
+
+		rec := getSizingRecommendation(tables, indexes, expData)
+		yearlySizing := YearlySizingRecommendation{
+			Year:                   year,
+			NumColocatedTables:     len(rec.ColocatedTables),
+			NumShardedTables:       len(rec.ShardedTables),
+			NumTabletsWithReplicas: getShardedTabletsRequired(rec, indexes) * SizingParams.getReplicationFactor(),
+			NumNodes:               rec.NumNodes,
+			VCPUsPerInstance:       rec.VCPUsPerInstance,
+			MemoryPerInstance:      rec.VCPUsPerInstance * rec.MemoryPerCore,
+			FailureReasoning:       rec.FailureReasoning,
+		}
+		for _, object := range append(tables, indexes...) {
+			yearlySizing.DataSizeInGB += lo.Ternary(object.Size.Valid, object.Size.Float64, 0)
+			yearlySizing.ReadsPerSecond += lo.Ternary(object.ReadsPerSec.Valid, object.ReadsPerSec.Int64, 0)
+			yearlySizing.WritesPerSecond += lo.Ternary(object.WritesPerSec.Valid, object.WritesPerSec.Int64, 0)
+		}
+		yearlySizing.DataSizeInGB = math.Round(yearlySizing.DataSizeInGB*100) / 100
+		log.Infof("sizing projection for year %d: %+v", year, yearlySizing)
+		result = append(result, yearlySizing)
+	}
+	return result
+}
+
+// returns a copy of the metadata with the size/row count and the reads/writes per second grown by the given factors
+func growSourceDBMetadata(objects []SourceDBMetadata, dataGrowth float64, throughputGrowth float64) []SourceDBMetadata {
+	result := make([]SourceDBMetadata, 0, len(objects))
+	for _, object := range objects {
+		if object.Size.Valid {
+			object.Size.Float64 *= dataGrowth
+		}
+		if object.ProfiledDataSize.Valid {
+			object.ProfiledDataSize.Float64 *= dataGrowth
+		}
+		if object.RowCount.Valid {
+			object.RowCount.Float64 *= dataGrowth
+		}
+		if object.ReadsPerSec.Valid {
+			object.ReadsPerSec.Int64 = int64(math.Round(float64(object.ReadsPerSec.Int64) * throughputGrowth))
+		}
+		if object.WritesPerSec.Valid {
+			object.WritesPerSec.Int64 = int64(math.Round(float64(object.WritesPerSec.Int64) * throughputGrowth))
+		}
+		result = append(result, object)
+	}
+	return result
+}
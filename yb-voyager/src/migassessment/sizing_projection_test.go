@@ -0,0 +1,157 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migassessment
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setSizingParams(t *testing.T, params SizingParameters) {
+	previous := SizingParams
+	SizingParams = &params
+	t.Cleanup(func() { SizingParams = previous })
+}
+
+func TestSizingParametersValidate(t *testing.T) {
+	assert.NoError(t, (&SizingParameters{ReplicationFactor: 3}).Validate())
+	assert.NoError(t, (&SizingParameters{ReplicationFactor: 5, NumZones: 3, ProjectionYears: 3, YearlyDataGrowthPercent: 20}).Validate())
+	assert.ErrorContains(t, (&SizingParameters{ReplicationFactor: 4}).Validate(), "odd number")
+	assert.ErrorContains(t, (&SizingParameters{ReplicationFactor: 3, ProjectionYears: 11}).Validate(), "projection years")
+	assert.ErrorContains(t, (&SizingParameters{ReplicationFactor: 3, YearlyThroughputGrowthPercent: -5}).Validate(), "negative")
+}
+
+// writes are replicated to more nodes with RF=5, hence more nodes needed compared to RF=3(15 nodes)
+func TestFindNumNodesNeededBasedOnThroughputRequirement_RF5(t *testing.T) {
+	setSizingParams(t, SizingParameters{ReplicationFactor: 5})
+
+	shardedThroughput := []ExpDataThroughput{
+		{
+			numCores:                   sql.NullFloat64{Float64: 4},
+			maxSupportedSelectsPerCore: sql.NullFloat64{Float64: 200},
+			maxSupportedInsertsPerCore: sql.NullFloat64{Float64: 100},
+		},
+	}
+	recommendation := map[int]IntermediateRecommendation{
+		4: {
+			ShardedTables: []SourceDBMetadata{
+				{
+					ObjectName: "table2", Size: sql.NullFloat64{Float64: 20.0, Valid: true},
+					ReadsPerSec:  sql.NullInt64{Valid: true, Int64: 2000},
+					WritesPerSec: sql.NullInt64{Valid: true, Int64: 5000},
+				},
+			},
+			VCPUsPerInstance: 4,
+			MemoryPerCore:    4,
+		},
+		8: {VCPUsPerInstance: 8, MemoryPerCore: 4},
+	}
+
+	updatedRecommendation := findNumNodesNeededBasedOnThroughputRequirement(nil, shardedThroughput, recommendation)
+	// ceil(2000/200 + 5000*5/3/100) = 94 cores i.e. 24 nodes of 4 cores
+	assert.Equal(t, float64(24), updatedRecommendation[4].NumNodes)
+}
+
+func TestAlignNumNodesWithZones(t *testing.T) {
+	recommendation := map[int]IntermediateRecommendation{
+		4: {VCPUsPerInstance: 4, NumNodes: 4},
+		8: {VCPUsPerInstance: 8, NumNodes: 3},
+	}
+
+	// number of zones not specified
+	setSizingParams(t, SizingParameters{ReplicationFactor: 3})
+	updatedRecommendation := alignNumNodesWithZones(recommendation)
+	assert.Equal(t, float64(4), updatedRecommendation[4].NumNodes)
+	assert.Equal(t, float64(3), updatedRecommendation[8].NumNodes)
+
+	setSizingParams(t, SizingParameters{ReplicationFactor: 3, NumZones: 3})
+	updatedRecommendation = alignNumNodesWithZones(recommendation)
+	assert.Equal(t, float64(6), updatedRecommendation[4].NumNodes)
+	assert.Equal(t, float64(3), updatedRecommendation[8].NumNodes)
+}
+
+func TestGrowSourceDBMetadata(t *testing.T) {
+	objects := []SourceDBMetadata{
+		{
+			ObjectName:   "table1",
+			Size:         sql.NullFloat64{Float64: 10, Valid: true},
+			RowCount:     sql.NullFloat64{Float64: 1000, Valid: true},
+			ReadsPerSec:  sql.NullInt64{Int64: 100, Valid: true},
+			WritesPerSec: sql.NullInt64{Int64: 15, Valid: true},
+		},
+		{ObjectName: "table2"},
+	}
+
+	grown := growSourceDBMetadata(objects, 1.44, 1.1)
+	assert.InDelta(t, 14.4, grown[0].Size.Float64, 0.0001)
+	assert.InDelta(t, 1440.0, grown[0].RowCount.Float64, 0.0001)
+	assert.Equal(t, int64(110), grown[0].ReadsPerSec.Int64)
+	assert.Equal(t, int64(17), grown[0].WritesPerSec.Int64)
+	assert.False(t, grown[1].Size.Valid)
+	// original metadata is not modified
+	assert.Equal(t, 10.0, objects[0].Size.Float64)
+}
+
+func TestGetYearlySizingProjection(t *testing.T) {
+	experimentDB, _ := createTestExperimentDB(t)
+	expData := &sizingExperimentData{}
+	var err error
+	expData.colocatedLimits, err = loadColocatedLimit(experimentDB)
+	assert.NoError(t, err)
+	expData.colocatedThroughput, err = loadExpDataThroughput(experimentDB, COLOCATED_SIZING_TABLE)
+	assert.NoError(t, err)
+	expData.shardedLimits, err = loadShardedTableLimits(experimentDB)
+	assert.NoError(t, err)
+	expData.shardedThroughput, err = loadExpDataThroughput(experimentDB, SHARDED_SIZING_TABLE)
+	assert.NoError(t, err)
+
+	tables := []SourceDBMetadata{
+		{
+			SchemaName: "public", ObjectName: "orders",
+			Size:         sql.NullFloat64{Float64: 60, Valid: true},
+			RowCount:     sql.NullFloat64{Float64: 100000000, Valid: true},
+			ReadsPerSec:  sql.NullInt64{Int64: 500, Valid: true},
+			WritesPerSec: sql.NullInt64{Int64: 200, Valid: true},
+		},
+	}
+
+	// no projection requested
+	setSizingParams(t, SizingParameters{ReplicationFactor: 3})
+	assert.Nil(t, getYearlySizingProjection(tables, nil, expData))
+
+	setSizingParams(t, SizingParameters{ReplicationFactor: 3, ProjectionYears: 3, YearlyDataGrowthPercent: 100,
+		YearlyThroughputGrowthPercent: 50})
+	projection := getYearlySizingProjection(tables, nil, expData)
+	assert.Len(t, projection, 4)
+	assert.Equal(t, 0, projection[0].Year)
+	assert.Equal(t, 60.0, projection[0].DataSizeInGB)
+	assert.Equal(t, 480.0, projection[3].DataSizeInGB)
+	assert.Equal(t, int64(1688), projection[3].ReadsPerSecond)
+	// the colocated table goes beyond the colocated limits with the growth
+	assert.Equal(t, 1, projection[0].NumColocatedTables)
+	assert.Equal(t, 1, projection[3].NumShardedTables)
+	for i := 1; i < len(projection); i++ {
+		assert.Empty(t, projection[i].FailureReasoning)
+		assert.GreaterOrEqual(t, projection[i].NumNodes*float64(projection[i].VCPUsPerInstance),
+			projection[i-1].NumNodes*float64(projection[i-1].VCPUsPerInstance))
+	}
+	assert.Greater(t, projection[3].NumTabletsWithReplicas, 0)
+}
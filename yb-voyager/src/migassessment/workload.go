@@ -0,0 +1,328 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package migassessment
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+)
+
+/*
+The reads/writes counters of the tables and indexes are sampled every iops capture interval, either twice(initial
+and final) or periodically over the iops capture duration. Every sample is stored in the table_index_iops table with
+the measurement time, and the reads/writes per second of every interval between the consecutive samples are used to
+compute the average, p95 and peak rates of the objects. The sizing uses the peak rates.
+*/
+
+const (
+	// interval assumed for the samples without the measurement time(collected by older versions of the scripts)
+	DEFAULT_IOPS_CAPTURE_INTERVAL_SECS = 120
+
+	INITIAL_MEASUREMENT = "initial"
+)
+
+type WorkloadProfile struct {
+	CaptureStartTime string           `json:"CaptureStartTime"`
+	CaptureEndTime   string           `json:"CaptureEndTime"`
+	NumSamples       int              `json:"NumSamples"`
+	Objects          []ObjectWorkload `json:"Objects"`
+}
+
+type ObjectWorkload struct {
+	SchemaName          string           `json:"SchemaName"`
+	ObjectName          string           `json:"ObjectName"`
+	ObjectType          string           `json:"ObjectType"`
+	AvgReadsPerSecond   float64          `json:"AvgReadsPerSecond"`
+	P95ReadsPerSecond   float64          `json:"P95ReadsPerSecond"`
+	PeakReadsPerSecond  float64          `json:"PeakReadsPerSecond"`
+	AvgWritesPerSecond  float64          `json:"AvgWritesPerSecond"`
+	P95WritesPerSecond  float64          `json:"P95WritesPerSecond"`
+	PeakWritesPerSecond float64          `json:"PeakWritesPerSecond"`
+	Hourly              []HourlyWorkload `json:"Hourly,omitempty"` // daily workload shape, only the sampled hours
+}
+
+// average reads/writes per second of an object in an hour of the day(UTC) across all the days sampled
+type HourlyWorkload struct {
+	Hour            int     `json:"Hour"`
+	ReadsPerSecond  float64 `json:"ReadsPerSecond"`
+	WritesPerSecond float64 `json:"WritesPerSecond"`
+}
+
+func (o *ObjectWorkload) isIndex() bool {
+	return o.ObjectType == "index" || o.ObjectType == PARTITIONED_INDEX_OBJECT_TYPE
+}
+
+// TopTables returns the n tables with the highest peak reads+writes per second
+func (w *WorkloadProfile) TopTables(n int) []ObjectWorkload {
+	tables := lo.Filter(w.Objects, func(o ObjectWorkload, _ int) bool {
+		return !o.isIndex()
+	})
+	sort.SliceStable(tables, func(i, j int) bool {
+		return tables[i].PeakReadsPerSecond+tables[i].PeakWritesPerSecond > tables[j].PeakReadsPerSecond+tables[j].PeakWritesPerSecond
+	})
+	return tables[:min(n, len(tables))]
+}
+
+type iopsSample struct {
+	schemaName      string
+	objectName      string
+	objectType      string
+	measurementType string
+	reads           sql.NullInt64
+	writes          sql.NullInt64
+	measurementTime sql.NullInt64 // unix epoch seconds
+}
+
+type intervalRate struct {
+	endTime         sql.NullInt64
+	readsPerSecond  float64
+	writesPerSecond float64
+}
+
+/*
+WorkloadAssessment reports the workload of the tables and indexes when the iops were sampled periodically
+i.e. more than the initial and final measurements are present.
+*/
+func WorkloadAssessment(adb *AssessmentDB) (*WorkloadProfile, error) {
+	if SourceDBType != "postgresql" && SourceDBType != "mysql" {
+		return nil, nil
+	}
+
+	samples, err := adb.fetchIopsSamples()
+	if err != nil {
+		return nil, fmt.Errorf("fetching iops samples: %w", err)
+	}
+	measurements := lo.Uniq(lo.Map(samples, func(s iopsSample, _ int) string {
+		return s.measurementType
+	}))
+	if len(measurements) <= 2 {
+		log.Infof("iops were not sampled periodically, skipping workload assessment")
+		return nil, nil
+	}
+
+	profile := &WorkloadProfile{
+		NumSamples: len(measurements),
+		Objects:    computeObjectWorkloads(samples),
+	}
+	times := lo.FilterMap(samples, func(s iopsSample, _ int) (int64, bool) {
+		return s.measurementTime.Int64, s.measurementTime.Valid
+	})
+	if len(times) > 0 {
+		profile.CaptureStartTime = time.Unix(lo.Min(times), 0).UTC().Format(time.RFC3339)
+		profile.CaptureEndTime = time.Unix(lo.Max(times), 0).UTC().Format(time.RFC3339)
+	}
+	return profile, nil
+}
+
+// computes the average, p95 and peak reads/writes per second along with the hourly shape of every object
+func computeObjectWorkloads(samples []iopsSample) []ObjectWorkload {
+	samplesByObject := make(map[string][]iopsSample)
+	var objectKeys []string
+	for _, s := range samples {
+		key := s.schemaName + "." + s.objectName
+		if _, ok := samplesByObject[key]; !ok {
+			objectKeys = append(objectKeys, key)
+		}
+		samplesByObject[key] = append(samplesByObject[key], s)
+	}
+
+	var result []ObjectWorkload
+	for _, key := range objectKeys {
+		objectSamples := samplesByObject[key]
+		rates := computeIntervalRates(objectSamples)
+		if len(rates) == 0 {
+			continue
+		}
+		reads := lo.Map(rates, func(r intervalRate, _ int) float64 { return r.readsPerSecond })
+		writes := lo.Map(rates, func(r intervalRate, _ int) float64 { return r.writesPerSecond })
+		result = append(result, ObjectWorkload{
+			SchemaName:          objectSamples[0].schemaName,
+			ObjectName:          objectSamples[0].objectName,
+			ObjectType:          objectSamples[0].objectType,
+			AvgReadsPerSecond:   roundRate(lo.Sum(reads) / float64(len(reads))),
+			P95ReadsPerSecond:   roundRate(percentile(reads, 95)),
+			PeakReadsPerSecond:  roundRate(lo.Max(reads)),
+			AvgWritesPerSecond:  roundRate(lo.Sum(writes) / float64(len(writes))),
+			P95WritesPerSecond:  roundRate(percentile(writes, 95)),
+			PeakWritesPerSecond: roundRate(lo.Max(writes)),
+			Hourly:              computeHourlyWorkload(rates),
+		})
+	}
+	return result
+}
+
+// reads/writes per second between the consecutive samples of an object, ordered by the measurement time
+func computeIntervalRates(samples []iopsSample) []intervalRate {
+	sorted := sortedByMeasurementTime(samples)
+	var rates []intervalRate
+	for i := 1; i < len(sorted); i++ {
+		prev, curr := sorted[i-1], sorted[i]
+		if !prev.reads.Valid || !curr.reads.Valid || !prev.writes.Valid || !curr.writes.Valid {
+			continue
+		}
+		intervalSecs := float64(DEFAULT_IOPS_CAPTURE_INTERVAL_SECS)
+		if prev.measurementTime.Valid && curr.measurementTime.Valid {
+			intervalSecs = float64(curr.measurementTime.Int64 - prev.measurementTime.Int64)
+		}
+		if intervalSecs <= 0 {
+			continue
+		}
+		// counters can go down if the stats are reset in between the samples
+		rates = append(rates, intervalRate{
+			endTime:         curr.measurementTime,
+			readsPerSecond:  math.Max(float64(curr.reads.Int64-prev.reads.Int64), 0) / intervalSecs,
+			writesPerSecond: math.Max(float64(curr.writes.Int64-prev.writes.Int64), 0) / intervalSecs,
+		})
+	}
+	return rates
+}
+
+func sortedByMeasurementTime(samples []iopsSample) []iopsSample {
+	// samples without the measurement time only have the initial and final measurements
+	order := func(s iopsSample) int64 {
+		if s.measurementTime.Valid {
+			return s.measurementTime.Int64
+		}
+		return lo.Ternary[int64](s.measurementType == INITIAL_MEASUREMENT, 0, 1)
+	}
+	sorted := slices.Clone(samples)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return order(sorted[i]) < order(sorted[j])
+	})
+	return sorted
+}
+
+func computeHourlyWorkload(rates []intervalRate) []HourlyWorkload {
+	type hourlyRates struct {
+		reads, writes []float64
+	}
+	ratesByHour := make(map[int]*hourlyRates)
+	for _, r := range rates {
+		if !r.endTime.Valid {
+			continue
+		}
+		hour := time.Unix(r.endTime.Int64, 0).UTC().Hour()
+		if ratesByHour[hour] == nil {
+			ratesByHour[hour] = &hourlyRates{}
+		}
+		ratesByHour[hour].reads = append(ratesByHour[hour].reads, r.readsPerSecond)
+		ratesByHour[hour].writes = append(ratesByHour[hour].writes, r.writesPerSecond)
+	}
+
+	var result []HourlyWorkload
+	for hour := 0; hour < 24; hour++ {
+		hr, ok := ratesByHour[hour]
+		if !ok {
+			continue
+		}
+		result = append(result, HourlyWorkload{
+			Hour:            hour,
+			ReadsPerSecond:  roundRate(lo.Sum(hr.reads) / float64(len(hr.reads))),
+			WritesPerSecond: roundRate(lo.Sum(hr.writes) / float64(len(hr.writes))),
+		})
+	}
+	return result
+}
+
+// nearest-rank percentile
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(values)
+	sort.Float64s(sorted)
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
+
+func roundRate(rate float64) float64 {
+	return math.Round(rate*100) / 100
+}
+
+func (adb *AssessmentDB) fetchIopsSamples() ([]iopsSample, error) {
+	// reads/writes are not collected for the partitioned tables/indexes, '' in the metadata CSV
+	query := fmt.Sprintf(`SELECT schema_name, object_name, object_type, measurement_type, NULLIF(seq_reads, ''),
+		NULLIF(row_writes, ''), NULLIF(measurement_time, '')
+	FROM %s
+	ORDER BY schema_name, object_name;`, TABLE_INDEX_IOPS)
+	rows, err := adb.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying-%s: %w", query, err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			log.Warnf("failed to close result set for query: [%s]", query)
+		}
+	}()
+
+	var result []iopsSample
+	for rows.Next() {
+		var s iopsSample
+		var objectType sql.NullString
+		err := rows.Scan(&s.schemaName, &s.objectName, &objectType, &s.measurementType, &s.reads, &s.writes, &s.measurementTime)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+		s.objectType = objectType.String
+		result = append(result, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rows: %w", err)
+	}
+	return result, nil
+}
+
+// sets the reads/writes per second of the tables and indexes in the table_index_stats as the peak rates
+func (adb *AssessmentDB) updateStatsWithPeakRates() error {
+	samples, err := adb.fetchIopsSamples()
+	if err != nil {
+		return fmt.Errorf("fetching iops samples: %w", err)
+	}
+
+	tx, err := adb.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting transaction for updating the peak rates: %w", err)
+	}
+	defer func() {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Warnf("error while rollback the peak rates update txn: %v", err)
+		}
+	}()
+
+	stmt := fmt.Sprintf(`UPDATE %s SET reads_per_second = ?, writes_per_second = ? WHERE schema_name = ? AND object_name = ?;`,
+		TABLE_INDEX_STATS)
+	for _, w := range computeObjectWorkloads(samples) {
+		// floor, same as the integer division of the counters by the interval
+		_, err := tx.Exec(stmt, int64(w.PeakReadsPerSecond), int64(w.PeakWritesPerSecond), w.SchemaName, w.ObjectName)
+		if err != nil {
+			return fmt.Errorf("error executing statement-%s: %w", stmt, err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing the peak rates update txn: %w", err)
+	}
+	return nil
+}
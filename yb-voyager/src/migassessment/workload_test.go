@@ -0,0 +1,138 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package migassessment
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeObjectWorkloads(t *testing.T) {
+	newSample := func(objectName string, measurementType string, reads int64, writes int64, measurementTime *int64) iopsSample {
+		s := iopsSample{
+			schemaName:      "public",
+			objectName:      objectName,
+			objectType:      "table",
+			measurementType: measurementType,
+			reads:           sql.NullInt64{Int64: reads, Valid: true},
+			writes:          sql.NullInt64{Int64: writes, Valid: true},
+		}
+		if measurementTime != nil {
+			s.measurementTime = sql.NullInt64{Int64: *measurementTime, Valid: true}
+		}
+		return s
+	}
+	at := func(epoch int64) *int64 { return &epoch }
+
+	// 1970-01-01 00:00:00 UTC onwards, every 30 minutes; samples are out of order
+	samples := []iopsSample{
+		newSample("orders", "sample-00002", 3000, 1800, at(3600)),
+		newSample("orders", INITIAL_MEASUREMENT, 0, 0, at(0)),
+		newSample("orders", "sample-00001", 1800, 1800, at(1800)),
+		// counters reset
+		newSample("orders", "final", 600, 100, at(5400)),
+		// older scripts without the measurement time, interval assumed as 120 seconds
+		newSample("legacy", "final", 1300, 250, nil),
+		newSample("legacy", INITIAL_MEASUREMENT, 100, 10, nil),
+	}
+	workloads := computeObjectWorkloads(samples)
+	assert.Len(t, workloads, 2)
+
+	orders := workloads[0]
+	assert.Equal(t, "orders", orders.ObjectName)
+	// reads per second of the intervals: 1, 0.67, 0
+	assert.Equal(t, 0.56, orders.AvgReadsPerSecond)
+	assert.Equal(t, 1.0, orders.P95ReadsPerSecond)
+	assert.Equal(t, 1.0, orders.PeakReadsPerSecond)
+	// writes per second of the intervals: 1, 0, 0
+	assert.Equal(t, 0.33, orders.AvgWritesPerSecond)
+	assert.Equal(t, 1.0, orders.PeakWritesPerSecond)
+	assert.Equal(t, []HourlyWorkload{
+		{Hour: 0, ReadsPerSecond: 1, WritesPerSecond: 1},
+		{Hour: 1, ReadsPerSecond: 0.33, WritesPerSecond: 0},
+	}, orders.Hourly)
+
+	legacy := workloads[1]
+	assert.Equal(t, "legacy", legacy.ObjectName)
+	assert.Equal(t, 10.0, legacy.PeakReadsPerSecond)
+	assert.Equal(t, 2.0, legacy.PeakWritesPerSecond)
+	assert.Empty(t, legacy.Hourly)
+}
+
+func TestPercentile(t *testing.T) {
+	values := make([]float64, 0, 100)
+	for i := 100; i >= 1; i-- {
+		values = append(values, float64(i))
+	}
+	assert.Equal(t, 95.0, percentile(values, 95))
+	assert.Equal(t, 100.0, percentile(values, 100))
+	assert.Equal(t, 7.0, percentile([]float64{7}, 95))
+	assert.Equal(t, 0.0, percentile(nil, 95))
+}
+
+// pins the reads/writes per second computed over the actual interval between the samples, the older versions
+// divided the counters by 120 seconds irrespective of the --iops-capture-interval
+func TestUpdateStatsWithPeakRates(t *testing.T) {
+	dbFilePath := filepath.Join(t.TempDir(), "assessment.db")
+	GetSourceMetadataDBFilePath = func() string {
+		return dbFilePath
+	}
+	assert.NoError(t, InitAssessmentDB())
+	adb, err := NewAssessmentDB("postgresql")
+	assert.NoError(t, err)
+
+	for _, stmt := range []string{
+		fmt.Sprintf(`INSERT INTO %s (schema_name, object_name, is_index) VALUES
+			('public', 'interval_60', 0), ('public', 'interval_120', 0), ('public', 'interval_300', 0), ('public', 'legacy', 0)`,
+			TABLE_INDEX_STATS),
+		// 100 reads and 10 writes per second on each table, sampled at different intervals
+		fmt.Sprintf(`INSERT INTO %s (schema_name, object_name, object_type, seq_reads, row_writes, measurement_type, measurement_time) VALUES
+			('public', 'interval_60', 'table', 1000, 100, 'initial', 1000), ('public', 'interval_60', 'table', 7000, 700, 'final', 1060),
+			('public', 'interval_120', 'table', 1000, 100, 'initial', 1000), ('public', 'interval_120', 'table', 13000, 1300, 'final', 1120),
+			('public', 'interval_300', 'table', 1000, 100, 'initial', 1000), ('public', 'interval_300', 'table', 31000, 3100, 'final', 1300),
+			('public', 'legacy', 'table', 1000, 100, 'initial', NULL), ('public', 'legacy', 'table', 13000, 1300, 'final', NULL)`,
+			TABLE_INDEX_IOPS),
+	} {
+		_, err := adb.db.Exec(stmt)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, adb.updateStatsWithPeakRates())
+
+	rows, err := adb.db.Query(fmt.Sprintf(`SELECT object_name, reads_per_second, writes_per_second FROM %s`, TABLE_INDEX_STATS))
+	assert.NoError(t, err)
+	defer rows.Close()
+	actual := make(map[string][2]int64)
+	for rows.Next() {
+		var name string
+		var reads, writes int64
+		assert.NoError(t, rows.Scan(&name, &reads, &writes))
+		actual[name] = [2]int64{reads, writes}
+	}
+	assert.NoError(t, rows.Err())
+	assert.Equal(t, map[string][2]int64{
+		"interval_60":  {100, 10}, // earlier 50, 5
+		"interval_120": {100, 10},
+		"interval_300": {100, 10}, // earlier 250, 25
+		"legacy":       {100, 10}, // no measurement time, 120 seconds assumed
+	}, actual)
+}
@@ -0,0 +1,290 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package queryissue
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"gopkg.in/yaml.v3"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/constants"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/issue"
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/query/queryparser"
+)
+
+/*
+Custom rules are user-defined issues (organisation specific anti-patterns) which are reported by
+analyze-schema and assess-migration along with the built-in issues.
+
+Sample rules file:
+
+	rules:
+	  - id: NO_SERIAL_COLUMNS
+	    name: "SERIAL columns"
+	    description: "SERIAL columns create hot spots on the sequence and the primary key index."
+	    impact: LEVEL_2
+	    suggestion: "Use identity columns with a large sequence cache instead."
+	    match:
+	      type_names: [serial, bigserial, smallserial]
+	  - id: NO_NOW_FUNCTION
+	    name: "now() function"
+	    match:
+	      function_names: [now, pg_catalog.clock_timestamp]
+	      node_types: [SQLValueFunction]
+
+A rule matches a query if any of its matchers matches any node in the parse tree of the query:
+  - node_types: name of the pg_query parse tree node, e.g. XmlExpr, LockingClause (prefix "pg_query." is optional)
+  - function_names: function name of the FuncCall nodes, optionally schema qualified
+  - type_names: name of the TypeName nodes (column types, casts, function arguments...)
+*/
+
+const (
+	CUSTOM_RULE_ID      = "CustomRuleId"
+	CUSTOM_RULE_MATCHES = "CustomRuleMatches"
+)
+
+type CustomRules struct {
+	Rules []*CustomRule `yaml:"rules"`
+}
+
+type CustomRule struct {
+	Id          string          `yaml:"id"`
+	Name        string          `yaml:"name"`
+	Description string          `yaml:"description"`
+	Impact      string          `yaml:"impact"`
+	Suggestion  string          `yaml:"suggestion"`
+	DocsLink    string          `yaml:"docs_link"`
+	Match       CustomRuleMatch `yaml:"match"`
+}
+
+type CustomRuleMatch struct {
+	NodeTypes     []string `yaml:"node_types"`
+	FunctionNames []string `yaml:"function_names"`
+	TypeNames     []string `yaml:"type_names"`
+}
+
+// SQL spellings of the builtin types mapped to the names used in the parse tree
+var typeNameAliases = map[string]string{
+	"timestamp without time zone": "timestamp",
+	"timestamp with time zone":    "timestamptz",
+	"time without time zone":      "time",
+	"time with time zone":         "timetz",
+	"integer":                     "int4",
+	"int":                         "int4",
+	"smallint":                    "int2",
+	"bigint":                      "int8",
+	"real":                        "float4",
+	"double precision":            "float8",
+	"boolean":                     "bool",
+	"character varying":           "varchar",
+	"character":                   "bpchar",
+	"char":                        "bpchar",
+	"decimal":                     "numeric",
+}
+
+// LoadCustomRules reads and validates the custom rules from the given YAML file.
+func LoadCustomRules(filePath string) (*CustomRules, error) {
+	bytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading custom rules file %q: %w", filePath, err)
+	}
+
+	var rules CustomRules
+	decoder := yaml.NewDecoder(strings.NewReader(string(bytes)))
+	decoder.KnownFields(true)
+	err = decoder.Decode(&rules)
+	if err != nil {
+		return nil, fmt.Errorf("parsing custom rules file %q: %w", filePath, err)
+	}
+
+	err = rules.validateAndNormalize()
+	if err != nil {
+		return nil, fmt.Errorf("invalid custom rules file %q: %w", filePath, err)
+	}
+	log.Infof("loaded %d custom rules from file %q", len(rules.Rules), filePath)
+	return &rules, nil
+}
+
+func (c *CustomRules) validateAndNormalize() error {
+	if len(c.Rules) == 0 {
+		return fmt.Errorf("no rules defined")
+	}
+	ruleIds := mapset.NewThreadUnsafeSet[string]()
+	for i, rule := range c.Rules {
+		if rule == nil || rule.Id == "" {
+			return fmt.Errorf("rule at index %d: 'id' is required", i)
+		}
+		rule.Id = strings.ToUpper(rule.Id)
+		if !ruleIds.Add(rule.Id) {
+			return fmt.Errorf("rule %s: duplicate rule id", rule.Id)
+		}
+		if rule.Name == "" {
+			return fmt.Errorf("rule %s: 'name' is required", rule.Id)
+		}
+		if rule.Impact == "" {
+			rule.Impact = constants.IMPACT_LEVEL_1
+		}
+		rule.Impact = strings.ToUpper(rule.Impact)
+		if !slices.Contains([]string{constants.IMPACT_LEVEL_1, constants.IMPACT_LEVEL_2, constants.IMPACT_LEVEL_3}, rule.Impact) {
+			return fmt.Errorf("rule %s: invalid impact %q, expected one of %s, %s, %s", rule.Id, rule.Impact,
+				constants.IMPACT_LEVEL_1, constants.IMPACT_LEVEL_2, constants.IMPACT_LEVEL_3)
+		}
+
+		match := &rule.Match
+		if len(match.NodeTypes)+len(match.FunctionNames)+len(match.TypeNames) == 0 {
+			return fmt.Errorf("rule %s: at least one of 'node_types', 'function_names' or 'type_names' is required in 'match'", rule.Id)
+		}
+		for j, nodeType := range match.NodeTypes {
+			if !strings.HasPrefix(nodeType, "pg_query.") {
+				nodeType = "pg_query." + nodeType
+			}
+			_, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(nodeType))
+			if err != nil {
+				return fmt.Errorf("rule %s: unknown node type %q", rule.Id, match.NodeTypes[j])
+			}
+			match.NodeTypes[j] = nodeType
+		}
+		for j, funcName := range match.FunctionNames {
+			match.FunctionNames[j] = strings.ToLower(strings.TrimSpace(funcName))
+		}
+		for j, typeName := range match.TypeNames {
+			typeName = strings.Join(strings.Fields(strings.ToLower(typeName)), " ")
+			if alias, ok := typeNameAliases[typeName]; ok {
+				typeName = alias
+			}
+			match.TypeNames[j] = typeName
+		}
+	}
+	return nil
+}
+
+func (r *CustomRule) getIssue() issue.Issue {
+	return issue.Issue{
+		Type:        r.Id,
+		Name:        r.Name,
+		Description: r.Description,
+		Impact:      r.Impact,
+		Suggestion:  r.Suggestion,
+		DocsLink:    r.DocsLink,
+	}
+}
+
+func NewCustomRuleIssue(rule *CustomRule, objectType string, objectName string, sqlStatement string, matches []string) QueryIssue {
+	slices.Sort(matches)
+	details := map[string]interface{}{
+		CUSTOM_RULE_ID:      rule.Id,
+		CUSTOM_RULE_MATCHES: matches,
+	}
+	return newQueryIssue(rule.getIssue(), objectType, objectName, sqlStatement, details)
+}
+
+// IsCustomRuleIssue returns true if the issue was reported by a user-defined rule
+func IsCustomRuleIssue(i QueryIssue) bool {
+	_, ok := i.Details[CUSTOM_RULE_ID]
+	return ok
+}
+
+// CustomRuleDetector evaluates all the custom rules on the nodes of a query's parse tree
+type CustomRuleDetector struct {
+	query string
+	rules []*CustomRule
+
+	// index of the rule -> matched node types/function names/type names
+	matchesDetected map[int]mapset.Set[string]
+}
+
+func NewCustomRuleDetector(query string, customRules *CustomRules) *CustomRuleDetector {
+	return &CustomRuleDetector{
+		query:           query,
+		rules:           customRules.Rules,
+		matchesDetected: make(map[int]mapset.Set[string]),
+	}
+}
+
+func (d *CustomRuleDetector) Detect(msg protoreflect.Message) error {
+	nodeType := queryparser.GetMsgFullName(msg)
+
+	var funcNames []string
+	if nodeType == queryparser.PG_QUERY_FUNCCALL_NODE {
+		schemaName, funcName := queryparser.GetFuncNameFromFuncCall(msg)
+		funcName = strings.ToLower(funcName)
+		funcNames = append(funcNames, funcName)
+		if schemaName != "" {
+			funcNames = append(funcNames, fmt.Sprintf("%s.%s", strings.ToLower(schemaName), funcName))
+		}
+	}
+
+	var typeNames []string
+	if nodeType == queryparser.PG_QUERY_TYPENAME_NODE {
+		names := queryparser.GetListField(msg, "names")
+		for i := 0; i < names.Len(); i++ {
+			name := queryparser.GetStringValueFromNode(names.Get(i).Message())
+			if name != "" {
+				typeNames = append(typeNames, strings.ToLower(name))
+			}
+		}
+		if len(typeNames) > 1 {
+			// both unqualified and qualified names can be matched e.g. timestamptz and pg_catalog.timestamptz
+			typeNames = []string{typeNames[len(typeNames)-1], strings.Join(typeNames, ".")}
+		}
+	}
+
+	for i, rule := range d.rules {
+		for _, ruleNodeType := range rule.Match.NodeTypes {
+			if ruleNodeType == nodeType {
+				d.addMatch(i, strings.TrimPrefix(nodeType, "pg_query."))
+			}
+		}
+		for _, funcName := range funcNames {
+			if slices.Contains(rule.Match.FunctionNames, funcName) {
+				d.addMatch(i, funcName)
+			}
+		}
+		for _, typeName := range typeNames {
+			if slices.Contains(rule.Match.TypeNames, typeName) {
+				d.addMatch(i, typeName)
+			}
+		}
+	}
+	return nil
+}
+
+func (d *CustomRuleDetector) addMatch(ruleIdx int, match string) {
+	log.Debugf("custom rule %s matched %q in query", d.rules[ruleIdx].Id, match)
+	if _, ok := d.matchesDetected[ruleIdx]; !ok {
+		d.matchesDetected[ruleIdx] = mapset.NewThreadUnsafeSet[string]()
+	}
+	d.matchesDetected[ruleIdx].Add(match)
+}
+
+func (d *CustomRuleDetector) GetIssues() []QueryIssue {
+	var issues []QueryIssue
+	for i, rule := range d.rules {
+		matches, ok := d.matchesDetected[i]
+		if !ok {
+			continue
+		}
+		issues = append(issues, NewCustomRuleIssue(rule, DML_QUERY_OBJECT_TYPE, "", d.query, matches.ToSlice()))
+	}
+	return issues
+}
@@ -0,0 +1,131 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package queryissue
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yugabyte/yb-voyager/yb-voyager/src/constants"
+)
+
+const testCustomRulesYaml = `
+rules:
+  - id: no_serial
+    name: "SERIAL columns"
+    impact: LEVEL_2
+    suggestion: "Use identity columns instead."
+    match:
+      type_names: [serial, bigserial]
+  - id: NO_TIMESTAMP_WITHOUT_TZ
+    name: "timestamp without time zone"
+    match:
+      type_names: ["timestamp without time zone"]
+  - id: BANNED_FUNCTIONS
+    name: "Banned functions"
+    match:
+      function_names: [pg_sleep, public.legacy_func]
+      node_types: [LockingClause]
+`
+
+func writeCustomRulesFile(t *testing.T, content string) string {
+	filePath := filepath.Join(t.TempDir(), "rules.yaml")
+	err := os.WriteFile(filePath, []byte(content), 0644)
+	assert.NoError(t, err)
+	return filePath
+}
+
+func TestLoadCustomRules(t *testing.T) {
+	rules, err := LoadCustomRules(writeCustomRulesFile(t, testCustomRulesYaml))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(rules.Rules))
+	assert.Equal(t, "NO_SERIAL", rules.Rules[0].Id)
+	assert.Equal(t, constants.IMPACT_LEVEL_1, rules.Rules[1].Impact)
+	assert.Equal(t, []string{"timestamp"}, rules.Rules[1].Match.TypeNames)
+	assert.Equal(t, []string{"pg_query.LockingClause"}, rules.Rules[2].Match.NodeTypes)
+
+	invalidRules := map[string]string{
+		"no rules":          `rules: []`,
+		"unknown key":       "rules:\n  - id: R1\n    name: r1\n    matches:\n      type_names: [serial]\n",
+		"missing matchers":  "rules:\n  - id: R1\n    name: r1\n",
+		"invalid impact":    "rules:\n  - id: R1\n    name: r1\n    impact: HIGH\n    match:\n      type_names: [serial]\n",
+		"unknown node type": "rules:\n  - id: R1\n    name: r1\n    match:\n      node_types: [NoSuchNode]\n",
+		"duplicate ids":     "rules:\n  - id: R1\n    name: r1\n    match:\n      type_names: [serial]\n  - id: r1\n    name: r1\n    match:\n      type_names: [serial]\n",
+	}
+	for name, content := range invalidRules {
+		_, err := LoadCustomRules(writeCustomRulesFile(t, content))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestCustomRuleDetector(t *testing.T) {
+	rules, err := LoadCustomRules(writeCustomRulesFile(t, testCustomRulesYaml))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		Sql             string
+		ExpectedMatches map[string][]string // rule id -> matches
+	}{
+		{
+			Sql: `CREATE TABLE public.orders (id bigserial PRIMARY KEY, created_at timestamp, updated_at timestamptz);`,
+			ExpectedMatches: map[string][]string{
+				"NO_SERIAL":               {"bigserial"},
+				"NO_TIMESTAMP_WITHOUT_TZ": {"timestamp"},
+			},
+		},
+		{
+			Sql: `SELECT pg_sleep(1), public.legacy_func(id) FROM orders WHERE id = 1 FOR UPDATE;`,
+			ExpectedMatches: map[string][]string{
+				"BANNED_FUNCTIONS": {"LockingClause", "pg_sleep", "public.legacy_func"},
+			},
+		},
+		{
+			Sql:             `SELECT legacy_func(id), created_at::timestamptz FROM orders;`,
+			ExpectedMatches: map[string][]string{},
+		},
+	}
+
+	for _, tc := range tests {
+		issues := getDetectorIssues(t, NewCustomRuleDetector(tc.Sql, rules), tc.Sql)
+		assert.Equal(t, len(tc.ExpectedMatches), len(issues), "sql: %s", tc.Sql)
+		for _, i := range issues {
+			assert.True(t, IsCustomRuleIssue(i))
+			assert.Equal(t, tc.ExpectedMatches[i.Type], i.Details[CUSTOM_RULE_MATCHES], "sql: %s", tc.Sql)
+		}
+	}
+
+	// custom rules are evaluated along with the built-in detectors
+	detector := NewParserIssueDetector()
+	detector.SetCustomRules(rules)
+	issues, err := detector.getDDLIssues(`CREATE TABLE public.t1 (id serial PRIMARY KEY, data xml);`)
+	assert.NoError(t, err)
+	customIssues := 0
+	for _, i := range issues {
+		if IsCustomRuleIssue(i) {
+			customIssues++
+			assert.Equal(t, "NO_SERIAL", i.Type)
+			assert.Equal(t, constants.IMPACT_LEVEL_2, i.Impact)
+			assert.Equal(t, TABLE_OBJECT_TYPE, i.ObjectType)
+			assert.Equal(t, "public.t1", i.ObjectName)
+		}
+	}
+	assert.Equal(t, 1, customIssues)
+}
@@ -69,6 +69,9 @@ type ParserIssueDetector struct {
 
 	//columns names with jsonb type
 	jsonbColumns []string
+
+	// user-defined rules evaluated along with the built-in detectors
+	customRules *CustomRules
 }
 
 func NewParserIssueDetector() *ParserIssueDetector {
@@ -81,6 +84,23 @@ func NewParserIssueDetector() *ParserIssueDetector {
 	}
 }
 
+func (p *ParserIssueDetector) SetCustomRules(customRules *CustomRules) {
+	p.customRules = customRules
+}
+
+// GetCustomRuleDescription returns the description of the custom rule with the given id(issue type)
+func (p *ParserIssueDetector) GetCustomRuleDescription(ruleId string) string {
+	if p.customRules == nil {
+		return ""
+	}
+	for _, rule := range p.customRules.Rules {
+		if rule.Id == ruleId {
+			return rule.Description
+		}
+	}
+	return ""
+}
+
 func (p *ParserIssueDetector) GetCompositeTypes() []string {
 	return p.compositeTypes
 }
@@ -167,6 +187,26 @@ func (p *ParserIssueDetector) getPLPGSQLIssues(query string) ([]QueryIssue, erro
 		issues = append(issues, issuesInQuery...)
 	}
 
+	dynamicSQLQueries, err := queryparser.GetAllDynamicSQLStatementsInPLPGSQL(query)
+	if err != nil {
+		return nil, fmt.Errorf("error getting all the dynamic SQL queries from query: %w", err)
+	}
+	for _, dynamicSQLQuery := range dynamicSQLQueries {
+		issuesInQuery, err := p.getAllIssues(dynamicSQLQuery)
+		if err != nil {
+			// dynamic SQL is reconstructed on best-effort basis, it might not always be a valid statement
+			log.Infof("error getting issues in dynamic SQL query-%s of query-%s: %v", dynamicSQLQuery, query, err)
+			continue
+		}
+		for _, i := range issuesInQuery {
+			if i.Details == nil {
+				i.Details = make(map[string]interface{})
+			}
+			i.Details[IS_DYNAMIC_SQL] = true
+			issues = append(issues, i)
+		}
+	}
+
 	percentTypeSyntaxIssues, err := p.GetPercentTypeSyntaxIssues(query)
 	if err != nil {
 		return nil, fmt.Errorf("error getting reference TYPE syntax issues: %v", err)
@@ -398,6 +438,9 @@ func (p *ParserIssueDetector) genericIssues(query string) ([]QueryIssue, error)
 		NewUniqueNullsNotDistinctDetector(query),
 		NewJsonPredicateExprDetector(query),
 	}
+	if p.customRules != nil {
+		detectors = append(detectors, NewCustomRuleDetector(query, p.customRules))
+	}
 
 	processor := func(msg protoreflect.Message) error {
 		for _, detector := range detectors {
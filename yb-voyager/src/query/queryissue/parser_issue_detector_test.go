@@ -20,6 +20,7 @@ package queryissue
 import (
 	"fmt"
 	"slices"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -184,6 +185,11 @@ func modifiedIssuesforPLPGSQL(issues []QueryIssue, objType string, objName strin
 		return i
 	})
 }
+func dynamicSQLIssue(issue QueryIssue) QueryIssue {
+	issue.Details[IS_DYNAMIC_SQL] = true
+	return issue
+}
+
 func TestAllIssues(t *testing.T) {
 	requiredDDLs := []string{stmt12}
 	parserIssueDetector := NewParserIssueDetector()
@@ -192,7 +198,7 @@ func TestAllIssues(t *testing.T) {
 			NewPercentTypeSyntaxIssue("FUNCTION", "list_high_earners", "public.emp1.salary%TYPE"),
 			NewPercentTypeSyntaxIssue("FUNCTION", "list_high_earners", "employees.name%TYPE"),
 			NewPercentTypeSyntaxIssue("FUNCTION", "list_high_earners", "employees.salary%TYPE"),
-			NewClusterONIssue("TABLE", "employees", "ALTER TABLE employees CLUSTER ON idx;"),
+			dynamicSQLIssue(NewClusterONIssue("TABLE", "employees", "ALTER TABLE employees CLUSTER ON idx;")),
 			NewAdvisoryLocksIssue("DML_QUERY", "", "SELECT pg_advisory_unlock(sender_id);"),
 			NewAdvisoryLocksIssue("DML_QUERY", "", "SELECT pg_advisory_unlock(receiver_id);"),
 			NewXmlFunctionsIssue("DML_QUERY", "", "SELECT id, xpath('/person/name/text()', data) AS name FROM test_xml_type;"),
@@ -200,9 +206,9 @@ func TestAllIssues(t *testing.T) {
 		},
 		stmt2: []QueryIssue{
 			NewPercentTypeSyntaxIssue("FUNCTION", "process_order", "orders.id%TYPE"),
-			NewStorageParameterIssue("TABLE", "public.example", "ALTER TABLE ONLY public.example ADD CONSTRAINT example_email_key UNIQUE (email) WITH (fillfactor=70);"),
-			NewMultiColumnGinIndexIssue("INDEX", "idx_example ON example_table", "CREATE INDEX idx_example ON example_table USING gin(name, name1);"),
-			NewUnsupportedIndexMethodIssue("INDEX", "idx_example ON schema1.example_table", "CREATE INDEX idx_example ON schema1.example_table USING gist(name);", "gist"),
+			dynamicSQLIssue(NewStorageParameterIssue("TABLE", "public.example", "ALTER TABLE ONLY public.example ADD CONSTRAINT example_email_key UNIQUE (email) WITH (fillfactor=70);")),
+			dynamicSQLIssue(NewMultiColumnGinIndexIssue("INDEX", "idx_example ON example_table", "CREATE INDEX idx_example ON example_table USING gin(name, name1);")),
+			dynamicSQLIssue(NewUnsupportedIndexMethodIssue("INDEX", "idx_example ON schema1.example_table", "CREATE INDEX idx_example ON schema1.example_table USING gist(name);", "gist")),
 			NewAdvisoryLocksIssue("DML_QUERY", "", "SELECT pg_advisory_unlock(orderid);"),
 		},
 		stmt3: []QueryIssue{
@@ -476,8 +482,8 @@ $$ LANGUAGE plpgsql;
 		sqls[4]: []QueryIssue{
 			NewLOFuntionsIssue("DML_QUERY", "", "SELECT lo_put(fd, convert_to(new_data, 'UTF8'));", []string{"lo_put"}),
 			NewLOFuntionsIssue("DML_QUERY", "", "SELECT lo_close(fd);", []string{"lo_close"}),
-			NewLODatatypeIssue("TABLE", "test_large_objects", "CREATE TABLE IF NOT EXISTS test_large_objects(id INT, raster lo DEFAULT lo_import(3242));", "raster"),
-			NewLOFuntionsIssue("TABLE", "test_large_objects", "CREATE TABLE IF NOT EXISTS test_large_objects(id INT, raster lo DEFAULT lo_import(3242));", []string{"lo_import"}),
+			dynamicSQLIssue(NewLODatatypeIssue("TABLE", "test_large_objects", "CREATE TABLE IF NOT EXISTS test_large_objects(id INT, raster lo DEFAULT lo_import(3242));", "raster")),
+			dynamicSQLIssue(NewLOFuntionsIssue("TABLE", "test_large_objects", "CREATE TABLE IF NOT EXISTS test_large_objects(id INT, raster lo DEFAULT lo_import(3242));", []string{"lo_import"})),
 		},
 		sqls[5]: []QueryIssue{
 			NewLOFuntionsIssue("TRIGGER", "t_raster ON image", sqls[5], []string{"lo_manage"}),
@@ -1040,3 +1046,35 @@ REFERENCES schema1.abc (id);
 		}
 	}
 }
+
+func TestDynamicSQLIssuesInPLPGSQL(t *testing.T) {
+	sql := `CREATE OR REPLACE FUNCTION public.create_audit_table(tbl text, lock_id int) RETURNS VOID AS $$
+BEGIN
+	EXECUTE 'CREATE TABLE IF NOT EXISTS ' || quote_ident(tbl) || '_audit (id int, payload xml)';
+	EXECUTE format('SELECT pg_advisory_lock(%s)', lock_id);
+	EXECUTE 'SELECT xmin FROM ' || tbl || ' WHERE id = $1' USING lock_id;
+	PERFORM pg_advisory_unlock(lock_id);
+END;
+$$ LANGUAGE plpgsql;`
+
+	expectedIssues := []QueryIssue{
+		dynamicSQLIssue(NewXMLDatatypeIssue("FUNCTION", "public.create_audit_table", "CREATE TABLE IF NOT EXISTS voyager_placeholder_audit (id int, payload xml);", "payload")),
+		dynamicSQLIssue(NewAdvisoryLocksIssue("FUNCTION", "public.create_audit_table", "SELECT pg_advisory_lock(voyager_placeholder);")),
+		dynamicSQLIssue(NewSystemColumnsIssue("FUNCTION", "public.create_audit_table", "SELECT xmin FROM voyager_placeholder WHERE id = $1;")),
+		NewAdvisoryLocksIssue("FUNCTION", "public.create_audit_table", "SELECT pg_advisory_unlock(lock_id);"),
+	}
+
+	parserIssueDetector := NewParserIssueDetector()
+	issues, err := parserIssueDetector.GetAllPLPGSQLIssues(sql, ybversion.LatestStable)
+	assert.NoError(t, err)
+	assert.Equal(t, len(expectedIssues), len(issues))
+	for _, expectedIssue := range expectedIssues {
+		found := slices.ContainsFunc(issues, func(queryIssue QueryIssue) bool {
+			return cmp.Equal(expectedIssue, queryIssue)
+		})
+		assert.True(t, found, "Expected issue not found: %v", expectedIssue)
+	}
+	for _, issue := range issues {
+		assert.Equal(t, strings.HasPrefix(issue.SqlStatement, "SELECT pg_advisory_unlock"), !IsDynamicSQLIssue(issue))
+	}
+}
@@ -40,3 +40,9 @@ func newQueryIssue(issue issue.Issue, objectType string, objectName string, sqlS
 		Details:      details,
 	}
 }
+
+// IsDynamicSQLIssue returns true if the issue was detected in the SQL built as a string for EXECUTE in PL/pgSQL
+func IsDynamicSQLIssue(i QueryIssue) bool {
+	isDynamicSQL, ok := i.Details[IS_DYNAMIC_SQL].(bool)
+	return ok && isDynamicSQL
+}
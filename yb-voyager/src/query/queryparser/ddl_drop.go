@@ -0,0 +1,203 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package queryparser
+
+import (
+	"fmt"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+)
+
+/*
+GetDropStmtForCreateDDL returns the DROP statement which undoes the object created by the given DDL.
+An empty string is returned for the DDLs which don't create an object (COMMENT, GRANT, ALTER other than ADD CONSTRAINT, ...)
+The DROP statement is built as a parse tree and deparsed so that the identifiers are quoted correctly.
+
+The DROP is not CASCADE, so that it fails instead of silently dropping the objects depending on it which were not
+created by import schema, e.g. views or foreign keys of the user's tables. The objects created by import schema
+are expected to be dropped in the reverse order of their creation, in which the dependent objects are dropped first.
+
+For example: CREATE INDEX idx1 ON public."Test"(id); -> DROP INDEX IF EXISTS public.idx1
+*/
+func GetDropStmtForCreateDDL(parseTree *pg_query.ParseResult) (string, error) {
+	if len(parseTree.Stmts) == 0 {
+		return "", nil
+	}
+	dropStmt := &pg_query.DropStmt{
+		MissingOk: true,
+		Behavior:  pg_query.DropBehavior_DROP_RESTRICT,
+	}
+	switch node := parseTree.Stmts[0].Stmt.Node.(type) {
+	case *pg_query.Node_AlterTableStmt:
+		return getDropConstraintStmt(node.AlterTableStmt)
+	case *pg_query.Node_CreateStmt:
+		dropStmt.RemoveType = pg_query.ObjectType_OBJECT_TABLE
+		dropStmt.Objects = []*pg_query.Node{rangeVarToNameList(node.CreateStmt.Relation)}
+	case *pg_query.Node_CreateForeignTableStmt:
+		dropStmt.RemoveType = pg_query.ObjectType_OBJECT_FOREIGN_TABLE
+		dropStmt.Objects = []*pg_query.Node{rangeVarToNameList(node.CreateForeignTableStmt.BaseStmt.Relation)}
+	case *pg_query.Node_IndexStmt:
+		if node.IndexStmt.Idxname == "" {
+			return "", nil
+		}
+		// index is always created in the schema of its table
+		dropStmt.RemoveType = pg_query.ObjectType_OBJECT_INDEX
+		dropStmt.Objects = []*pg_query.Node{nameList(node.IndexStmt.Relation.Schemaname, node.IndexStmt.Idxname)}
+	case *pg_query.Node_ViewStmt:
+		dropStmt.RemoveType = pg_query.ObjectType_OBJECT_VIEW
+		dropStmt.Objects = []*pg_query.Node{rangeVarToNameList(node.ViewStmt.View)}
+	case *pg_query.Node_CreateTableAsStmt:
+		if node.CreateTableAsStmt.Objtype == pg_query.ObjectType_OBJECT_MATVIEW {
+			dropStmt.RemoveType = pg_query.ObjectType_OBJECT_MATVIEW
+		} else {
+			dropStmt.RemoveType = pg_query.ObjectType_OBJECT_TABLE
+		}
+		dropStmt.Objects = []*pg_query.Node{rangeVarToNameList(node.CreateTableAsStmt.Into.Rel)}
+	case *pg_query.Node_CreateSeqStmt:
+		dropStmt.RemoveType = pg_query.ObjectType_OBJECT_SEQUENCE
+		dropStmt.Objects = []*pg_query.Node{rangeVarToNameList(node.CreateSeqStmt.Sequence)}
+	case *pg_query.Node_CompositeTypeStmt:
+		dropStmt.RemoveType = pg_query.ObjectType_OBJECT_TYPE
+		dropStmt.Objects = []*pg_query.Node{typeNameNode(rangeVarToNameList(node.CompositeTypeStmt.Typevar).GetList().Items)}
+	case *pg_query.Node_CreateEnumStmt:
+		dropStmt.RemoveType = pg_query.ObjectType_OBJECT_TYPE
+		dropStmt.Objects = []*pg_query.Node{typeNameNode(node.CreateEnumStmt.TypeName)}
+	case *pg_query.Node_CreateRangeStmt:
+		dropStmt.RemoveType = pg_query.ObjectType_OBJECT_TYPE
+		dropStmt.Objects = []*pg_query.Node{typeNameNode(node.CreateRangeStmt.TypeName)}
+	case *pg_query.Node_CreateDomainStmt:
+		dropStmt.RemoveType = pg_query.ObjectType_OBJECT_DOMAIN
+		dropStmt.Objects = []*pg_query.Node{typeNameNode(node.CreateDomainStmt.Domainname)}
+	case *pg_query.Node_CreateFunctionStmt:
+		dropStmt.RemoveType = pg_query.ObjectType_OBJECT_FUNCTION
+		if node.CreateFunctionStmt.IsProcedure {
+			dropStmt.RemoveType = pg_query.ObjectType_OBJECT_PROCEDURE
+		}
+		dropStmt.Objects = []*pg_query.Node{functionWithArgsNode(node.CreateFunctionStmt)}
+	case *pg_query.Node_CreateSchemaStmt:
+		dropStmt.RemoveType = pg_query.ObjectType_OBJECT_SCHEMA
+		dropStmt.Objects = []*pg_query.Node{pg_query.MakeStrNode(node.CreateSchemaStmt.Schemaname)}
+	case *pg_query.Node_CreateExtensionStmt:
+		dropStmt.RemoveType = pg_query.ObjectType_OBJECT_EXTENSION
+		dropStmt.Objects = []*pg_query.Node{pg_query.MakeStrNode(node.CreateExtensionStmt.Extname)}
+	case *pg_query.Node_CreateTrigStmt:
+		dropStmt.RemoveType = pg_query.ObjectType_OBJECT_TRIGGER
+		dropStmt.Objects = []*pg_query.Node{appendToNameList(rangeVarToNameList(node.CreateTrigStmt.Relation), node.CreateTrigStmt.Trigname)}
+	case *pg_query.Node_CreatePolicyStmt:
+		dropStmt.RemoveType = pg_query.ObjectType_OBJECT_POLICY
+		dropStmt.Objects = []*pg_query.Node{appendToNameList(rangeVarToNameList(node.CreatePolicyStmt.Table), node.CreatePolicyStmt.PolicyName)}
+	case *pg_query.Node_DefineStmt:
+		if node.DefineStmt.Kind != pg_query.ObjectType_OBJECT_COLLATION {
+			return "", nil
+		}
+		dropStmt.RemoveType = pg_query.ObjectType_OBJECT_COLLATION
+		dropStmt.Objects = []*pg_query.Node{pg_query.MakeListNode(node.DefineStmt.Defnames)}
+	default:
+		return "", nil
+	}
+
+	return deparseDropStmt(&pg_query.Node{Node: &pg_query.Node_DropStmt{DropStmt: dropStmt}})
+}
+
+/*
+getDropConstraintStmt returns the statement to drop the constraint added by ALTER TABLE ... ADD CONSTRAINT.
+The constraints, for example foreign keys, are added after all the tables are created, so they have to be dropped
+before the tables they refer to.
+
+For example: ALTER TABLE ONLY public.orders ADD CONSTRAINT orders_fk FOREIGN KEY (cust_id) REFERENCES public.customers(id);
+-> ALTER TABLE IF EXISTS public.orders DROP CONSTRAINT IF EXISTS orders_fk
+*/
+func getDropConstraintStmt(alterTableStmt *pg_query.AlterTableStmt) (string, error) {
+	if alterTableStmt.Objtype != pg_query.ObjectType_OBJECT_TABLE || len(alterTableStmt.Cmds) != 1 {
+		return "", nil
+	}
+	alterTableCmd := alterTableStmt.Cmds[0].GetAlterTableCmd()
+	constraint := alterTableCmd.GetDef().GetConstraint()
+	if alterTableCmd.GetSubtype() != pg_query.AlterTableType_AT_AddConstraint || constraint.GetConname() == "" {
+		return "", nil
+	}
+	relation := &pg_query.RangeVar{
+		Schemaname:     alterTableStmt.Relation.Schemaname,
+		Relname:        alterTableStmt.Relation.Relname,
+		Inh:            true,
+		Relpersistence: "p",
+	}
+	dropConstraintStmt := &pg_query.AlterTableStmt{
+		Relation:  relation,
+		Objtype:   pg_query.ObjectType_OBJECT_TABLE,
+		MissingOk: true,
+		Cmds: []*pg_query.Node{{Node: &pg_query.Node_AlterTableCmd{AlterTableCmd: &pg_query.AlterTableCmd{
+			Subtype:   pg_query.AlterTableType_AT_DropConstraint,
+			Name:      constraint.GetConname(),
+			Behavior:  pg_query.DropBehavior_DROP_RESTRICT,
+			MissingOk: true,
+		}}}},
+	}
+	return deparseDropStmt(&pg_query.Node{Node: &pg_query.Node_AlterTableStmt{AlterTableStmt: dropConstraintStmt}})
+}
+
+func deparseDropStmt(stmt *pg_query.Node) (string, error) {
+	dropParseTree := &pg_query.ParseResult{
+		Stmts: []*pg_query.RawStmt{{Stmt: stmt}},
+	}
+	dropSql, err := pg_query.Deparse(dropParseTree)
+	if err != nil {
+		return "", fmt.Errorf("deparse drop stmt: %w", err)
+	}
+	return dropSql, nil
+}
+
+func nameList(schemaName string, objName string) *pg_query.Node {
+	var names []*pg_query.Node
+	if schemaName != "" {
+		names = append(names, pg_query.MakeStrNode(schemaName))
+	}
+	names = append(names, pg_query.MakeStrNode(objName))
+	return pg_query.MakeListNode(names)
+}
+
+func rangeVarToNameList(rangeVar *pg_query.RangeVar) *pg_query.Node {
+	return nameList(rangeVar.GetSchemaname(), rangeVar.GetRelname())
+}
+
+func appendToNameList(list *pg_query.Node, name string) *pg_query.Node {
+	items := append(list.GetList().Items, pg_query.MakeStrNode(name))
+	return pg_query.MakeListNode(items)
+}
+
+func typeNameNode(names []*pg_query.Node) *pg_query.Node {
+	return &pg_query.Node{Node: &pg_query.Node_TypeName{TypeName: &pg_query.TypeName{Names: names, Typemod: -1}}}
+}
+
+// DROP FUNCTION/PROCEDURE only considers the input arguments of the function for its signature
+func functionWithArgsNode(createFuncStmt *pg_query.CreateFunctionStmt) *pg_query.Node {
+	var args []*pg_query.Node
+	for _, param := range createFuncStmt.GetParameters() {
+		funcParam, ok := param.Node.(*pg_query.Node_FunctionParameter)
+		if !ok {
+			continue
+		}
+		switch funcParam.FunctionParameter.Mode {
+		case pg_query.FunctionParameterMode_FUNC_PARAM_OUT, pg_query.FunctionParameterMode_FUNC_PARAM_TABLE:
+			continue
+		}
+		args = append(args, &pg_query.Node{Node: &pg_query.Node_TypeName{TypeName: funcParam.FunctionParameter.ArgType}})
+	}
+	return &pg_query.Node{Node: &pg_query.Node_ObjectWithArgs{ObjectWithArgs: &pg_query.ObjectWithArgs{
+		Objname: createFuncStmt.GetFuncname(),
+		Objargs: args,
+	}}}
+}
@@ -0,0 +1,89 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package queryparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDropStmtForCreateDDL(t *testing.T) {
+	tests := []struct {
+		Sql          string
+		ExpectedDrop string
+	}{
+		{
+			Sql:          `CREATE TABLE public."Orders" (id int PRIMARY KEY, amount numeric);`,
+			ExpectedDrop: `DROP TABLE IF EXISTS public."Orders"`,
+		},
+		{
+			Sql:          `CREATE INDEX idx_orders_amount ON public.orders USING btree (amount);`,
+			ExpectedDrop: `DROP INDEX IF EXISTS public.idx_orders_amount`,
+		},
+		{
+			Sql:          `CREATE SEQUENCE sales.order_seq START WITH 1;`,
+			ExpectedDrop: `DROP SEQUENCE IF EXISTS sales.order_seq`,
+		},
+		{
+			Sql:          `CREATE MATERIALIZED VIEW public.mv AS SELECT * FROM public.orders;`,
+			ExpectedDrop: `DROP MATERIALIZED VIEW IF EXISTS public.mv`,
+		},
+		{
+			Sql:          `CREATE TYPE public.status AS ENUM ('active', 'inactive');`,
+			ExpectedDrop: `DROP TYPE IF EXISTS public.status`,
+		},
+		{
+			Sql:          `CREATE SCHEMA sales;`,
+			ExpectedDrop: `DROP SCHEMA IF EXISTS sales`,
+		},
+		{
+			Sql: `CREATE FUNCTION public.add(a integer, b integer, OUT total integer) LANGUAGE plpgsql AS $$
+				BEGIN total := a + b; END; $$;`,
+			ExpectedDrop: `DROP FUNCTION IF EXISTS public.add(int, int)`,
+		},
+		{
+			Sql:          `CREATE TRIGGER audit_trg BEFORE INSERT ON public.orders FOR EACH ROW EXECUTE FUNCTION public.audit();`,
+			ExpectedDrop: `DROP TRIGGER IF EXISTS audit_trg ON public.orders`,
+		},
+		{
+			Sql:          `ALTER TABLE ONLY public.orders ADD CONSTRAINT orders_pkey PRIMARY KEY (id);`,
+			ExpectedDrop: `ALTER TABLE IF EXISTS public.orders DROP CONSTRAINT IF EXISTS orders_pkey`,
+		},
+		{
+			Sql:          `ALTER TABLE ONLY public.orders ADD CONSTRAINT "Orders_fk" FOREIGN KEY (cust_id) REFERENCES public.customers(id);`,
+			ExpectedDrop: `ALTER TABLE IF EXISTS public.orders DROP CONSTRAINT IF EXISTS "Orders_fk"`,
+		},
+		{
+			Sql:          `ALTER TABLE public.orders ALTER COLUMN id SET DEFAULT nextval('public.orders_id_seq');`,
+			ExpectedDrop: ``,
+		},
+		{
+			Sql:          `ALTER TABLE public.orders ADD PRIMARY KEY (id);`,
+			ExpectedDrop: ``,
+		},
+	}
+
+	for _, tc := range tests {
+		parseTree, err := Parse(tc.Sql)
+		assert.NoError(t, err)
+		dropStmt, err := GetDropStmtForCreateDDL(parseTree)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.ExpectedDrop, dropStmt, "sql: %s", tc.Sql)
+	}
+}
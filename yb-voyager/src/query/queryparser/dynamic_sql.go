@@ -0,0 +1,216 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package queryparser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+)
+
+const (
+	// replaces the non-constant parts of a dynamic SQL expression. It is a valid identifier as well as a valid
+	// column reference, so the statement remains parseable in most of the places a variable is used to build the SQL.
+	DYNAMIC_SQL_PLACEHOLDER         = "voyager_placeholder"
+	DYNAMIC_SQL_LITERAL_PLACEHOLDER = "'voyager_placeholder'"
+)
+
+/*
+BuildDynamicSQLFromExpr evaluates the PL/pgSQL expression used to build a dynamic SQL string.
+Supported: string/numeric constants, || concatenation, casts, format(), concat(), concat_ws(),
+quote_ident(), quote_literal() and quote_nullable(). Anything else is replaced with a placeholder.
+
+For example: 'SELECT * FROM ' || quote_ident(tbl) || ' WHERE id = $1' -> SELECT * FROM voyager_placeholder WHERE id = $1
+*/
+func BuildDynamicSQLFromExpr(expr string) (string, error) {
+	parseTree, err := Parse(fmt.Sprintf("SELECT %s", expr))
+	if err != nil {
+		return "", fmt.Errorf("parsing dynamic SQL expression: %w", err)
+	}
+	if len(parseTree.Stmts) != 1 {
+		return "", fmt.Errorf("unexpected number of statements in dynamic SQL expression")
+	}
+	selectStmt := parseTree.Stmts[0].Stmt.GetSelectStmt()
+	if selectStmt == nil || len(selectStmt.TargetList) != 1 || selectStmt.FromClause != nil {
+		return "", fmt.Errorf("dynamic SQL expression is not a single value expression")
+	}
+	resTarget := selectStmt.TargetList[0].GetResTarget()
+	if resTarget == nil {
+		return "", fmt.Errorf("dynamic SQL expression is not a single value expression")
+	}
+
+	sql, isConst := evalDynamicSQLExpr(resTarget.Val)
+	if !isConst && !strings.Contains(sql, " ") {
+		// only a variable/function call e.g. EXECUTE stmt; nothing to analyze
+		return "", fmt.Errorf("no constant fragments in the dynamic SQL expression")
+	}
+	sql = strings.TrimSpace(sql)
+	if sql == "" {
+		return "", fmt.Errorf("empty dynamic SQL")
+	}
+	return sql, nil
+}
+
+// returns the text the node evaluates to and whether it is a constant (no placeholders substituted)
+func evalDynamicSQLExpr(node *pg_query.Node) (string, bool) {
+	if node == nil {
+		return DYNAMIC_SQL_PLACEHOLDER, false
+	}
+	switch n := node.Node.(type) {
+	case *pg_query.Node_AConst:
+		switch val := n.AConst.Val.(type) {
+		case *pg_query.A_Const_Sval:
+			return val.Sval.Sval, true
+		case *pg_query.A_Const_Ival:
+			return strconv.Itoa(int(val.Ival.Ival)), true
+		case *pg_query.A_Const_Fval:
+			return val.Fval.Fval, true
+		case *pg_query.A_Const_Boolval:
+			return strconv.FormatBool(val.Boolval.Boolval), true
+		}
+		return "", true // NULL
+	case *pg_query.Node_AExpr:
+		if n.AExpr.Kind == pg_query.A_Expr_Kind_AEXPR_OP && len(n.AExpr.Name) == 1 &&
+			n.AExpr.Name[0].GetString_().GetSval() == "||" {
+			left, isLeftConst := evalDynamicSQLExpr(n.AExpr.Lexpr)
+			right, isRightConst := evalDynamicSQLExpr(n.AExpr.Rexpr)
+			return left + right, isLeftConst && isRightConst
+		}
+	case *pg_query.Node_TypeCast:
+		return evalDynamicSQLExpr(n.TypeCast.Arg)
+	case *pg_query.Node_CoalesceExpr:
+		if len(n.CoalesceExpr.Args) > 0 {
+			return evalDynamicSQLExpr(n.CoalesceExpr.Args[0])
+		}
+	case *pg_query.Node_FuncCall:
+		return evalDynamicSQLFuncCall(n.FuncCall)
+	}
+	return DYNAMIC_SQL_PLACEHOLDER, false
+}
+
+func evalDynamicSQLFuncCall(funcCall *pg_query.FuncCall) (string, bool) {
+	funcName := ""
+	if len(funcCall.Funcname) > 0 {
+		funcName = strings.ToLower(funcCall.Funcname[len(funcCall.Funcname)-1].GetString_().GetSval())
+	}
+	args := funcCall.Args
+	switch funcName {
+	case "format":
+		if len(args) == 0 {
+			break
+		}
+		formatStr, isConst := evalDynamicSQLExpr(args[0])
+		if !isConst {
+			break
+		}
+		return evalFormatFunction(formatStr, args[1:])
+	case "concat", "concat_ws":
+		var sep string
+		isSepConst := true
+		if funcName == "concat_ws" {
+			if len(args) == 0 {
+				break
+			}
+			sep, isSepConst = evalDynamicSQLExpr(args[0])
+			args = args[1:]
+		}
+		parts := make([]string, 0, len(args))
+		isConst := isSepConst
+		for _, arg := range args {
+			part, isPartConst := evalDynamicSQLExpr(arg)
+			parts = append(parts, part)
+			isConst = isConst && isPartConst
+		}
+		return strings.Join(parts, sep), isConst
+	case "quote_ident":
+		if len(args) == 1 {
+			return evalDynamicSQLExpr(args[0])
+		}
+	case "quote_literal", "quote_nullable":
+		if len(args) == 1 {
+			val, isConst := evalDynamicSQLExpr(args[0])
+			if !isConst {
+				return DYNAMIC_SQL_LITERAL_PLACEHOLDER, false
+			}
+			return quoteLiteral(val), true
+		}
+	}
+	return DYNAMIC_SQL_PLACEHOLDER, false
+}
+
+/*
+evalFormatFunction substitutes the format specifiers(%s, %I, %L) of format() with the evaluated arguments.
+The position(%1$s) and the width flags are supported as per https://www.postgresql.org/docs/current/functions-string.html#FUNCTIONS-STRING-FORMAT
+*/
+func evalFormatFunction(formatStr string, args []*pg_query.Node) (string, bool) {
+	var result strings.Builder
+	isConst := true
+	nextArg := 0
+	for i := 0; i < len(formatStr); i++ {
+		if formatStr[i] != '%' {
+			result.WriteByte(formatStr[i])
+			continue
+		}
+		i++
+		if i >= len(formatStr) {
+			break
+		}
+		if formatStr[i] == '%' {
+			result.WriteByte('%')
+			continue
+		}
+
+		// optional position n$, flags(-) and width(n or *) before the type
+		j := i
+		for j < len(formatStr) && strings.IndexByte("0123456789$-*", formatStr[j]) != -1 {
+			j++
+		}
+		if j >= len(formatStr) {
+			break
+		}
+		spec := formatStr[i:j]
+		if dollarIdx := strings.IndexByte(spec, '$'); dollarIdx > 0 {
+			position, err := strconv.Atoi(spec[:dollarIdx])
+			if err == nil {
+				nextArg = position - 1
+			}
+		}
+		i = j
+
+		val, isArgConst := DYNAMIC_SQL_PLACEHOLDER, false
+		if nextArg >= 0 && nextArg < len(args) {
+			val, isArgConst = evalDynamicSQLExpr(args[nextArg])
+		}
+		nextArg++
+		switch formatStr[i] {
+		case 'L':
+			if isArgConst {
+				val = quoteLiteral(val)
+			} else {
+				val = DYNAMIC_SQL_LITERAL_PLACEHOLDER
+			}
+		}
+		result.WriteString(val)
+		isConst = isConst && isArgConst
+	}
+	return result.String(), isConst
+}
+
+func quoteLiteral(val string) string {
+	return "'" + strings.ReplaceAll(val, "'", "''") + "'"
+}
@@ -0,0 +1,100 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package queryparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildDynamicSQLFromExpr(t *testing.T) {
+	tests := []struct {
+		Expr        string
+		ExpectedSql string
+		ExpectError bool
+	}{
+		{
+			Expr:        `'DROP TABLE IF EXISTS employees'`,
+			ExpectedSql: `DROP TABLE IF EXISTS employees`,
+		},
+		{
+			Expr:        `'CREATE TABLE ' || quote_ident(tbl) || '_p (id int, data xml)'`,
+			ExpectedSql: `CREATE TABLE voyager_placeholder_p (id int, data xml)`,
+		},
+		{
+			Expr:        `'SELECT * FROM ' || 'public.' || tbl::text || ' WHERE name = ' || quote_literal(v_name)`,
+			ExpectedSql: `SELECT * FROM public.voyager_placeholder WHERE name = 'voyager_placeholder'`,
+		},
+		{
+			Expr:        `format('SELECT pg_advisory_lock(%s) FROM %I.%I WHERE a = %L AND b = %L AND c = %2$s %%', 100, 'public', tbl, 'it''s', v)`,
+			ExpectedSql: `SELECT pg_advisory_lock(100) FROM public.voyager_placeholder WHERE a = 'it''s' AND b = 'voyager_placeholder' AND c = public %`,
+		},
+		{
+			Expr:        `concat_ws(' ', 'SELECT xmin', 'FROM', tbl)`,
+			ExpectedSql: `SELECT xmin FROM voyager_placeholder`,
+		},
+		{
+			Expr:        `v_stmt`,
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		sql, err := BuildDynamicSQLFromExpr(tc.Expr)
+		if tc.ExpectError {
+			assert.Error(t, err, "expr: %s", tc.Expr)
+			continue
+		}
+		assert.NoError(t, err, "expr: %s", tc.Expr)
+		assert.Equal(t, tc.ExpectedSql, sql, "expr: %s", tc.Expr)
+	}
+}
+
+func TestGetAllDynamicSQLStatementsInPLPGSQL(t *testing.T) {
+	sql := `CREATE FUNCTION public.create_partition(tbl text) RETURNS void LANGUAGE plpgsql AS $$
+DECLARE
+	r record;
+	c refcursor;
+BEGIN
+	EXECUTE 'CREATE TABLE ' || quote_ident(tbl) || '_p (id int) ' USING tbl;
+	EXECUTE format('SELECT pg_advisory_lock(%s) FROM %I', 1, tbl) INTO r;
+	FOR r IN EXECUTE 'SELECT xmin FROM ' || tbl LOOP
+		UPDATE accounts SET balance = 0;
+	END LOOP;
+	OPEN c FOR EXECUTE 'SELECT 1; SELECT 2';
+	EXECUTE v_stmt;
+END $$;`
+
+	stmts, err := GetAllDynamicSQLStatementsInPLPGSQL(sql)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"CREATE TABLE voyager_placeholder_p (id int);",
+		"SELECT pg_advisory_lock(1) FROM voyager_placeholder;",
+		"SELECT xmin FROM voyager_placeholder;",
+		"SELECT 1;",
+		"SELECT 2;",
+	}, stmts)
+
+	// the dynamic SQL expressions are not returned as PL/pgSQL statements but the other parts of the statements are
+	plpgsqlStmts, err := GetAllPLPGSQLStatements(sql)
+	assert.NoError(t, err)
+	assert.Contains(t, plpgsqlStmts, "UPDATE accounts SET balance = 0;")
+	assert.Contains(t, plpgsqlStmts, "tbl;")
+	assert.NotContains(t, plpgsqlStmts, "'SELECT xmin FROM ' || tbl;")
+}
@@ -44,6 +44,23 @@ func Parse(query string) (*pg_query.ParseResult, error) {
 	return tree, nil
 }
 
+/*
+NormalizeQuery replaces the constants in the query with the parameters($1, $2, ..) like pg_stat_statements does
+and returns the normalized query along with its fingerprint.
+Queries differing only in the constants have the same fingerprint.
+*/
+func NormalizeQuery(query string) (string, uint64, error) {
+	normalizedQuery, err := pg_query.Normalize(query)
+	if err != nil {
+		return "", 0, fmt.Errorf("normalizing query: %w", err)
+	}
+	fingerprint, err := pg_query.FingerprintToUInt64(query)
+	if err != nil {
+		return "", 0, fmt.Errorf("fingerprinting query: %w", err)
+	}
+	return normalizedQuery, fingerprint, nil
+}
+
 func ParsePLPGSQLToJson(query string) (string, error) {
 	log.Debugf("parsing the PLPGSQL to json query [%s]", query)
 	jsonString, err := pg_query.ParsePlPgSqlToJSON(query)
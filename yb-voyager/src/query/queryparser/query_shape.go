@@ -0,0 +1,275 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package queryparser
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	PG_QUERY_AEXPR_NODE          = "pg_query.A_Expr"
+	PG_QUERY_SORTBY_NODE         = "pg_query.SortBy"
+	PG_QUERY_LOCKING_CLAUSE_NODE = "pg_query.LockingClause"
+)
+
+var rangeOperators = []string{"<", ">", "<=", ">="}
+
+// ColumnUsage describes how a column is used in the predicates and the ORDER BY clauses of a query
+type ColumnUsage struct {
+	EqualityFilter bool
+	RangeFilter    bool
+	OrderByAsc     bool
+	OrderByDesc    bool
+}
+
+// ColumnRef is a column reference in a query, qualified by the table name or alias if used so in the query
+type ColumnRef struct {
+	Qualifier string
+	Name      string
+}
+
+// TableRef is a relation in the FROM clause of a query, along with its alias if any
+type TableRef struct {
+	SchemaName string
+	TableName  string
+	Alias      string
+}
+
+/*
+QueryShape is the set of relations referenced in a query along with the usage of the columns in it.
+Columns tracks the usage by the unqualified column names, and ColumnRefs by the column references as used in
+the query, so that they can be resolved to the relations via their names or aliases(see RelationColumns).
+
+For example: SELECT * FROM orders o WHERE o.created_at > $1 ORDER BY created_at DESC
+Relations: [orders], Tables: [{TableName: orders, Alias: o}], Columns: {created_at: {RangeFilter: true, OrderByDesc: true}}
+ColumnRefs: {{o, created_at}: {RangeFilter: true}, {"", created_at}: {OrderByDesc: true}}
+*/
+type QueryShape struct {
+	Relations     []string // both unqualified and schema qualified names as used in the query
+	Tables        []TableRef
+	Columns       map[string]*ColumnUsage
+	ColumnRefs    map[ColumnRef]*ColumnUsage
+	JoinColumns   [][2]ColumnRef // pairs of columns compared for equality, like a.id = b.a_id
+	HasRowLocking bool           // SELECT ... FOR UPDATE/NO KEY UPDATE/SHARE/KEY SHARE
+}
+
+func GetQueryShape(query string) (*QueryShape, error) {
+	parseTree, err := Parse(query)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing query: %w", err)
+	}
+
+	shape := &QueryShape{
+		Columns:    make(map[string]*ColumnUsage),
+		ColumnRefs: make(map[ColumnRef]*ColumnUsage),
+	}
+	visited := make(map[protoreflect.Message]bool)
+	err = TraverseParseTree(GetProtoMessageFromParseTree(parseTree), visited, func(msg protoreflect.Message) error {
+		switch GetMsgFullName(msg) {
+		case PG_QUERY_RANGEVAR_NODE:
+			rangeVar, ok := msg.Interface().(*pg_query.RangeVar)
+			if !ok {
+				return nil
+			}
+			relName := strings.ToLower(rangeVar.Relname)
+			tableRef := TableRef{SchemaName: strings.ToLower(rangeVar.Schemaname), TableName: relName}
+			if rangeVar.Alias != nil {
+				tableRef.Alias = strings.ToLower(rangeVar.Alias.Aliasname)
+			}
+			shape.Tables = append(shape.Tables, tableRef)
+			if !slices.Contains(shape.Relations, relName) {
+				shape.Relations = append(shape.Relations, relName)
+			}
+			if rangeVar.Schemaname != "" {
+				qualifiedName := fmt.Sprintf("%s.%s", strings.ToLower(rangeVar.Schemaname), relName)
+				if !slices.Contains(shape.Relations, qualifiedName) {
+					shape.Relations = append(shape.Relations, qualifiedName)
+				}
+			}
+		case PG_QUERY_AEXPR_NODE:
+			aExpr, ok := msg.Interface().(*pg_query.A_Expr)
+			if !ok {
+				return nil
+			}
+			shape.processAExpr(aExpr)
+		case PG_QUERY_LOCKING_CLAUSE_NODE:
+			shape.HasRowLocking = true
+		case PG_QUERY_SORTBY_NODE:
+			sortBy, ok := msg.Interface().(*pg_query.SortBy)
+			if !ok {
+				return nil
+			}
+			for _, usage := range shape.getColumnUsages(sortBy.Node) {
+				if sortBy.SortbyDir == pg_query.SortByDir_SORTBY_DESC {
+					usage.OrderByDesc = true
+				} else {
+					usage.OrderByAsc = true
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("traversing parse tree: %w", err)
+	}
+	return shape, nil
+}
+
+func (s *QueryShape) processAExpr(aExpr *pg_query.A_Expr) {
+	switch aExpr.Kind {
+	case pg_query.A_Expr_Kind_AEXPR_OP:
+		if len(aExpr.Name) != 1 {
+			return
+		}
+		operator := aExpr.Name[0].GetString_().GetSval()
+		if operator == "=" && s.isColumnRef(aExpr.Lexpr) && s.isColumnRef(aExpr.Rexpr) {
+			pair := [2]ColumnRef{s.getColumnRef(aExpr.Lexpr), s.getColumnRef(aExpr.Rexpr)}
+			if !slices.Contains(s.JoinColumns, pair) {
+				s.JoinColumns = append(s.JoinColumns, pair)
+			}
+		}
+		for _, operand := range []*pg_query.Node{aExpr.Lexpr, aExpr.Rexpr} {
+			for _, usage := range s.getColumnUsages(operand) {
+				if operator == "=" {
+					usage.EqualityFilter = true
+				} else if slices.Contains(rangeOperators, operator) {
+					usage.RangeFilter = true
+				}
+			}
+		}
+	case pg_query.A_Expr_Kind_AEXPR_IN:
+		for _, usage := range s.getColumnUsages(aExpr.Lexpr) {
+			usage.EqualityFilter = true
+		}
+	case pg_query.A_Expr_Kind_AEXPR_BETWEEN, pg_query.A_Expr_Kind_AEXPR_NOT_BETWEEN,
+		pg_query.A_Expr_Kind_AEXPR_BETWEEN_SYM, pg_query.A_Expr_Kind_AEXPR_NOT_BETWEEN_SYM:
+		for _, usage := range s.getColumnUsages(aExpr.Lexpr) {
+			usage.RangeFilter = true
+		}
+	}
+}
+
+func (s *QueryShape) isColumnRef(node *pg_query.Node) bool {
+	return s.getColumnRef(node).Name != ""
+}
+
+// returns the lower cased column reference if the node is a column reference(possibly with a cast) else empty ColumnRef
+func (s *QueryShape) getColumnRef(node *pg_query.Node) ColumnRef {
+	if node == nil {
+		return ColumnRef{}
+	}
+	if typeCast := node.GetTypeCast(); typeCast != nil {
+		node = typeCast.Arg
+	}
+	columnRef := node.GetColumnRef()
+	if columnRef == nil {
+		return ColumnRef{}
+	}
+	// fields are [column], [table, column] or [schema, table, column]
+	var names []string
+	for _, field := range columnRef.Fields {
+		if name := field.GetString_().GetSval(); name != "" {
+			names = append(names, strings.ToLower(name))
+		}
+	}
+	if len(names) == 0 {
+		return ColumnRef{}
+	}
+	ref := ColumnRef{Name: names[len(names)-1]}
+	if len(names) > 1 {
+		ref.Qualifier = names[len(names)-2]
+	}
+	return ref
+}
+
+// returns the usages(by the unqualified name and by the reference) if the node is a column reference(possibly with a cast)
+func (s *QueryShape) getColumnUsages(node *pg_query.Node) []*ColumnUsage {
+	ref := s.getColumnRef(node)
+	if ref.Name == "" {
+		return nil
+	}
+	if _, ok := s.Columns[ref.Name]; !ok {
+		s.Columns[ref.Name] = &ColumnUsage{}
+	}
+	if _, ok := s.ColumnRefs[ref]; !ok {
+		s.ColumnRefs[ref] = &ColumnUsage{}
+	}
+	return []*ColumnUsage{s.Columns[ref.Name], s.ColumnRefs[ref]}
+}
+
+/*
+ResolveColumn returns the relation the column reference belongs to. A qualified column is resolved via the alias
+or the name of the relation. An unqualified column is resolved only if there is a single relation in the query,
+since the column lists of the relations are not known to find the relation in a multi-relation query.
+*/
+func (s *QueryShape) ResolveColumn(ref ColumnRef) (TableRef, bool) {
+	if ref.Qualifier == "" {
+		if len(s.Tables) == 1 {
+			return s.Tables[0], true
+		}
+		return TableRef{}, false
+	}
+	tables := lo.Filter(s.Tables, func(t TableRef, _ int) bool {
+		return t.Alias == ref.Qualifier || (t.Alias == "" && t.TableName == ref.Qualifier)
+	})
+	if len(tables) != 1 {
+		return TableRef{}, false
+	}
+	return tables[0], true
+}
+
+// RelationColumns returns the usage of the columns which are resolved to the relation, see ResolveColumn
+func (s *QueryShape) RelationColumns(schemaName string, relName string) map[string]*ColumnUsage {
+	result := make(map[string]*ColumnUsage)
+	for ref, usage := range s.ColumnRefs {
+		table, ok := s.ResolveColumn(ref)
+		if !ok || !table.Matches(schemaName, relName) {
+			continue
+		}
+		if _, ok := result[ref.Name]; !ok {
+			result[ref.Name] = &ColumnUsage{}
+		}
+		result[ref.Name].merge(usage)
+	}
+	return result
+}
+
+func (u *ColumnUsage) merge(other *ColumnUsage) {
+	u.EqualityFilter = u.EqualityFilter || other.EqualityFilter
+	u.RangeFilter = u.RangeFilter || other.RangeFilter
+	u.OrderByAsc = u.OrderByAsc || other.OrderByAsc
+	u.OrderByDesc = u.OrderByDesc || other.OrderByDesc
+}
+
+// Matches returns true if the table reference is to the relation, an unqualified reference matches any schema
+func (t TableRef) Matches(schemaName string, relName string) bool {
+	return t.TableName == strings.ToLower(relName) && (t.SchemaName == "" || t.SchemaName == strings.ToLower(schemaName))
+}
+
+// ReferencesRelation returns true if the relation(optionally schema qualified) is used in the query
+func (s *QueryShape) ReferencesRelation(schemaName string, relName string) bool {
+	relName = strings.ToLower(relName)
+	if slices.Contains(s.Relations, fmt.Sprintf("%s.%s", strings.ToLower(schemaName), relName)) {
+		return true
+	}
+	return slices.Contains(s.Relations, relName)
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strings"
 
+	pg_query "github.com/pganalyze/pg_query_go/v6"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -34,8 +35,22 @@ const (
 	TYPENAME         = "typname"
 	PLPGSQL_TYPE     = "PLpgSQL_type"
 	PLPGSQL_FUNCTION = "PLpgSQL_function"
+
+	PLPGSQL_STMT_DYNEXECUTE   = "PLpgSQL_stmt_dynexecute"
+	PLPGSQL_STMT_DYNFORS      = "PLpgSQL_stmt_dynfors"
+	PLPGSQL_STMT_OPEN         = "PLpgSQL_stmt_open"
+	PLPGSQL_STMT_RETURN_QUERY = "PLpgSQL_stmt_return_query"
+	DYNQUERY                  = "dynquery"
 )
 
+// field of the PLPGSQL statement which has the expression building the dynamic SQL string
+var dynamicSQLExprFields = map[string]string{
+	PLPGSQL_STMT_DYNEXECUTE:   QUERY,    // EXECUTE <expr> [INTO ...] [USING ...]
+	PLPGSQL_STMT_DYNFORS:      QUERY,    // FOR <target> IN EXECUTE <expr> LOOP ...
+	PLPGSQL_STMT_OPEN:         DYNQUERY, // OPEN <cursor> FOR EXECUTE <expr>
+	PLPGSQL_STMT_RETURN_QUERY: DYNQUERY, // RETURN QUERY EXECUTE <expr>
+}
+
 /*
 *
 This function is not concrete yet because of following limitation from parser -
@@ -71,6 +86,89 @@ func GetAllPLPGSQLStatements(query string) ([]string, error) {
 	return plPgSqlStatements, nil
 }
 
+/*
+GetAllDynamicSQLStatementsInPLPGSQL returns the SQL statements built as strings for EXECUTE in the PL/pgSQL code.
+This is best-effort: the string is reconstructed from the constant fragments of the expression and the non-constant
+parts (variables, function calls...) are replaced with placeholders. Statements which can't be parsed are skipped.
+
+For example:
+
+	EXECUTE 'CREATE TABLE ' || quote_ident(tbl) || '_p (id int)';   -> CREATE TABLE voyager_placeholder_p (id int);
+	EXECUTE format('SELECT pg_advisory_lock(%s) FROM %I', 1, tbl);  -> SELECT pg_advisory_lock(1) FROM voyager_placeholder;
+*/
+func GetAllDynamicSQLStatementsInPLPGSQL(query string) ([]string, error) {
+	parsedJson, parsedJsonMap, err := getParsedJsonMap(query)
+	if err != nil {
+		return []string{}, err
+	}
+
+	function := parsedJsonMap[PLPGSQL_FUNCTION]
+	parsedFunctionMap, ok := function.(map[string]interface{})
+	if !ok {
+		return []string{}, fmt.Errorf("the PlPgSQL_Function field is not a map in parsed json-%s", parsedJson)
+	}
+
+	var dynamicSQLExprs []string
+	traversePlPgSQLJsonForDynamicSQLExprs(parsedFunctionMap[ACTION], &dynamicSQLExprs)
+
+	var dynamicSQLStatements []string
+	for _, expr := range dynamicSQLExprs {
+		sql, err := BuildDynamicSQLFromExpr(expr)
+		if err != nil {
+			log.Infof("skipping the dynamic SQL expression [%s]: %v", expr, err)
+			continue
+		}
+		stmts, err := pg_query.SplitWithParser(sql, true)
+		if err != nil {
+			log.Infof("skipping the dynamic SQL [%s] built from expression [%s]: %v", sql, expr, err)
+			continue
+		}
+		for _, stmt := range stmts {
+			if stmt != "" {
+				dynamicSQLStatements = append(dynamicSQLStatements, stmt+";")
+			}
+		}
+	}
+	return dynamicSQLStatements, nil
+}
+
+func traversePlPgSQLJsonForDynamicSQLExprs(fieldValue interface{}, dynamicSQLExprs *[]string) {
+	fieldMap, isMap := fieldValue.(map[string]interface{})
+	fieldList, isList := fieldValue.([]interface{})
+	switch true {
+	case isMap:
+		for k, v := range fieldMap {
+			exprField, isDynamicSQLStmt := dynamicSQLExprFields[k]
+			stmtMap, ok := v.(map[string]interface{})
+			if isDynamicSQLStmt && ok {
+				query := getQueryFromPlPgSQLExpr(stmtMap[exprField])
+				if query != "" {
+					*dynamicSQLExprs = append(*dynamicSQLExprs, query)
+				}
+			}
+			traversePlPgSQLJsonForDynamicSQLExprs(v, dynamicSQLExprs)
+		}
+	case isList:
+		for _, l := range fieldList {
+			traversePlPgSQLJsonForDynamicSQLExprs(l, dynamicSQLExprs)
+		}
+	}
+}
+
+// returns the "query" of the {"PLpgSQL_expr": {"query": "..."}} json
+func getQueryFromPlPgSQLExpr(exprJson interface{}) string {
+	exprJsonMap, ok := exprJson.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	expr, ok := exprJsonMap[PLPGSQL_EXPR].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	query, _ := expr[QUERY].(string)
+	return query
+}
+
 /*
 Query example-
 
@@ -174,6 +272,19 @@ func TraversePlPgSQLJson(fieldValue interface{}, plPgSqlStatements *[]string) {
 	switch true {
 	case isMap:
 		for k, v := range fieldMap {
+			if exprField, ok := dynamicSQLExprFields[k]; ok {
+				// the dynamic SQL expression is not a statement in itself, refer GetAllDynamicSQLStatementsInPLPGSQL()
+				// the rest of the fields e.g. USING params and loop body are still traversed
+				stmtMap, ok := v.(map[string]interface{})
+				if ok {
+					for field, fieldVal := range stmtMap {
+						if field != exprField {
+							TraversePlPgSQLJson(fieldVal, plPgSqlStatements)
+						}
+					}
+					continue
+				}
+			}
 			switch k {
 			// base case of recursive calls to reach this PLPGSQL_EXPR field in json which will have "query" field with statement
 			case PLPGSQL_EXPR:
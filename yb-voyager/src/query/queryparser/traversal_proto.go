@@ -29,6 +29,7 @@ const (
 	PG_QUERY_ASTAR_NODE          = "pg_query.A_Star"
 	PG_QUERY_ACONST_NODE         = "pg_query.A_Const"
 	PG_QUERY_TYPECAST_NODE       = "pg_query.TypeCast"
+	PG_QUERY_TYPENAME_NODE       = "pg_query.TypeName"
 	PG_QUERY_XMLEXPR_NODE        = "pg_query.XmlExpr"
 	PG_QUERY_FUNCCALL_NODE       = "pg_query.FuncCall"
 	PG_QUERY_COLUMNREF_NODE      = "pg_query.ColumnRef"
@@ -96,6 +96,21 @@ func (s *StreamImportStatsReporter) ReportStats(ctx context.Context) {
 	}
 }
 
+// TrackStats keeps the ingestion rate and the remaining events up to date when the stats are not displayed.
+func (s *StreamImportStatsReporter) TrackStats(ctx context.Context) {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.slideWindow()
+			s.UpdateRemainingEvents()
+		}
+	}
+}
+
 func (s *StreamImportStatsReporter) refreshStats() {
 	elapsedTime := math.Round(time.Since(s.startTime).Minutes()*100) / 100
 	s.slideWindow()
@@ -171,3 +186,29 @@ func (s *StreamImportStatsReporter) UpdateRemainingEvents() {
 		s.estimatedTimeToCatchUp = time.Duration(s.remainingEvents/lastMinIngestionRate) * time.Minute
 	}
 }
+
+func (s *StreamImportStatsReporter) GetRemainingEvents() int64 {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	return s.remainingEvents
+}
+
+type StreamingStats struct {
+	TotalEventsImported    int64
+	CurrImportedEvents     int64
+	IngestionRate          int64 // events per second in the last 3 minutes
+	RemainingEvents        int64
+	EstimatedTimeToCatchUp time.Duration
+}
+
+func (s *StreamImportStatsReporter) GetStreamingStats() StreamingStats {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	return StreamingStats{
+		TotalEventsImported:    s.TotalEventsImported,
+		CurrImportedEvents:     s.CurrImportedEvents,
+		IngestionRate:          s.getIngestionRateForLastNMinutes(3) / 60,
+		RemainingEvents:        s.remainingEvents,
+		EstimatedTimeToCatchUp: s.estimatedTimeToCatchUp,
+	}
+}
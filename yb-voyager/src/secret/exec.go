@@ -0,0 +1,65 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package secret
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// exit code of the helper command when it doesn't have the secret
+const EXEC_HELPER_NOT_FOUND_EXIT_CODE = 2
+
+/*
+execProvider gets the secrets from a helper command, similar to the git credential helpers.
+The command is run by the shell as `<command> get <name>` and must print the secret on stdout.
+It must exit with EXEC_HELPER_NOT_FOUND_EXIT_CODE if it doesn't have the secret, any other non-zero exit code is an error.
+*/
+type execProvider struct {
+	command string
+}
+
+func newExecProvider(command string) *execProvider {
+	return &execProvider{command: command}
+}
+
+func (p *execProvider) String() string {
+	return fmt.Sprintf("secret helper command %q", p.command)
+}
+
+func (p *execProvider) GetSecret(name string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), FETCH_TIMEOUT)
+	defer cancel()
+	// the name is passed as an argument to the shell script instead of formatting it into the script
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", p.command+` "$@"`, "sh", "get", name)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == EXEC_HELPER_NOT_FOUND_EXIT_CODE {
+			return "", fmt.Errorf("%s from %s: %w", name, p, ErrSecretNotFound)
+		}
+		return "", fmt.Errorf("get %s from %s: %w: %s", name, p, err, strings.TrimSpace(stderr.String()))
+	}
+	// only the trailing newline printed by the helper is removed, the secret can have other whitespace
+	return strings.TrimSuffix(strings.TrimSuffix(stdout.String(), "\n"), "\r"), nil
+}
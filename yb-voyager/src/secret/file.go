@@ -0,0 +1,86 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package secret
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// fileProvider reads the secrets from a file with `<name>=<secret>` lines. Empty lines and lines starting with '#' are ignored.
+type fileProvider struct {
+	path    string
+	secrets map[string]string // read on first use
+}
+
+func newFileProvider(path string) *fileProvider {
+	return &fileProvider{path: path}
+}
+
+func (p *fileProvider) String() string {
+	return fmt.Sprintf("password file %q", p.path)
+}
+
+func (p *fileProvider) GetSecret(name string) (string, error) {
+	if p.secrets == nil {
+		secrets, err := p.read()
+		if err != nil {
+			return "", err
+		}
+		p.secrets = secrets
+	}
+	value, ok := p.secrets[name]
+	if !ok {
+		return "", fmt.Errorf("%s in %s: %w", name, p, ErrSecretNotFound)
+	}
+	return value, nil
+}
+
+func (p *fileProvider) read() (map[string]string, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", p, err)
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		return nil, fmt.Errorf("%s has permissions %#o, it must not be accessible by group or others (chmod 600 %q)",
+			p, info.Mode().Perm(), p.path)
+	}
+	content, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", p, err)
+	}
+
+	secrets := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, found := strings.Cut(line, "=")
+		if !found {
+			// not including the line in the error as it can have a secret
+			return nil, fmt.Errorf("%s: line %d: expected <name>=<secret>", p, lineNum)
+		}
+		secrets[strings.TrimSpace(name)] = value
+	}
+	return secrets, scanner.Err()
+}
@@ -0,0 +1,69 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package secret
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// names of the secrets looked up in the secret source
+const (
+	SOURCE_DB_PASSWORD         = "source-db-password"
+	TARGET_DB_PASSWORD         = "target-db-password"
+	SOURCE_REPLICA_DB_PASSWORD = "source-replica-db-password"
+	YUGABYTED_DB_PASSWORD      = "yugabyted-db-password"
+	SERVE_AUTH_TOKEN           = "serve-auth-token"
+)
+
+// timeout for fetching a secret from the helper command or the HTTP endpoint
+var FETCH_TIMEOUT = 30 * time.Second
+
+var ErrSecretNotFound = errors.New("secret not found")
+
+// Provider returns the secrets by name. The secrets must never be logged or persisted by the callers.
+type Provider interface {
+	// GetSecret returns ErrSecretNotFound if the secret is not present in the source
+	GetSecret(name string) (string, error)
+	// String describes the source in messages, it doesn't include any secret
+	String() string
+}
+
+/*
+NewProvider creates the provider from the secret source spec:
+  - file:<path>       a file with `<name>=<secret>` lines, which must not be accessible by group or others
+  - exec:<command>    a helper command invoked as `<command> get <name>` which prints the secret on stdout
+  - vault:<path>      a secret in the Vault KV secrets engine (e.g. vault:secret/yb-voyager), with keys as the secret names.
+    The server and token are taken from VAULT_ADDR (default http://127.0.0.1:8200) and VAULT_TOKEN
+*/
+func NewProvider(spec string) (Provider, error) {
+	kind, location, found := strings.Cut(spec, ":")
+	if !found || location == "" {
+		return nil, fmt.Errorf("invalid secret source %q: expected file:<path>, exec:<command> or vault:<path>", spec)
+	}
+	switch kind {
+	case "file":
+		return newFileProvider(location), nil
+	case "exec":
+		return newExecProvider(location), nil
+	case "vault":
+		return newVaultProvider(location)
+	default:
+		return nil, fmt.Errorf("invalid secret source %q: unknown type %q, expected one of file, exec, vault", spec, kind)
+	}
+}
@@ -0,0 +1,156 @@
+//go:build unit
+
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package secret
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProviderInvalidSpec(t *testing.T) {
+	for _, spec := range []string{"", "file", "file:", "env:PASSWORD", "vault:secret"} {
+		_, err := NewProvider(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "passwords")
+	content := `# voyager passwords
+source-db-password=src=pass word
+
+target-db-password = tgt
+`
+	err := os.WriteFile(path, []byte(content), 0600)
+	assert.NoError(t, err)
+
+	p, err := NewProvider("file:" + path)
+	assert.NoError(t, err)
+	password, err := p.GetSecret(SOURCE_DB_PASSWORD)
+	assert.NoError(t, err)
+	assert.Equal(t, "src=pass word", password)
+	password, err = p.GetSecret(TARGET_DB_PASSWORD)
+	assert.NoError(t, err)
+	assert.Equal(t, " tgt", password)
+	_, err = p.GetSecret(SOURCE_REPLICA_DB_PASSWORD)
+	assert.True(t, errors.Is(err, ErrSecretNotFound))
+}
+
+func TestFileProviderPermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "passwords")
+	err := os.WriteFile(path, []byte("source-db-password=secret-value\n"), 0644)
+	assert.NoError(t, err)
+
+	p, err := NewProvider("file:" + path)
+	assert.NoError(t, err)
+	_, err = p.GetSecret(SOURCE_DB_PASSWORD)
+	assert.ErrorContains(t, err, "chmod 600")
+	assert.NotContains(t, err.Error(), "secret-value")
+}
+
+func TestFileProviderInvalidLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "passwords")
+	err := os.WriteFile(path, []byte("source-db-password=x\nsecret-value\n"), 0600)
+	assert.NoError(t, err)
+
+	p, err := NewProvider("file:" + path)
+	assert.NoError(t, err)
+	_, err = p.GetSecret(SOURCE_DB_PASSWORD)
+	assert.ErrorContains(t, err, "line 2")
+	assert.NotContains(t, err.Error(), "secret-value")
+}
+
+func TestExecProvider(t *testing.T) {
+	helper := filepath.Join(t.TempDir(), "helper.sh")
+	script := `#!/bin/sh
+[ "$1" = "get" ] || exit 1
+case "$2" in
+  target-db-password) echo "tgt pass" ;;
+  yugabyted-db-password) echo "failed" >&2; exit 3 ;;
+  *) exit 2 ;;
+esac
+`
+	err := os.WriteFile(helper, []byte(script), 0700)
+	assert.NoError(t, err)
+
+	p, err := NewProvider("exec:" + helper)
+	assert.NoError(t, err)
+	password, err := p.GetSecret(TARGET_DB_PASSWORD)
+	assert.NoError(t, err)
+	assert.Equal(t, "tgt pass", password)
+	_, err = p.GetSecret(SOURCE_DB_PASSWORD)
+	assert.True(t, errors.Is(err, ErrSecretNotFound))
+	_, err = p.GetSecret(YUGABYTED_DB_PASSWORD)
+	assert.ErrorContains(t, err, "failed")
+	assert.False(t, errors.Is(err, ErrSecretNotFound))
+}
+
+func TestVaultProvider(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/yb-voyager":
+			w.Write([]byte(`{"data":{"data":{"source-db-password":"src","target-db-password":"tgt"},"metadata":{"version":1}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer server.Close()
+	t.Setenv("VAULT_ADDR", server.URL)
+
+	t.Setenv("VAULT_TOKEN", "")
+	_, err := NewProvider("vault:secret/yb-voyager")
+	assert.ErrorContains(t, err, "VAULT_TOKEN")
+
+	t.Setenv("VAULT_TOKEN", "test-token")
+	p, err := NewProvider("vault:secret/yb-voyager")
+	assert.NoError(t, err)
+	password, err := p.GetSecret(SOURCE_DB_PASSWORD)
+	assert.NoError(t, err)
+	assert.Equal(t, "src", password)
+	password, err = p.GetSecret(TARGET_DB_PASSWORD)
+	assert.NoError(t, err)
+	assert.Equal(t, "tgt", password)
+	_, err = p.GetSecret(SOURCE_REPLICA_DB_PASSWORD)
+	assert.True(t, errors.Is(err, ErrSecretNotFound))
+	assert.Equal(t, 1, requests, "secret should be read from vault once")
+
+	p, err = NewProvider("vault:secret/missing")
+	assert.NoError(t, err)
+	_, err = p.GetSecret(SOURCE_DB_PASSWORD)
+	assert.True(t, errors.Is(err, ErrSecretNotFound))
+
+	t.Setenv("VAULT_TOKEN", "wrong-token")
+	p, err = NewProvider("vault:secret/yb-voyager")
+	assert.NoError(t, err)
+	_, err = p.GetSecret(SOURCE_DB_PASSWORD)
+	assert.ErrorContains(t, err, "permission denied")
+}
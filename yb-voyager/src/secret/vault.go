@@ -0,0 +1,122 @@
+/*
+Copyright (c) YugabyteDB, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const DEFAULT_VAULT_ADDR = "http://127.0.0.1:8200"
+
+/*
+vaultProvider reads the secrets from a secret in the Vault KV secrets engine using the HTTP API.
+For the KV version 2 engine (default `secret/` mount of a Vault dev server), the path is given as <mount>/<secret path>
+e.g. secret/yb-voyager, which is read from /v1/<mount>/data/<secret path>.
+*/
+type vaultProvider struct {
+	addr       string
+	token      string
+	mount      string
+	secretPath string
+	secrets    map[string]string // read on first use
+}
+
+func newVaultProvider(path string) (*vaultProvider, error) {
+	mount, secretPath, found := strings.Cut(strings.Trim(path, "/"), "/")
+	if !found || secretPath == "" {
+		return nil, fmt.Errorf("invalid vault secret path %q: expected <mount>/<path> e.g. secret/yb-voyager", path)
+	}
+	addr := os.Getenv("VAULT_ADDR")
+	if addr == "" {
+		addr = DEFAULT_VAULT_ADDR
+	}
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("VAULT_TOKEN environment variable needs to be set to read the secrets from vault")
+	}
+	return &vaultProvider{addr: strings.TrimSuffix(addr, "/"), token: token, mount: mount, secretPath: secretPath}, nil
+}
+
+func (p *vaultProvider) String() string {
+	return fmt.Sprintf("vault secret %q at %s", p.mount+"/"+p.secretPath, p.addr)
+}
+
+func (p *vaultProvider) GetSecret(name string) (string, error) {
+	if p.secrets == nil {
+		secrets, err := p.read()
+		if err != nil {
+			return "", err
+		}
+		p.secrets = secrets
+	}
+	value, ok := p.secrets[name]
+	if !ok {
+		return "", fmt.Errorf("%s in %s: %w", name, p, ErrSecretNotFound)
+	}
+	return value, nil
+}
+
+func (p *vaultProvider) read() (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), FETCH_TIMEOUT)
+	defer cancel()
+	url := fmt.Sprintf("%s/v1/%s/data/%s", p.addr, p.mount, p.secretPath)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request for %s: %w", p, err)
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", p, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response for %s: %w", p, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return map[string]string{}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		// vault error responses don't have the secrets, only the error messages
+		return nil, fmt.Errorf("read %s: %s: %s", p, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var kvResponse struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	err = json.Unmarshal(body, &kvResponse)
+	if err != nil {
+		return nil, fmt.Errorf("parse response for %s: %w", p, err)
+	}
+	secrets := make(map[string]string)
+	for key, value := range kvResponse.Data.Data {
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: value of %q is not a string", p, key)
+		}
+		secrets[key] = s
+	}
+	return secrets, nil
+}
@@ -0,0 +1,288 @@
+#!/bin/bash
+#   Copyright (c) YugabyteDB, Inc.
+#
+#   Licensed under the Apache License, Version 2.0 (the "License");
+#   You may not use this file except in compliance with the License.
+#   You may obtain a copy of the License at
+#
+#       http://www.apache.org/licenses/LICENSE-2.0
+#
+#   Unless required by applicable law or agreed to in writing, software
+#   distributed under the License is distributed on an "AS IS" BASIS,
+#   See the License for the specific language governing permissions and
+#   limitations under the License.
+
+set -e
+
+SCRIPT_DIR=$( cd -- "$( dirname -- "${BASH_SOURCE:-$0}" )" &> /dev/null && pwd )
+SCRIPT_NAME=$(basename $0)
+
+HELP_TEXT="
+Usage: $SCRIPT_NAME <mysql_host> <mysql_port> <mysql_user> <database_name> <assessment_metadata_dir> [iops_capture_interval] [iops_capture_duration]
+
+Collects MySQL database statistics and schema information.
+Note: The order of the arguments is important and must be followed.
+
+Arguments:
+  mysql_host                  Hostname or IP address of the MySQL server.
+
+  mysql_port                  Port of the MySQL server.
+
+  mysql_user                  The user to connect to the MySQL server with.
+
+  database_name               The name of the database for which statistics are to be collected.
+
+  assessment_metadata_dir     The directory path where the assessment metadata will be stored.
+                              This script will attempt to create the directory if it does not exist.
+
+  iops_capture_interval       Configure the interval for measuring the IOPS metadata on source (in seconds). (Default 120)
+
+  iops_capture_duration       Total duration for which the IOPS metadata is sampled every iops_capture_interval (in seconds).
+                              (Default 0, i.e. only two samples iops_capture_interval apart)
+
+Example:
+  MYSQL_PWD=<password> $SCRIPT_NAME 'localhost' '3306' 'root' 'sakila' '/path/to/assessment/metadata' '60'
+
+Please ensure to replace the placeholders with actual values suited to your environment.
+"
+
+# Check for the --help option
+if [ "$1" == "--help" ]; then
+    echo "$HELP_TEXT"
+    exit 0
+fi
+
+# Check if all required arguments are provided
+if [ "$#" -lt 5 ]; then
+    echo "Usage: $0 <mysql_host> <mysql_port> <mysql_user> <database_name> <assessment_metadata_dir> [iops_capture_interval] [iops_capture_duration]"
+    exit 1
+elif [ "$#" -gt 7 ]; then
+    echo "Usage: $0 <mysql_host> <mysql_port> <mysql_user> <database_name> <assessment_metadata_dir> [iops_capture_interval] [iops_capture_duration]"
+    exit 1
+fi
+
+mysql_host=$1
+mysql_port=$2
+mysql_user=$3
+database_name=$4
+assessment_metadata_dir=$5
+iops_capture_interval=120 # default sleep for calculating iops
+if [ "$#" -ge 6 ]; then
+    iops_capture_interval=$6
+fi
+iops_capture_duration=0 # default only initial and final measurements
+if [ "$#" -eq 7 ]; then
+    iops_capture_duration=$7
+fi
+
+if [ ! -d "$assessment_metadata_dir" ]; then
+    echo "ERROR: Directory '$assessment_metadata_dir' does not exist. Please create the directory and try again."
+    exit 1
+fi
+
+LOG_FILE=$assessment_metadata_dir/yb-voyager-assessment.log
+TEMPLATE_FILE_PATH="/etc/yb-voyager/base-ora2pg.conf"
+# schema in which ora2pg exports the MySQL database objects
+TARGET_SCHEMA_NAME="public"
+log() {
+    local level="$1"
+    shift
+    local message="$@"
+    echo "[$(date -u +'%Y-%m-%d %H:%M:%S')] [$level] $message" | tee -a "$LOG_FILE" > /dev/null
+}
+
+print_and_log() {
+    local level="$1"
+    shift
+    local message="$@"
+    echo "$message"
+    log "$level" "$message"
+}
+
+# runs the command given as the arguments(not eval'd, so the user provided values are never interpreted by the shell)
+# the error returned by the command in `"$@" 2>&1 | tee -a "$LOG_FILE"` was getting ignored
+# this function checks the PIPESTATUS[0] of the first command
+run_command() {
+    # print and log the stderr/stdout of the command
+    "$@" 2>&1 | tee -a "$LOG_FILE"
+    if [ ${PIPESTATUS[0]} -ne 0 ]; then
+        print_and_log "ERROR" "command failed: $*"
+        exit 1
+    fi
+}
+
+# escapes the value to be used in a single quoted SQL string literal
+sql_quote() {
+    local value="${1//\\/\\\\}"
+    echo "'${value//\'/\'\'}'"
+}
+
+# escapes the value to be used in the replacement of the sed s||| command
+sed_escape() {
+    printf '%s' "$1" | sed -e 's/[|&\\]/\\&/g'
+}
+
+# mysql client(--batch) prints the rows as tab separated values, converting them to CSV with all the fields quoted
+tsv_to_csv() {
+    local tsv_file_path="$1"
+    local csv_file_path="$2"
+    awk 'BEGIN { FS = "\t"; OFS = "," } { for (i = 1; i <= NF; i++) { gsub(/"/, "\"\"", $i); $i = "\"" $i "\"" } print }' "$tsv_file_path" > "$csv_file_path"
+    rm -f "$tsv_file_path"
+}
+
+# runs the given script with the schema_name, target_schema_name and measurement_type session variables
+# and stores the result in the CSV file
+run_mysql_script() {
+    local script="$1"
+    local csv_file_path="$2"
+    local measurement_type="${3:-}"
+    local tsv_file_path="${csv_file_path%.csv}.tsv"
+    local sql="SET @schema_name=$(sql_quote "$database_name"); SET @target_schema_name=$(sql_quote "$TARGET_SCHEMA_NAME");"
+    sql="$sql SET @measurement_type=$(sql_quote "$measurement_type"); source $script;"
+    local mysql_command=(mysql "${mysql_connection_args[@]}" --batch --raw -e "$sql")
+    log "INFO" "executing mysql_command: ${mysql_command[*]} > $tsv_file_path"
+    if ! "${mysql_command[@]}" > "$tsv_file_path" 2> >(tee -a "$LOG_FILE" >&2); then
+        print_and_log "ERROR" "command failed: ${mysql_command[*]}"
+        exit 1
+    fi
+    tsv_to_csv "$tsv_file_path" "$csv_file_path"
+}
+
+main() {
+    # Resolve the absolute path of assessment_metadata_dir
+    assessment_metadata_dir=$(cd "$assessment_metadata_dir" && pwd)
+
+    # Switch to assessment_metadata_dir and remember the current directory
+    log "INFO" "switch to assessment_metadata_dir='$assessment_metadata_dir'"
+    pushd "$assessment_metadata_dir" > /dev/null || exit
+
+    if [ -z "$MYSQL_PWD" ]; then
+        echo -n "Enter MySQL password: "
+        read -s MYSQL_PWD
+        echo
+    fi
+    # exporting irrespective it was set or not, used by both mysql client and ora2pg
+    export MYSQL_PWD
+    export ORA2PG_PASSWD=$MYSQL_PWD
+
+    # Check for mysql client installation
+    if ! command -v mysql &> /dev/null; then
+        print_and_log "ERROR" "mysql client could not be found. Please install mysql client and try again."
+        exit 1
+    fi
+
+    mysql_connection_args=(--host="$mysql_host" --port="$mysql_port" --user="$mysql_user" --database="$database_name")
+
+    performance_schema_on=$(mysql "${mysql_connection_args[@]}" --batch --skip-column-names -e 'SELECT @@performance_schema')
+    if [ "$performance_schema_on" != "1" ]; then
+        print_and_log "WARN" "Warning: performance_schema is not enabled in the MySQL configuration."
+        echo "It's required for calculating reads/writes per second stats of tables/indexes. Do you still want to continue? (Y/N): "
+        read continue_execution
+        continue_execution=$(echo "$continue_execution" | tr '[:upper:]' '[:lower:]') # converting to lower case for easier comparison
+        if [ "$continue_execution" != "yes" ] && [ "$continue_execution" != "y" ]; then
+            print_and_log "INFO" "Exiting..."
+            exit 2
+        fi
+    fi
+
+    # the tables are exported by ora2pg without the schema(EXPORT_SCHEMA 0) i.e. in the public schema, so the metadata
+    # is reported in the same schema for it to be joined with the exported schema(e.g. the sizing and datatype issues)
+    if ! grep -qE "^EXPORT_SCHEMA[[:space:]]+0[[:space:]]*$" "$TEMPLATE_FILE_PATH"; then
+        print_and_log "ERROR" "EXPORT_SCHEMA must be 0 in $TEMPLATE_FILE_PATH for the metadata to be reported in the '$TARGET_SCHEMA_NAME' schema"
+        exit 1
+    fi
+
+    print_and_log "INFO" "Assessment metadata collection started for '$database_name' database"
+    for script in $SCRIPT_DIR/*.mysql; do # Loop through each MySQL script and execute it
+        script_name=$(basename "$script" .mysql)
+        script_action=$(basename "$script" .mysql | sed 's/-/ /g')
+        print_and_log "INFO" "Collecting $script_action..."
+
+        case $script_name in
+            "table-index-iops")
+                if [ "$performance_schema_on" != "1" ]; then
+                    print_and_log "WARN" "Skipping $script_action: performance_schema is not enabled"
+                    continue
+                fi
+                run_mysql_script "$script" "table-index-iops-initial.csv" "initial"
+
+                # sampling every iops_capture_interval for iops_capture_duration, the last sample being the final measurement
+                num_samples=$((iops_capture_duration / iops_capture_interval))
+                if [ "$num_samples" -lt 1 ]; then
+                    num_samples=1
+                fi
+                if [ "$num_samples" -gt 1 ]; then
+                    print_and_log "INFO" "Sampling IOPS every $iops_capture_interval seconds for $iops_capture_duration seconds..."
+                fi
+                for ((sample_num = 1; sample_num <= num_samples; sample_num++)); do
+                    log "INFO" "Sleeping for $iops_capture_interval seconds to capture IOPS data"
+                    # sleeping to calculate the iops reading two different time intervals, to calculate reads_per_second and writes_per_second
+                    sleep $iops_capture_interval
+
+                    measurement_type=$(printf "sample-%05d" $sample_num)
+                    if [ "$sample_num" -eq "$num_samples" ]; then
+                        measurement_type="final"
+                    fi
+                    run_mysql_script "$script" "table-index-iops-$measurement_type.csv" "$measurement_type"
+                done
+            ;;
+            *)
+                run_mysql_script "$script" "$script_name.csv"
+            ;;
+        esac
+    done
+
+    # Check for ora2pg installation
+    if ! command -v ora2pg &> /dev/null; then
+        print_and_log "ERROR" "ora2pg could not be found. Please install ora2pg and try again."
+        exit 1
+    fi
+
+    rm -rf schema && mkdir -p schema
+    print_and_log "INFO" "Collecting schema information..."
+
+    MYSQL_DSN_VALUE="dbi:mysql:host=$mysql_host;database=$database_name;port=$mysql_port"
+    DISABLE_COMMENT_VALUE="1"
+    DISABLE_PARTITION_VALUE="0"
+    USE_ORAFCE_VALUE="0"
+    DATA_TYPE_MAPPING_VALUE="VARCHAR2:varchar,NVARCHAR2:varchar,DATE:date,LONG:text,LONG RAW:bytea,CLOB:text,NCLOB:text,BLOB:bytea,BFILE:bytea,RAW(16):uuid,RAW(32):uuid,RAW:bytea,UROWID:oid,ROWID:oid,FLOAT:double precision,DEC:decimal,DECIMAL:decimal,DOUBLE PRECISION:double precision,INT:integer,INTEGER:integer,REAL:real,SMALLINT:smallint,BINARY_FLOAT:double precision,BINARY_DOUBLE:double precision,TIMESTAMP:timestamp,XMLTYPE:xml,BINARY_INTEGER:integer,PLS_INTEGER:integer,TIMESTAMP WITH TIME ZONE:timestamp with time zone,TIMESTAMP WITH LOCAL TIME ZONE:timestamp with time zone"
+
+    # Define the path to the output file
+    OUTPUT_FILE_PATH=$assessment_metadata_dir/.ora2pg.conf
+
+    log "INFO" "Generating ora2pg config file"
+    # Read the template file and replace placeholders
+    sed -e "s|{{ .OracleHome }}||g" \
+        -e "s|{{ .OracleDSN }}|$(sed_escape "$MYSQL_DSN_VALUE")|g" \
+        -e "s|{{ .OracleUser }}|$(sed_escape "$mysql_user")|g" \
+        -e "s|{{ .Schema }}|$(sed_escape "$database_name")|g" \
+        -e "s|{{ .DisableComment }}|$DISABLE_COMMENT_VALUE|g" \
+        -e "s|{{ .DisablePartition }}|$DISABLE_PARTITION_VALUE|g" \
+        -e "s|{{ .UseOrafce }}|$USE_ORAFCE_VALUE|g" \
+        -e "s|{{ .DataTypeMapping }}|$DATA_TYPE_MAPPING_VALUE|g" \
+        -e "/{{if .Allow }}/d" \
+        -e "s|{{ .Allow }}||g" \
+        -e "/{{end}}/d" \
+        "$TEMPLATE_FILE_PATH" > "$OUTPUT_FILE_PATH"
+
+    # Types to be exported, indexes are exported along with the tables
+    types=("TABLE" "VIEW" "TRIGGER" "FUNCTION" "PROCEDURE")
+    for type in "${types[@]}"; do
+        ltype=$(echo $type | tr '[:upper:]' '[:lower:]')
+        output_dir="$assessment_metadata_dir/schema/${ltype}s"
+        output_file="$ltype.sql"
+        log "INFO" "For type $type - ltype: $ltype, output_dir: $output_dir, output_file: $output_file"
+        mkdir -p "$output_dir"
+
+        ora2pg_cmd=(ora2pg -p -m -q -t "$type" -o "$output_file" -b "$output_dir" -c "$OUTPUT_FILE_PATH" --no_header)
+        log "INFO" "executing ora2pg command for type $type: ${ora2pg_cmd[*]}"
+        run_command "${ora2pg_cmd[@]}"
+    done
+
+    # Return to the original directory after operations are done
+    popd > /dev/null
+
+    print_and_log "INFO" "Assessment metadata collection completed"
+}
+
+main
@@ -40,8 +40,15 @@ Arguments:
 
   iops_capture_interval       Configure the interval for measuring the IOPS metadata on source (in seconds). (Default 120)
 
+  iops_capture_duration       Total duration for which the IOPS metadata is sampled every iops_capture_interval (in seconds).
+                              (Default 0, i.e. only two samples iops_capture_interval apart)
+
+Environment variables:
+  DATA_PROFILE_SAMPLE_PERCENT Profile the data(null ratio, distinct values, value/row widths and key skew) of the tables
+                              by sampling the given percentage of their blocks. Data profiling is skipped if not set.
+
 Example:
-  PGPASSWORD=<password> $SCRIPT_NAME 'postgresql://user@localhost:5432/mydatabase' 'public|sales' '/path/to/assessment/metadata' 'true' '60'
+  PGPASSWORD=<password> $SCRIPT_NAME 'postgresql://user@localhost:5432/mydatabase' 'public|sales' '/path/to/assessment/metadata' 'true' '60' '86400'
 
 Please ensure to replace the placeholders with actual values suited to your environment.
 "
@@ -54,10 +61,10 @@ fi
 
 # Check if all required arguments are provided
 if [ "$#" -lt 4 ]; then
-    echo "Usage: $0 <pg_connection_string> <schema_list> <assessment_metadata_dir> <pgss_enabled> [iops_capture_interval]"
+    echo "Usage: $0 <pg_connection_string> <schema_list> <assessment_metadata_dir> <pgss_enabled> [iops_capture_interval] [iops_capture_duration]"
     exit 1
-elif [ "$#" -gt 5 ]; then
-    echo "Usage: $0 <pg_connection_string> <schema_list> <assessment_metadata_dir> <pgss_enabled> [iops_capture_interval]"
+elif [ "$#" -gt 6 ]; then
+    echo "Usage: $0 <pg_connection_string> <schema_list> <assessment_metadata_dir> <pgss_enabled> [iops_capture_interval] [iops_capture_duration]"
     exit 1
 fi
 
@@ -73,11 +80,17 @@ fi
 pgss_enabled=$4
 iops_capture_interval=120 # default sleep for calculating iops
 # Override default sleep interval if a fifth argument is provided
-if [ "$#" -eq 5 ]; then
+if [ "$#" -ge 5 ]; then
     iops_capture_interval=$5
     echo "sleep interval for calculating iops: $iops_capture_interval seconds"
 fi
 
+iops_capture_duration=0 # default only initial and final measurements
+if [ "$#" -eq 6 ]; then
+    iops_capture_duration=$6
+    echo "duration for sampling iops: $iops_capture_duration seconds"
+fi
+
 
 
 LOG_FILE=$assessment_metadata_dir/yb-voyager-assessment.log
@@ -197,14 +210,28 @@ main() {
                 run_command "$psql_command"
                 mv table-index-iops.csv table-index-iops-initial.csv
 
-                log "INFO" "Sleeping for $iops_capture_interval seconds to capture IOPS data"
-                # sleeping to calculate the iops reading two different time intervals, to calculate reads_per_second and writes_per_second
-                sleep $iops_capture_interval
-
-                psql_command="psql -q $pg_connection_string -f $script -v schema_list=$schema_list -v ON_ERROR_STOP=on -v measurement_type=final"
-                log "INFO" "Executing final IOPS collection: $psql_command"
-                run_command "$psql_command"
-                mv table-index-iops.csv table-index-iops-final.csv
+                # sampling every iops_capture_interval for iops_capture_duration, the last sample being the final measurement
+                num_samples=$((iops_capture_duration / iops_capture_interval))
+                if [ "$num_samples" -lt 1 ]; then
+                    num_samples=1
+                fi
+                if [ "$num_samples" -gt 1 ]; then
+                    print_and_log "INFO" "Sampling IOPS every $iops_capture_interval seconds for $iops_capture_duration seconds..."
+                fi
+                for ((sample_num = 1; sample_num <= num_samples; sample_num++)); do
+                    log "INFO" "Sleeping for $iops_capture_interval seconds to capture IOPS data"
+                    # sleeping to calculate the iops reading two different time intervals, to calculate reads_per_second and writes_per_second
+                    sleep $iops_capture_interval
+
+                    measurement_type=$(printf "sample-%05d" $sample_num)
+                    if [ "$sample_num" -eq "$num_samples" ]; then
+                        measurement_type="final"
+                    fi
+                    psql_command="psql -q $pg_connection_string -f $script -v schema_list=$schema_list -v ON_ERROR_STOP=on -v measurement_type=$measurement_type"
+                    log "INFO" "Executing $measurement_type IOPS collection: $psql_command"
+                    run_command "$psql_command"
+                    mv table-index-iops.csv table-index-iops-$measurement_type.csv
+                done
             ;;
             "db-queries-summary")
                 if [[ "$REPORT_UNSUPPORTED_QUERY_CONSTRUCTS" == "false" ]]; then
@@ -222,6 +249,16 @@ main() {
                 log "INFO" "Executing script: $psql_command"
                 run_command "$psql_command"
             ;;
+            "data-profile")
+                if [[ -z "$DATA_PROFILE_SAMPLE_PERCENT" ]]; then
+                    print_and_log "INFO" "Skipping $script_action: data profiling is not enabled"
+                    continue
+                fi
+
+                psql_command="psql -q $pg_connection_string -f $script -v schema_list=$schema_list -v sample_percent=$DATA_PROFILE_SAMPLE_PERCENT -v ON_ERROR_STOP=on"
+                log "INFO" "Executing script: $psql_command"
+                run_command "$psql_command"
+            ;;
             *)
                 psql_command="psql -q $pg_connection_string -f $script -v schema_list=$schema_list -v ON_ERROR_STOP=on"
                 log "INFO" "Executing script: $psql_command"
@@ -20,10 +20,13 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/go-sql-driver/mysql"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
@@ -39,7 +42,7 @@ type MySQL struct {
 	db *sql.DB
 }
 
-var mysqlUnsupportedDataTypes = []string{"TINYBLOB", "BLOB", "MEDIUMBLOB", "LONGBLOB"}
+var MysqlUnsupportedDataTypes = []string{"TINYBLOB", "BLOB", "MEDIUMBLOB", "LONGBLOB"}
 
 func newMySQL(s *Source) *MySQL {
 	return &MySQL{source: s}
@@ -347,7 +350,7 @@ func (ms *MySQL) GetColumnsWithSupportedTypes(tableList []sqlname.NameTuple, use
 			//Using this ContainsAnyStringFromSlice as the catalog we use for fetching datatypes uses the data_type only
 			// which just contains the base type for example VARCHARs it won't include any length, precision or scale information
 			//of these types there are other columns available for these information so we just do string match of types with our list
-			if utils.ContainsAnyStringFromSlice(mysqlUnsupportedDataTypes, dataTypes[i]) {
+			if utils.ContainsAnyStringFromSlice(MysqlUnsupportedDataTypes, dataTypes[i]) {
 				log.Infof("Skipping unsupproted column %s.%s of type %s", tname, columns[i], dataTypes[i])
 				unsupportedColumnNames = append(unsupportedColumnNames, fmt.Sprintf("%s.%s of type %s", tname, columns[i], dataTypes[i]))
 			} else {
@@ -538,8 +541,35 @@ func (ms *MySQL) CheckIfReplicationSlotsAreAvailable() (isAvailable bool, usedCo
 	return false, 0, 0, nil
 }
 
+// performance_schema is required for the reads/writes per second and mysql.innodb_index_stats for the index sizes
+var mysqlAssessMigrationRequiredTables = []string{
+	"performance_schema.table_io_waits_summary_by_table",
+	"performance_schema.table_io_waits_summary_by_index_usage",
+	"mysql.innodb_index_stats",
+}
+
 func (ms *MySQL) GetMissingAssessMigrationPermissions() ([]string, bool, error) {
-	return nil, false, nil
+	var missingTables []string
+	for _, table := range mysqlAssessMigrationRequiredTables {
+		query := fmt.Sprintf("SELECT 1 FROM %s LIMIT 1", table)
+		rows, err := ms.db.Query(query)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			// ER_TABLEACCESS_DENIED_ERROR
+			if errors.As(err, &mysqlErr) && mysqlErr.Number == 1142 {
+				missingTables = append(missingTables, table)
+				continue
+			}
+			return nil, false, fmt.Errorf("error checking select permission on %s: %w", table, err)
+		}
+		rows.Close()
+	}
+
+	var result []string
+	if len(missingTables) > 0 {
+		result = append(result, fmt.Sprintf("\n%s[%s]", color.RedString("Missing SELECT permission for user %s on Tables: ", ms.source.User), strings.Join(missingTables, ", ")))
+	}
+	return result, false, nil
 }
 
 func (ms *MySQL) GetSchemasMissingUsagePermissions() ([]string, error) {
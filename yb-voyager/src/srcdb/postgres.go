@@ -1164,7 +1164,11 @@ func (pg *PostgreSQL) GetMissingAssessMigrationPermissions() ([]string, bool, er
 	pgssEnabled := true
 	if result != "" {
 		pgssEnabled = false
-		combinedResult = append(combinedResult, result)
+		if len(pg.source.DBLogFiles) > 0 {
+			log.Infof("ignoring the pg_stat_statements setup issue since the queries are collected from the log files: %s", result)
+		} else {
+			combinedResult = append(combinedResult, result)
+		}
 	}
 	return combinedResult, pgssEnabled, nil
 }
@@ -60,6 +60,7 @@ type Source struct {
 	RunGuardrailsChecks      utils.BoolStr `json:"run_guardrails_checks"`
 
 	ExportObjectTypeList []string `json:"-"`
+	DBLogFiles           []string `json:"-"` // server log files to collect the executed queries from, instead of pg_stat_statements
 	sourceDB             SourceDB `json:"-"`
 }
 
@@ -108,6 +108,7 @@ type AnalyzeSchemaIssue struct {
 	Type                   string                          `json:"-" xml:"-"` // identifier for issue type ADVISORY_LOCKS, SYSTEM_COLUMNS, etc
 	Impact                 string                          `json:"-" xml:"-"` // temporary field; since currently we generate assessment issue from analyze issue
 	SqlStatement           string                          `json:"SqlStatement,omitempty"`
+	IsDynamicSQL           bool                            `json:"IsDynamicSQL,omitempty" xml:"IsDynamicSQL,omitempty"` // SqlStatement is built as a string for EXECUTE in PL/pgSQL
 	FilePath               string                          `json:"FilePath"`
 	Suggestion             string                          `json:"Suggestion"`
 	GH                     string                          `json:"GH"`
@@ -645,9 +645,9 @@ func ConnectToSqliteDatabase(dbPath string) (*sql.DB, error) {
 	// TODO: add retry logic
 	err = db.Ping()
 	if err != nil {
-		err := db.Close()
-		if err != nil {
-			return nil, err
+		closeErr := db.Close()
+		if closeErr != nil {
+			return nil, closeErr
 		}
 		return nil, err
 	}